@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"github.com/krobus00/storage-service/internal/bootstrap"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/spf13/cobra"
+)
+
+// objectsCmd represents the objects command.
+var objectsCmd = &cobra.Command{
+	Use:   "objects",
+	Short: "inspect and manage stored objects",
+	Long:  `inspect and manage stored objects`,
+}
+
+var objectsGetCmd = &cobra.Command{
+	Use:   "get [id]",
+	Short: "get object by id",
+	Long:  `get object by id`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectCommand("get", args[0], tenantID, output)
+	},
+}
+
+var objectsDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "delete object by id",
+	Long:  `delete object by id`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectCommand("delete", args[0], tenantID, output)
+	},
+}
+
+var objectsPresignCmd = &cobra.Command{
+	Use:   "presign [id]",
+	Short: "generate presigned url for object",
+	Long:  `generate presigned url for object`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectCommand("presign", args[0], tenantID, output)
+	},
+}
+
+var objectsGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "purge expired temporary objects without references",
+	Long:  `purge expired temporary objects without references and retry the deletion of blobs left behind by failed uploads`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		bootstrap.StartObjectGCCommand(dryRun, output)
+	},
+}
+
+var objectsReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "compare object rows with the bucket listings and delete orphan blobs",
+	Long:  `compare object rows with the bucket listings, report rows whose content is missing and delete blobs without a row older than the grace period`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		bootstrap.StartObjectReconcileCommand(dryRun, output)
+	},
+}
+
+var objectsVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "re-read stored objects and report missing or corrupted content",
+	Long:  `re-read stored objects, compare their size and sha256 with the recorded ones and report missing or corrupted content`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		bootstrap.StartObjectVerifyCommand(output)
+	},
+}
+
+var objectsRewrapCmd = &cobra.Command{
+	Use:   "rewrap",
+	Short: "rewrap data keys with the active encryption key",
+	Long:  `wrap the data keys of encrypted objects that are still under a retired master key with the active one, the retired key can be removed from the config afterwards`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		bootstrap.StartObjectRewrapCommand(dryRun, output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(objectsCmd)
+	objectsCmd.PersistentFlags().StringP("output", "o", bootstrap.OutputTable, "output table|json")
+	for _, c := range []*cobra.Command{objectsGetCmd, objectsDeleteCmd, objectsPresignCmd} {
+		c.Flags().String("tenant", constant.DefaultTenantID, "tenant to operate on")
+	}
+	// the gc is a system job and sweeps every tenant
+	objectsGCCmd.Flags().Bool("dry-run", false, "only list the objects that would be purged")
+	objectsReconcileCmd.Flags().Bool("dry-run", false, "only report, keep the orphan blobs")
+	objectsRewrapCmd.Flags().Bool("dry-run", false, "only count the data keys that would be rewrapped")
+	objectsCmd.AddCommand(objectsGetCmd, objectsDeleteCmd, objectsPresignCmd, objectsGCCmd, objectsReconcileCmd, objectsVerifyCmd, objectsRewrapCmd)
+}
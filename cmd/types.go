@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"github.com/krobus00/storage-service/internal/bootstrap"
+	"github.com/krobus00/storage-service/internal/constant"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// typesCmd represents the types command.
+var typesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "manage object types",
+	Long:  `manage object types`,
+}
+
+var typesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list object types",
+	Long:  `list object types`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectTypeCommand("list", "", nil, tenantID, output)
+	},
+}
+
+var typesCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "create object type",
+	Long:  `create object type`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectTypeCommand("create", args[0], routingFlags(cmd), tenantID, output)
+	},
+}
+
+var typesUpdateCmd = &cobra.Command{
+	Use:   "update [name]",
+	Short: "update object type routing, stored objects keep their bucket and key",
+	Long:  `update object type routing, stored objects keep their bucket and key`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectTypeCommand("update", args[0], routingFlags(cmd), tenantID, output)
+	},
+}
+
+var typesDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "delete object type and every object of that type",
+	Long:  `delete object type and every object of that type`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !yes {
+			log.Fatalln(bootstrap.ErrNotConfirmed.Error())
+		}
+
+		bootstrap.StartObjectTypeCommand("delete", args[0], nil, tenantID, output)
+	},
+}
+
+// routingFlags collects the routing flags that were set.
+func routingFlags(cmd *cobra.Command) *bootstrap.ObjectTypeRouting {
+	changed := func(name string) *string {
+		if !cmd.Flags().Changed(name) {
+			return nil
+		}
+		value, _ := cmd.Flags().GetString(name)
+		return &value
+	}
+	routing := &bootstrap.ObjectTypeRouting{
+		Bucket:       changed("bucket"),
+		KeyTemplate:  changed("key-template"),
+		StorageClass: changed("storage-class"),
+		CacheControl: changed("cache-control"),
+	}
+	if cmd.Flags().Changed("encrypted") {
+		encrypted, _ := cmd.Flags().GetBool("encrypted")
+		routing.Encrypted = &encrypted
+	}
+	return routing
+}
+
+func init() {
+	rootCmd.AddCommand(typesCmd)
+	typesCmd.PersistentFlags().StringP("output", "o", bootstrap.OutputTable, "output table|json")
+	typesCmd.PersistentFlags().String("tenant", constant.DefaultTenantID, "tenant to operate on")
+	for _, cmd := range []*cobra.Command{typesCreateCmd, typesUpdateCmd} {
+		cmd.Flags().String("bucket", "", "bucket of the type, empty for the tenant bucket")
+		cmd.Flags().String("key-template", "", "object key template, e.g. {type}/{yyyy}/{mm}/{uuid}{ext}")
+		cmd.Flags().String("storage-class", "", "S3 storage class of uploaded objects")
+		cmd.Flags().String("cache-control", "", "Cache-Control of uploaded objects")
+		cmd.Flags().Bool("encrypted", false, "envelope encrypt uploaded objects, they can not be presigned")
+	}
+	typesDeleteCmd.Flags().Bool("yes", false, "confirm the deletion of the type and all its objects")
+	typesCmd.AddCommand(typesListCmd, typesCreateCmd, typesUpdateCmd, typesDeleteCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/krobus00/storage-service/internal/bootstrap"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/spf13/cobra"
+)
+
+// usageCmd represents the usage command.
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "inspect and maintain storage usage accounting",
+	Long:  `inspect and maintain storage usage accounting`,
+}
+
+var usageRecomputeCmd = &cobra.Command{
+	Use:   "recompute [user-id]",
+	Short: "rebuild the storage usage from the stored objects, every user when no id is given",
+	Long:  `rebuild the storage usage from the stored objects, every user when no id is given`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+		userID := ""
+		if len(args) > 0 {
+			userID = args[0]
+		}
+
+		bootstrap.StartUsageRecomputeCommand(userID, tenantID, output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+	usageCmd.PersistentFlags().StringP("output", "o", bootstrap.OutputTable, "output table|json")
+	usageRecomputeCmd.Flags().String("tenant", constant.DefaultTenantID, "tenant of the user, ignored when every user is recomputed")
+	usageCmd.AddCommand(usageRecomputeCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/krobus00/storage-service/internal/bootstrap"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/spf13/cobra"
+)
+
+// whitelistCmd represents the whitelist command.
+var whitelistCmd = &cobra.Command{
+	Use:   "whitelist",
+	Short: "manage allowed extensions per object type",
+	Long:  `manage allowed extensions per object type`,
+}
+
+var whitelistAddCmd = &cobra.Command{
+	Use:   "add [type] [extension]",
+	Short: "allow extension for object type",
+	Long:  `allow extension for object type`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectWhitelistTypeCommand("add", args[0], args[1], tenantID, output)
+	},
+}
+
+var whitelistRemoveCmd = &cobra.Command{
+	Use:   "remove [type] [extension]",
+	Short: "disallow extension for object type",
+	Long:  `disallow extension for object type`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		tenantID, _ := cmd.Flags().GetString("tenant")
+
+		bootstrap.StartObjectWhitelistTypeCommand("remove", args[0], args[1], tenantID, output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whitelistCmd)
+	whitelistCmd.PersistentFlags().StringP("output", "o", bootstrap.OutputTable, "output table|json")
+	whitelistCmd.PersistentFlags().String("tenant", constant.DefaultTenantID, "tenant to operate on")
+	whitelistCmd.AddCommand(whitelistAddCmd, whitelistRemoveCmd)
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS object_grants (
+    id varchar(36) PRIMARY KEY,
+    object_id varchar(36) NOT NULL,
+    grantee_type varchar(10) NOT NULL,
+    grantee_id varchar(36) NOT NULL,
+    permission varchar(10) NOT NULL,
+    granted_by varchar(36) NOT NULL,
+    expired_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_object FOREIGN KEY(object_id) REFERENCES objects(id) ON DELETE CASCADE,
+    CONSTRAINT uniq_object_grantee_permission UNIQUE (object_id, grantee_type, grantee_id, permission)
+);
+CREATE INDEX IF NOT EXISTS idx_object_grants_grantee ON object_grants (grantee_type, grantee_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS object_grants;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- no foreign key on object_id, the trail must outlive the object
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id varchar(36) PRIMARY KEY,
+    actor_id varchar(36) NOT NULL,
+    object_id varchar(36) NOT NULL,
+    action varchar(20) NOT NULL,
+    outcome varchar(20) NOT NULL,
+    client_ip varchar(45) NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    trace_id varchar(32) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_object_id_created_at ON audit_logs (object_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id_created_at ON audit_logs (actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_logs;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS share_links (
+    id varchar(36) PRIMARY KEY,
+    object_id varchar(36) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    password_hash varchar(60) NULL,
+    max_downloads integer NOT NULL DEFAULT 0,
+    download_count integer NOT NULL DEFAULT 0,
+    created_by varchar(36) NOT NULL,
+    expired_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_object FOREIGN KEY(object_id) REFERENCES objects(id) ON DELETE CASCADE,
+    CONSTRAINT uniq_share_links_token_hash UNIQUE (token_hash)
+);
+CREATE INDEX IF NOT EXISTS idx_share_links_object_id ON share_links (object_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS share_links;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS original_file_name varchar(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE objects DROP COLUMN IF EXISTS original_file_name;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS metadata jsonb NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE objects DROP COLUMN IF EXISTS metadata;
+ALTER TABLE objects DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS object_references (
+    id varchar(36) PRIMARY KEY,
+    object_id varchar(36) NOT NULL,
+    owner_service varchar(64) NOT NULL,
+    entity_type varchar(64) NOT NULL,
+    entity_id varchar(128) NOT NULL,
+    created_by varchar(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_object FOREIGN KEY(object_id) REFERENCES objects(id) ON DELETE CASCADE,
+    CONSTRAINT uniq_object_references UNIQUE (object_id, owner_service, entity_type, entity_id)
+);
+CREATE INDEX IF NOT EXISTS idx_object_references_entity ON object_references (owner_service, entity_type, entity_id);
+
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS temporary_until TIMESTAMP NULL;
+CREATE INDEX IF NOT EXISTS idx_objects_temporary_until ON objects (temporary_until) WHERE temporary_until IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_objects_temporary_until;
+ALTER TABLE objects DROP COLUMN IF EXISTS temporary_until;
+DROP TABLE IF EXISTS object_references;
+-- +goose StatementEnd
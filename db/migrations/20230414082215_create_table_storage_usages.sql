@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS size bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS storage_usages (
+    user_id varchar(36) NOT NULL,
+    type_id varchar(36) NOT NULL,
+    bytes bigint NOT NULL DEFAULT 0,
+    objects bigint NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS storage_usages;
+ALTER TABLE objects DROP COLUMN IF EXISTS size;
+-- +goose StatementEnd
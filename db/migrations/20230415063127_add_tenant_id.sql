@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE object_types DROP CONSTRAINT IF EXISTS object_types_name_key;
+ALTER TABLE object_types ADD CONSTRAINT uniq_tenant_id_and_name UNIQUE (tenant_id, name);
+
+ALTER TABLE object_whitelist_types ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_objects_tenant_id_created_at ON objects (tenant_id, created_at DESC);
+
+ALTER TABLE share_links ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE share_links DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS idx_objects_tenant_id_created_at;
+ALTER TABLE objects DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE object_whitelist_types DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE object_types DROP CONSTRAINT IF EXISTS uniq_tenant_id_and_name;
+ALTER TABLE object_types ADD CONSTRAINT object_types_name_key UNIQUE (name);
+ALTER TABLE object_types DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
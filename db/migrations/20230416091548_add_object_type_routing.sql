@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS bucket varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS key_template varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS storage_class varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS cache_control varchar(255) NOT NULL DEFAULT '';
+
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS bucket varchar(255) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE objects DROP COLUMN IF EXISTS bucket;
+
+ALTER TABLE object_types DROP COLUMN IF EXISTS cache_control;
+ALTER TABLE object_types DROP COLUMN IF EXISTS storage_class;
+ALTER TABLE object_types DROP COLUMN IF EXISTS key_template;
+ALTER TABLE object_types DROP COLUMN IF EXISTS bucket;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS idempotency_key varchar(255) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_objects_idempotency_key ON objects (tenant_id, uploaded_by, idempotency_key) WHERE idempotency_key <> '';
+
+CREATE TABLE IF NOT EXISTS pending_deletions (
+    id varchar(36) PRIMARY KEY,
+    bucket varchar(255) NOT NULL,
+    key text NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    attempts int NOT NULL DEFAULT 0,
+    last_error text NOT NULL DEFAULT '',
+    created_at timestamp NOT NULL DEFAULT NOW(),
+    updated_at timestamp NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_pending_deletions_updated_at ON pending_deletions (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pending_deletions;
+
+DROP INDEX IF EXISTS uniq_objects_idempotency_key;
+ALTER TABLE objects DROP COLUMN IF EXISTS idempotency_key;
+-- +goose StatementEnd
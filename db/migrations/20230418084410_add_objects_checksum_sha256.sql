@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS checksum_sha256 varchar(64) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE objects DROP COLUMN IF EXISTS checksum_sha256;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE object_types ADD COLUMN IF NOT EXISTS encrypted boolean NOT NULL DEFAULT false;
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS encryption_key_id varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE objects ADD COLUMN IF NOT EXISTS wrapped_data_key text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE objects DROP COLUMN IF EXISTS wrapped_data_key;
+ALTER TABLE objects DROP COLUMN IF EXISTS encryption_key_id;
+ALTER TABLE object_types DROP COLUMN IF EXISTS encrypted;
+-- +goose StatementEnd
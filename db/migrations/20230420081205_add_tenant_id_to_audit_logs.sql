@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_id_created_at ON audit_logs (tenant_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_logs_tenant_id_created_at;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
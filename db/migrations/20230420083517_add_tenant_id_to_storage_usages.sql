@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE storage_usages ADD COLUMN IF NOT EXISTS tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE storage_usages DROP CONSTRAINT IF EXISTS storage_usages_pkey;
+ALTER TABLE storage_usages ADD PRIMARY KEY (tenant_id, user_id, type_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE storage_usages DROP CONSTRAINT IF EXISTS storage_usages_pkey;
+DELETE FROM storage_usages WHERE tenant_id <> 'default';
+ALTER TABLE storage_usages ADD PRIMARY KEY (user_id, type_id);
+ALTER TABLE storage_usages DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
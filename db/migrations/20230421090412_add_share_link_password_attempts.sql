@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE share_links ADD COLUMN IF NOT EXISTS failed_attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE share_links ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE share_links DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE share_links DROP COLUMN IF EXISTS failed_attempts;
+-- +goose StatementEnd
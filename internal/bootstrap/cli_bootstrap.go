@@ -0,0 +1,559 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/infrastructure"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/repository"
+	"github.com/krobus00/storage-service/internal/usecase"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/nats-io/nats.go"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+var (
+	ErrInvalidCommand = errors.New("invalid command")
+	ErrInvalidOutput  = errors.New("invalid output, use table|json")
+	ErrNotConfirmed   = errors.New("this deletes every object of the type, pass --yes to confirm")
+)
+
+type cliDependencies struct {
+	objectRepo              model.ObjectRepository
+	objectTypeRepo          model.ObjectTypeRepository
+	objectWhitelistTypeRepo model.ObjectWhitelistTypeRepository
+	storageUsageRepo        model.StorageUsageRepository
+	pendingDeletionRepo     model.PendingDeletionRepository
+	keyring                 model.Keyring
+}
+
+// initCLIDependencies wires the repositories against the configured database, redis and s3
+// so the cli commands go through the same code path (and cache invalidation) as the server.
+func initCLIDependencies(output string) *cliDependencies {
+	infrastructure.InitializeDBConn()
+	if output == OutputJSON {
+		// gorm logs queries to stdout, keep it clean for scripts
+		infrastructure.DB.Logger = infrastructure.DB.Logger.LogMode(gormLogger.Silent)
+	}
+
+	redisClient, err := infrastructure.NewRedisClient()
+	continueOrFatal(err)
+
+	cache, err := infrastructure.NewCache(redisClient)
+	continueOrFatal(err)
+
+	s3Client, err := infrastructure.NewS3Client()
+	continueOrFatal(err)
+
+	keyring, err := infrastructure.NewLocalKeyring()
+	continueOrFatal(err)
+
+	objectRepo := repository.NewObjectRepository()
+	err = objectRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+	err = objectRepo.InjectS3Client(s3Client)
+	continueOrFatal(err)
+	err = objectRepo.InjectCache(cache)
+	continueOrFatal(err)
+	err = objectRepo.InjectKeyring(keyring)
+	continueOrFatal(err)
+
+	objectTypeRepo := repository.NewObjectTypeRepository()
+	err = objectTypeRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+	err = objectTypeRepo.InjectCache(cache)
+	continueOrFatal(err)
+
+	objectWhitelistTypeRepo := repository.NewObjectWhitelistTypeRepository()
+	err = objectWhitelistTypeRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+	err = objectWhitelistTypeRepo.InjectCache(cache)
+	continueOrFatal(err)
+
+	storageUsageRepo := repository.NewStorageUsageRepository()
+	err = storageUsageRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	pendingDeletionRepo := repository.NewPendingDeletionRepository()
+	err = pendingDeletionRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	return &cliDependencies{
+		objectRepo:              objectRepo,
+		objectTypeRepo:          objectTypeRepo,
+		objectWhitelistTypeRepo: objectWhitelistTypeRepo,
+		storageUsageRepo:        storageUsageRepo,
+		pendingDeletionRepo:     pendingDeletionRepo,
+		keyring:                 keyring,
+	}
+}
+
+// newCLIObjectUsecase wires the object usecase for the operator commands, they skip the
+// access checks so no auth client is needed.
+func newCLIObjectUsecase(deps *cliDependencies, js nats.JetStreamContext) model.ObjectUsecase {
+	auditLogRepo := repository.NewAuditLogRepository()
+	continueOrFatal(auditLogRepo.InjectDB(infrastructure.DB))
+
+	auditLogUsecase := usecase.NewAuditLogUsecase()
+	continueOrFatal(auditLogUsecase.InjectAuditLogRepo(auditLogRepo))
+	continueOrFatal(auditLogUsecase.InjectJetstreamClient(js))
+
+	objectUsecase := usecase.NewObjectUsecase()
+	continueOrFatal(objectUsecase.InjectObjectRepo(deps.objectRepo))
+	continueOrFatal(objectUsecase.InjectObjectTypeRepo(deps.objectTypeRepo))
+	continueOrFatal(objectUsecase.InjectPendingDeletionRepo(deps.pendingDeletionRepo))
+	continueOrFatal(objectUsecase.InjectJetstreamClient(js))
+	continueOrFatal(objectUsecase.InjectAuditLogUsecase(auditLogUsecase))
+	return objectUsecase
+}
+
+func newCLIContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), config.GracefulShutdownTimeOut())
+}
+
+// newTenantCLIContext scopes the command to the tenant like a request of that tenant.
+func newTenantCLIContext(tenantID string) (context.Context, context.CancelFunc) {
+	continueOrFatal(validateTenant(tenantID))
+
+	ctx, cancel := newCLIContext()
+	return utils.NewTenantContext(ctx, tenantID), cancel
+}
+
+// ObjectTypeRouting holds the routing settings given on the command line, nil fields are left unchanged.
+type ObjectTypeRouting struct {
+	Bucket       *string
+	KeyTemplate  *string
+	StorageClass *string
+	CacheControl *string
+	Encrypted    *bool
+}
+
+func (r *ObjectTypeRouting) applyTo(objectType *model.ObjectType) {
+	if r == nil {
+		return
+	}
+	if r.Bucket != nil {
+		objectType.Bucket = *r.Bucket
+	}
+	if r.KeyTemplate != nil {
+		objectType.KeyTemplate = *r.KeyTemplate
+	}
+	if r.StorageClass != nil {
+		objectType.StorageClass = *r.StorageClass
+	}
+	if r.CacheControl != nil {
+		objectType.CacheControl = *r.CacheControl
+	}
+	if r.Encrypted != nil {
+		objectType.Encrypted = *r.Encrypted
+	}
+}
+
+func objectTypeRow(objectType *model.ObjectType) []string {
+	return []string{objectType.ID, objectType.Name, objectType.Bucket, objectType.KeyTemplate, objectType.StorageClass, objectType.CacheControl, strconv.FormatBool(objectType.Encrypted)}
+}
+
+var objectTypeHeaders = []string{"ID", "NAME", "BUCKET", "KEY TEMPLATE", "STORAGE CLASS", "CACHE CONTROL", "ENCRYPTED"}
+
+// purgeBatchSize is the number of objects of a deleted type purged per page.
+const purgeBatchSize = 100
+
+func StartObjectTypeCommand(action string, name string, routing *ObjectTypeRouting, tenantID string, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newTenantCLIContext(tenantID)
+	defer cancel()
+
+	switch action {
+	case "list":
+		objectTypes, err := deps.objectTypeRepo.FindAll(ctx)
+		continueOrFatal(err)
+
+		res := make([]*model.HTTPObjectTypeResponse, 0, len(objectTypes))
+		rows := make([][]string, 0, len(objectTypes))
+		for _, objectType := range objectTypes {
+			res = append(res, objectType.ToHTTPResponse())
+			rows = append(rows, objectTypeRow(objectType))
+		}
+		printOutput(output, res, objectTypeHeaders, rows)
+	case "create":
+		objectType := &model.ObjectType{
+			ID:   utils.GenerateUUID(),
+			Name: name,
+		}
+		routing.applyTo(objectType)
+		continueOrFatal(objectType.Validate())
+		err := deps.objectTypeRepo.Create(ctx, objectType)
+		continueOrFatal(err)
+
+		printOutput(output, objectType.ToHTTPResponse(), objectTypeHeaders, [][]string{objectTypeRow(objectType)})
+	case "update":
+		objectType := findObjectTypeOrFatal(ctx, deps, name)
+		routing.applyTo(objectType)
+		continueOrFatal(objectType.Validate())
+		err := deps.objectTypeRepo.Update(ctx, objectType)
+		continueOrFatal(err)
+
+		printOutput(output, objectType.ToHTTPResponse(), objectTypeHeaders, [][]string{objectTypeRow(objectType)})
+	case "delete":
+		objectType := findObjectTypeOrFatal(ctx, deps, name)
+
+		nc, js, err := infrastructure.NewJetstreamClient()
+		continueOrFatal(err)
+		defer func() {
+			_ = infrastructure.DrainJetstream(ctx, nc)
+		}()
+
+		// the cascade would only drop the rows, every object is purged first so its
+		// content, storage usage, events and cache go with it
+		objectUsecase := newCLIObjectUsecase(deps, js)
+		systemCtx := context.WithValue(ctx, constant.KeyUserIDCtx, constant.SystemID)
+		afterID := ""
+		for {
+			objects, err := deps.objectRepo.FindByTypeID(ctx, objectType.ID, afterID, purgeBatchSize)
+			continueOrFatal(err)
+			for _, object := range objects {
+				_, err = objectUsecase.PurgeObject(systemCtx, object.ID)
+				continueOrFatal(err)
+			}
+			if len(objects) < purgeBatchSize {
+				break
+			}
+			afterID = objects[len(objects)-1].ID
+		}
+
+		err = deps.objectTypeRepo.DeleteByID(ctx, objectType.ID)
+		continueOrFatal(err)
+
+		printOutput(output, objectType.ToHTTPResponse(), objectTypeHeaders, [][]string{objectTypeRow(objectType)})
+	default:
+		continueOrFatal(ErrInvalidCommand)
+	}
+}
+
+func StartObjectWhitelistTypeCommand(action string, typeName string, ext string, tenantID string, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newTenantCLIContext(tenantID)
+	defer cancel()
+
+	objectType := findObjectTypeOrFatal(ctx, deps, typeName)
+	whitelist := &model.ObjectWhitelistType{
+		TypeID:    objectType.ID,
+		Extension: normalizeExtension(ext),
+	}
+
+	switch action {
+	case "add":
+		err := deps.objectWhitelistTypeRepo.Create(ctx, whitelist)
+		continueOrFatal(err)
+	case "remove":
+		existing, err := deps.objectWhitelistTypeRepo.FindByTypeIDAndExt(ctx, whitelist.TypeID, whitelist.Extension)
+		continueOrFatal(err)
+		if existing == nil {
+			continueOrFatal(model.ErrExtensionNotAllowed)
+		}
+		err = deps.objectWhitelistTypeRepo.DeleteByTypeIDAndExt(ctx, whitelist.TypeID, whitelist.Extension)
+		continueOrFatal(err)
+	default:
+		continueOrFatal(ErrInvalidCommand)
+	}
+
+	printOutput(output, whitelist.ToHTTPResponse(), []string{"TYPE", "TYPE ID", "EXTENSION"}, [][]string{
+		{objectType.Name, whitelist.TypeID, whitelist.Extension},
+	})
+}
+
+func StartObjectCommand(action string, id string, tenantID string, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newTenantCLIContext(tenantID)
+	defer cancel()
+
+	object, err := deps.objectRepo.FindByID(ctx, id)
+	continueOrFatal(err)
+	if object == nil {
+		continueOrFatal(model.ErrObjectNotFound)
+	}
+
+	objectType, err := deps.objectTypeRepo.FindByID(ctx, object.TypeID)
+	continueOrFatal(err)
+	if objectType != nil {
+		object.SetType(objectType.Name)
+	}
+
+	objectHeaders := []string{"ID", "FILENAME", "KEY", "TYPE", "PUBLIC", "UPLOADED BY", "CREATED AT"}
+	objectRows := [][]string{{
+		object.ID,
+		object.FileName,
+		object.Key,
+		object.Type,
+		fmt.Sprintf("%t", object.IsPublic),
+		object.UploadedBy,
+		object.CreatedAt.UTC().Format(time.RFC3339),
+	}}
+
+	switch action {
+	case "get":
+		printOutput(output, object.ToHTTPResponse(), objectHeaders, objectRows)
+	case "delete":
+		nc, js, err := infrastructure.NewJetstreamClient()
+		continueOrFatal(err)
+		defer func() {
+			_ = infrastructure.DrainJetstream(ctx, nc)
+		}()
+
+		// operators act as the system, the delete is audited and published like one of a user
+		systemCtx := context.WithValue(ctx, constant.KeyUserIDCtx, constant.SystemID)
+		_, err = newCLIObjectUsecase(deps, js).PurgeObject(systemCtx, object.ID)
+		continueOrFatal(err)
+
+		printOutput(output, object.ToHTTPResponse(), objectHeaders, objectRows)
+	case "presign":
+		presignedObject, err := deps.objectRepo.GeneratePresignedURL(ctx, object, 0)
+		continueOrFatal(err)
+
+		res := presignedObject.ToHTTPResponse()
+		printOutput(output, res, []string{"ID", "URL", "EXPIRED AT"}, [][]string{
+			{res.ID, res.URL, res.ExpiredAt},
+		})
+	default:
+		continueOrFatal(ErrInvalidCommand)
+	}
+}
+
+func StartObjectGCCommand(dryRun bool, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newCLIContext()
+	defer cancel()
+
+	nc, js, err := infrastructure.NewJetstreamClient()
+	continueOrFatal(err)
+	defer func() {
+		_ = infrastructure.DrainJetstream(ctx, nc)
+	}()
+
+	gcUsecase := usecase.NewObjectGCUsecase()
+	continueOrFatal(gcUsecase.InjectObjectRepo(deps.objectRepo))
+	continueOrFatal(gcUsecase.InjectPendingDeletionRepo(deps.pendingDeletionRepo))
+	continueOrFatal(gcUsecase.InjectJetstreamClient(js))
+
+	objects, err := gcUsecase.PurgeTemporaryObjects(ctx, dryRun)
+	continueOrFatal(err)
+	if !dryRun {
+		_, err = gcUsecase.RetryPendingDeletions(ctx)
+		continueOrFatal(err)
+	}
+
+	res := make([]*model.HTTPUploadObjectResponse, 0, len(objects))
+	rows := make([][]string, 0, len(objects))
+	for _, object := range objects {
+		res = append(res, object.ToHTTPResponse())
+		rows = append(rows, []string{
+			object.ID,
+			object.FileName,
+			object.Key,
+			object.TemporaryUntil.UTC().Format(time.RFC3339),
+		})
+	}
+	printOutput(output, res, []string{"ID", "FILENAME", "KEY", "TEMPORARY UNTIL"}, rows)
+}
+
+func StartObjectReconcileCommand(dryRun bool, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newCLIContext()
+	defer cancel()
+
+	reconcileUsecase := usecase.NewObjectReconcileUsecase()
+	continueOrFatal(reconcileUsecase.InjectObjectRepo(deps.objectRepo))
+	continueOrFatal(reconcileUsecase.InjectObjectTypeRepo(deps.objectTypeRepo))
+
+	report, err := reconcileUsecase.Reconcile(ctx, dryRun)
+	continueOrFatal(err)
+
+	rows := make([][]string, 0, len(report.MissingContent)+len(report.Orphans))
+	for _, object := range report.MissingContent {
+		rows = append(rows, []string{"missing content", object.Bucket, object.Key, object.ID, ""})
+	}
+	for _, orphan := range report.Orphans {
+		status := "kept"
+		switch {
+		case orphan.Deleted:
+			status = "deleted"
+		case orphan.Error != "":
+			status = orphan.Error
+		case orphan.WithinGrace:
+			status = "within grace period"
+		}
+		rows = append(rows, []string{"orphan", orphan.Bucket, orphan.Key, "", status})
+	}
+	rows = append(rows, []string{
+		"summary",
+		fmt.Sprintf("%d buckets", len(report.Buckets)),
+		fmt.Sprintf("%d rows, %d blobs", report.RowsScanned, report.BlobsScanned),
+		fmt.Sprintf("%d missing, %d orphans", len(report.MissingContent), len(report.Orphans)),
+		fmt.Sprintf("%d deleted", report.OrphansDeleted),
+	})
+	printOutput(output, report, []string{"KIND", "BUCKET", "KEY", "OBJECT ID", "STATUS"}, rows)
+}
+
+func StartObjectVerifyCommand(output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	// re-reading every blob outlives the default cli timeout
+	ctx := context.Background()
+
+	verifyUsecase := usecase.NewObjectVerifyUsecase()
+	continueOrFatal(verifyUsecase.InjectObjectRepo(deps.objectRepo))
+
+	report, err := verifyUsecase.Verify(ctx)
+	continueOrFatal(err)
+
+	rows := make([][]string, 0, len(report.Failures)+1)
+	for _, failure := range report.Failures {
+		rows = append(rows, []string{failure.Status, failure.Object.ID, failure.Object.Bucket, failure.Object.Key, failure.Detail})
+	}
+	rows = append(rows, []string{
+		"summary",
+		fmt.Sprintf("%d checked", report.Checked),
+		fmt.Sprintf("%d passed", report.Passed),
+		fmt.Sprintf("%d size only", report.SizeOnly),
+		fmt.Sprintf("%d failed", len(report.Failures)),
+	})
+	printOutput(output, report, []string{"STATUS", "ID", "BUCKET", "KEY", "DETAIL"}, rows)
+}
+
+// StartObjectRewrapCommand wraps the data keys still under a retired master key with the active one.
+func StartObjectRewrapCommand(dryRun bool, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx := context.Background()
+
+	rewrapUsecase := usecase.NewObjectRewrapUsecase()
+	continueOrFatal(rewrapUsecase.InjectObjectRepo(deps.objectRepo))
+	continueOrFatal(rewrapUsecase.InjectKeyring(deps.keyring))
+
+	report, err := rewrapUsecase.Rewrap(ctx, dryRun)
+	continueOrFatal(err)
+
+	rows := make([][]string, 0, len(report.Failures)+1)
+	for _, failure := range report.Failures {
+		rows = append(rows, []string{"failed", failure.Object.ID, failure.Object.EncryptionKeyID, failure.Error})
+	}
+	rows = append(rows, []string{
+		"summary",
+		fmt.Sprintf("%d encrypted", report.Checked),
+		fmt.Sprintf("%d pending", report.Pending),
+		fmt.Sprintf("%d rewrapped to %s", report.Rewrapped, report.ActiveKeyID),
+	})
+	printOutput(output, report, []string{"STATUS", "ID", "KEY ID", "DETAIL"}, rows)
+}
+
+// StartUsageRecomputeCommand rebuilds the usage of the user in the tenant, or of every user of
+// every tenant when userID is empty, and prints the rebuilt usage of a single user.
+func StartUsageRecomputeCommand(userID string, tenantID string, output string) {
+	continueOrFatal(validateOutput(output))
+
+	deps := initCLIDependencies(output)
+	ctx, cancel := newTenantCLIContext(tenantID)
+	defer cancel()
+
+	continueOrFatal(deps.storageUsageRepo.Recompute(ctx, userID))
+	if userID == "" {
+		printOutput(output, map[string]bool{"recomputed": true}, []string{"RECOMPUTED"}, [][]string{{"all users"}})
+		return
+	}
+
+	usages, err := deps.storageUsageRepo.FindByUserID(ctx, userID)
+	continueOrFatal(err)
+
+	rows := make([][]string, 0, len(usages))
+	for _, usage := range usages {
+		rows = append(rows, []string{
+			usage.UserID,
+			usage.TypeID,
+			strconv.FormatInt(usage.Bytes, 10),
+			strconv.FormatInt(usage.Objects, 10),
+		})
+	}
+	quota := model.StorageQuota{
+		MaxBytes:   config.QuotaMaxBytes(userID),
+		MaxObjects: config.QuotaMaxObjects(userID),
+	}
+	printOutput(output, model.NewUserStorageUsage(userID, quota, usages).ToHTTPResponse(),
+		[]string{"USER ID", "TYPE ID", "BYTES", "OBJECTS"}, rows)
+}
+
+func findObjectTypeOrFatal(ctx context.Context, deps *cliDependencies, name string) *model.ObjectType {
+	objectType, err := deps.objectTypeRepo.FindByName(ctx, name)
+	continueOrFatal(err)
+	if objectType == nil {
+		continueOrFatal(model.ErrObjectTypeNotFound)
+	}
+	return objectType
+}
+
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+func validateTenant(tenantID string) error {
+	if !model.IsValidTenantID(tenantID) {
+		return model.ErrInvalidTenant
+	}
+	return nil
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case OutputTable, OutputJSON:
+		return nil
+	default:
+		return ErrInvalidOutput
+	}
+}
+
+// printOutput writes data as indented json for scripting, or as a table for humans.
+func printOutput(output string, data any, headers []string, rows [][]string) {
+	if output == OutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		continueOrFatal(encoder.Encode(data))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	continueOrFatal(w.Flush())
+}
@@ -20,8 +20,15 @@ func continueOrFatal(err error) {
 	}
 }
 
-// gracefulShutdown waits for termination syscalls and doing clean up operations after received it.
-func gracefulShutdown(ctx context.Context, timeout time.Duration, ops map[string]operation) <-chan struct{} {
+// shutdownPhase groups clean up operations that can run at the same time,
+// a phase only starts once every operation of the previous one returned.
+type shutdownPhase struct {
+	name string
+	ops  map[string]operation
+}
+
+// gracefulShutdown waits for termination syscalls and runs the clean up phases in order after received it.
+func gracefulShutdown(ctx context.Context, timeout time.Duration, phases []shutdownPhase) <-chan struct{} {
 	wait := make(chan struct{})
 	go func() {
 		s := make(chan os.Signal, 1)
@@ -35,35 +42,82 @@ func gracefulShutdown(ctx context.Context, timeout time.Duration, ops map[string
 		// set timeout for the ops to be done to prevent system hang
 		timeoutFunc := time.AfterFunc(timeout, func() {
 			log.Error(fmt.Sprintf("timeout %d ms has been elapsed, force exit", timeout.Milliseconds()))
-			os.Exit(0)
+			os.Exit(1)
 		})
 
 		defer timeoutFunc.Stop()
 
-		var wg sync.WaitGroup
-
-		// Do the operations asynchronously to save time
-		for key, op := range ops {
-			wg.Add(1)
-			innerOp := op
-			innerKey := key
-			go func() {
-				defer wg.Done()
-
-				log.Info(fmt.Sprintf("cleaning up: %s", innerKey))
-				if err := innerOp(ctx); err != nil {
-					log.Error(fmt.Sprintf("%s: clean up failed: %s", innerKey, err.Error()))
-					return
-				}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
 
-				log.Info(fmt.Sprintf("%s was shutdown gracefully", innerKey))
-			}()
+		for _, phase := range phases {
+			log.Info(fmt.Sprintf("shutdown phase: %s", phase.name))
+			runShutdownOps(ctx, phase.ops)
 		}
 
-		wg.Wait()
-
 		close(wait)
 	}()
 
 	return wait
 }
+
+func runShutdownOps(ctx context.Context, ops map[string]operation) {
+	var wg sync.WaitGroup
+
+	// Do the operations asynchronously to save time
+	for key, op := range ops {
+		wg.Add(1)
+		innerOp := op
+		innerKey := key
+		go func() {
+			defer wg.Done()
+
+			log.Info(fmt.Sprintf("cleaning up: %s", innerKey))
+			if err := innerOp(ctx); err != nil {
+				log.Error(fmt.Sprintf("%s: clean up failed: %s", innerKey, err.Error()))
+				return
+			}
+
+			log.Info(fmt.Sprintf("%s was shutdown gracefully", innerKey))
+		}()
+	}
+
+	wg.Wait()
+}
+
+// runPeriodically calls job every interval until ctx is done, the returned
+// channel is closed once a running job finished.
+func runPeriodically(ctx context.Context, interval time.Duration, job operation) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(ctx); err != nil {
+					log.Error(err.Error())
+				}
+			}
+		}
+	}()
+	return done
+}
+
+// waitDone waits for done to be closed, a nil channel has nothing to wait for.
+func waitDone(ctx context.Context, done <-chan struct{}) error {
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
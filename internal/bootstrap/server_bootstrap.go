@@ -14,10 +14,11 @@ import (
 	grpcServer "github.com/krobus00/storage-service/internal/transport/grpc"
 	httpServer "github.com/krobus00/storage-service/internal/transport/http"
 	"github.com/krobus00/storage-service/internal/usecase"
+	"github.com/krobus00/storage-service/internal/utils"
 	pb "github.com/krobus00/storage-service/pb/storage"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/sirupsen/logrus"
@@ -34,21 +35,27 @@ func StartServer() {
 	redisClient, err := infrastructure.NewRedisClient()
 	continueOrFatal(err)
 
+	cache, err := infrastructure.NewCache(redisClient)
+	continueOrFatal(err)
+
 	s3Client, err := infrastructure.NewS3Client()
 	continueOrFatal(err)
 
+	keyring, err := infrastructure.NewLocalKeyring()
+	continueOrFatal(err)
+
 	nc, js, err := infrastructure.NewJetstreamClient()
 	continueOrFatal(err)
 
-	tp, err := infrastructure.JaegerTraceProvider()
+	tp, err := infrastructure.NewTraceProvider(context.Background())
 	continueOrFatal(err)
 
 	echo := infrastructure.NewEcho()
 
 	// init grpc client
-	authConn, err := grpc.Dial(config.AuthGRPCHost(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authConn, err := infrastructure.NewGRPCClientConn(config.AuthGRPCHost())
 	continueOrFatal(err)
-	authClient := authPB.NewAuthServiceClient(authConn)
+	authClient := infrastructure.NewAuthClient(authPB.NewAuthServiceClient(authConn), cache)
 
 	// init repository
 	objectRepo := repository.NewObjectRepository()
@@ -56,22 +63,66 @@ func StartServer() {
 	continueOrFatal(err)
 	err = objectRepo.InjectS3Client(s3Client)
 	continueOrFatal(err)
-	err = objectRepo.InjectRedisClient(redisClient)
+	err = objectRepo.InjectCache(cache)
+	continueOrFatal(err)
+	err = objectRepo.InjectKeyring(keyring)
 	continueOrFatal(err)
 
 	objectTypeRepo := repository.NewObjectTypeRepository()
 	err = objectTypeRepo.InjectDB(infrastructure.DB)
 	continueOrFatal(err)
-	err = objectTypeRepo.InjectRedisClient(redisClient)
+	err = objectTypeRepo.InjectCache(cache)
 	continueOrFatal(err)
 
 	objectWhitelistTypeRepo := repository.NewObjectWhitelistTypeRepository()
 	err = objectWhitelistTypeRepo.InjectDB(infrastructure.DB)
 	continueOrFatal(err)
-	err = objectWhitelistTypeRepo.InjectRedisClient(redisClient)
+	err = objectWhitelistTypeRepo.InjectCache(cache)
+	continueOrFatal(err)
+
+	objectGrantRepo := repository.NewObjectGrantRepository()
+	err = objectGrantRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+	err = objectGrantRepo.InjectCache(cache)
+	continueOrFatal(err)
+
+	shareLinkRepo := repository.NewShareLinkRepository()
+	err = shareLinkRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	objectReferenceRepo := repository.NewObjectReferenceRepository()
+	err = objectReferenceRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	storageUsageRepo := repository.NewStorageUsageRepository()
+	err = storageUsageRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	pendingDeletionRepo := repository.NewPendingDeletionRepository()
+	err = pendingDeletionRepo.InjectDB(infrastructure.DB)
+	continueOrFatal(err)
+
+	auditLogRepo := repository.NewAuditLogRepository()
+	err = auditLogRepo.InjectDB(infrastructure.DB)
 	continueOrFatal(err)
 
 	// init usecase
+	auditLogUsecase := usecase.NewAuditLogUsecase()
+	err = auditLogUsecase.InjectAuditLogRepo(auditLogRepo)
+	continueOrFatal(err)
+	err = auditLogUsecase.InjectAuthClient(authClient)
+	continueOrFatal(err)
+	err = auditLogUsecase.InjectJetstreamClient(js)
+	continueOrFatal(err)
+
+	storageUsageUsecase := usecase.NewStorageUsageUsecase()
+	err = storageUsageUsecase.InjectStorageUsageRepo(storageUsageRepo)
+	continueOrFatal(err)
+	err = storageUsageUsecase.InjectObjectTypeRepo(objectTypeRepo)
+	continueOrFatal(err)
+	err = storageUsageUsecase.InjectAuthClient(authClient)
+	continueOrFatal(err)
+
 	objectUsecase := usecase.NewObjectUsecase()
 	err = objectUsecase.InjectObjectRepo(objectRepo)
 	continueOrFatal(err)
@@ -79,14 +130,45 @@ func StartServer() {
 	continueOrFatal(err)
 	err = objectUsecase.InjectObjectWhitelistTypeRepo(objectWhitelistTypeRepo)
 	continueOrFatal(err)
+	err = objectUsecase.InjectObjectGrantRepo(objectGrantRepo)
+	continueOrFatal(err)
+	err = objectUsecase.InjectShareLinkRepo(shareLinkRepo)
+	continueOrFatal(err)
+	err = objectUsecase.InjectObjectReferenceRepo(objectReferenceRepo)
+	continueOrFatal(err)
+	err = objectUsecase.InjectPendingDeletionRepo(pendingDeletionRepo)
+	continueOrFatal(err)
+	err = objectUsecase.InjectStorageUsageUsecase(storageUsageUsecase)
+	continueOrFatal(err)
 	err = objectUsecase.InjectAuthClient(authClient)
 	continueOrFatal(err)
 	err = objectUsecase.InjectJetstreamClient(js)
 	continueOrFatal(err)
+	err = objectUsecase.InjectAuditLogUsecase(auditLogUsecase)
+	continueOrFatal(err)
+
+	objectGCUsecase := usecase.NewObjectGCUsecase()
+	err = objectGCUsecase.InjectObjectRepo(objectRepo)
+	continueOrFatal(err)
+	err = objectGCUsecase.InjectPendingDeletionRepo(pendingDeletionRepo)
+	continueOrFatal(err)
+	err = objectGCUsecase.InjectJetstreamClient(js)
+	continueOrFatal(err)
+
+	objectReconcileUsecase := usecase.NewObjectReconcileUsecase()
+	err = objectReconcileUsecase.InjectObjectRepo(objectRepo)
+	continueOrFatal(err)
+	err = objectReconcileUsecase.InjectObjectTypeRepo(objectTypeRepo)
+	continueOrFatal(err)
+
+	objectVerifyUsecase := usecase.NewObjectVerifyUsecase()
+	err = objectVerifyUsecase.InjectObjectRepo(objectRepo)
+	continueOrFatal(err)
 
 	// init stream
 	publisherUsecase := []model.PublisherUsecase{
 		objectUsecase,
+		auditLogUsecase,
 	}
 
 	for _, uc := range publisherUsecase {
@@ -99,26 +181,60 @@ func StartServer() {
 	objectCtrl := httpServer.NewObjectController()
 	err = objectCtrl.InjectObjectUsecase(objectUsecase)
 	continueOrFatal(err)
+	err = objectCtrl.InjectDownloadMode(config.DownloadMode())
+	continueOrFatal(err)
+
+	auditLogCtrl := httpServer.NewAuditLogController()
+	err = auditLogCtrl.InjectAuditLogUsecase(auditLogUsecase)
+	continueOrFatal(err)
+
+	usageCtrl := httpServer.NewStorageUsageController()
+	err = usageCtrl.InjectStorageUsageUsecase(storageUsageUsecase)
+	continueOrFatal(err)
 
 	httpDelivery := httpServer.NewDelivery()
 	err = httpDelivery.InjectEcho(echo)
 	continueOrFatal(err)
 	err = httpDelivery.InjectObjectController(objectCtrl)
 	continueOrFatal(err)
+	err = httpDelivery.InjectAuditLogController(auditLogCtrl)
+	continueOrFatal(err)
+	err = httpDelivery.InjectStorageUsageController(usageCtrl)
+	continueOrFatal(err)
 	httpDelivery.InitRoutes()
 
 	// init grpc
 	grpcDelivery := grpcServer.NewDelivery()
 	err = grpcDelivery.InjectObjectUsecase(objectUsecase)
 	continueOrFatal(err)
+	err = grpcDelivery.InjectAuditLogUsecase(auditLogUsecase)
+	continueOrFatal(err)
+	err = grpcDelivery.InjectStorageUsageUsecase(storageUsageUsecase)
+	continueOrFatal(err)
 
-	storageGrpcServer := grpc.NewServer()
+	storageGrpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcServer.UnaryTraceInterceptor(),
+			grpcServer.UnaryRequestInfoInterceptor(),
+			grpcServer.UnaryTenantInterceptor(),
+		),
+	)
+
+	// init health
+	healthChecker := infrastructure.NewHealthChecker(config.HealthCheckTimeout())
+	healthChecker.AddCheck("database", infrastructure.NewDatabaseHealthCheck(infrastructure.DB))
+	healthChecker.AddCheck("redis", infrastructure.NewRedisHealthCheck(redisClient))
+	healthChecker.AddCheck("s3", infrastructure.NewS3HealthCheck(s3Client, objectTypeRepo))
+	healthChecker.AddCheck("nats", infrastructure.NewNATSHealthCheck(nc))
+	healthChecker.AddCheck("auth service", infrastructure.NewGRPCConnHealthCheck(authConn))
 
 	pb.RegisterStorageServiceServer(storageGrpcServer, grpcDelivery)
+	healthpb.RegisterHealthServer(storageGrpcServer, healthChecker)
 	if config.Env() == "development" {
 		reflection.Register(storageGrpcServer)
 	}
-	lis, _ := net.Listen("tcp", ":"+config.PortGRPC())
+	lis, err := net.Listen("tcp", ":"+config.PortGRPC())
+	continueOrFatal(err)
 	go func() {
 		_ = storageGrpcServer.Serve(lis)
 	}()
@@ -129,32 +245,119 @@ func StartServer() {
 	}()
 	log.Info(fmt.Sprintf("http server started on :%s", config.PortHTTP()))
 
-	http.Handle("/metrics", promhttp.Handler())
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsMux.HandleFunc("/healthz", healthChecker.HandleLiveness)
+	metricsMux.HandleFunc("/readyz", healthChecker.HandleReadiness)
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", config.PortMetrics()),
+		Handler:           metricsMux,
+		ReadHeaderTimeout: config.HealthCheckTimeout(),
+	}
 
 	go func() {
-		_ = http.ListenAndServe(fmt.Sprintf(":%s", config.PortMetrics()), nil)
+		_ = metricsServer.ListenAndServe()
 	}()
 	logrus.Info(fmt.Sprintf("metrics server started on :%s", config.PortMetrics()))
 
-	wait := gracefulShutdown(context.Background(), config.GracefulShutdownTimeOut(), map[string]operation{
-		"redis connection": func(ctx context.Context) error {
-			return redisClient.Close()
-		},
-		"database connection": func(ctx context.Context) error {
-			infrastructure.StopTickerCh <- true
-			return db.Close()
-		},
-		"http": func(ctx context.Context) error {
-			return echo.Shutdown(ctx)
+	gcCtx, stopGC := context.WithCancel(context.Background())
+	var gcDone <-chan struct{}
+	if interval := config.GCInterval(); interval > 0 {
+		gcDone = runPeriodically(gcCtx, interval, func(ctx context.Context) error {
+			_, err := objectGCUsecase.PurgeTemporaryObjects(ctx, false)
+			if err != nil {
+				return err
+			}
+			_, err = objectGCUsecase.RetryPendingDeletions(ctx)
+			return err
+		})
+		logrus.Info(fmt.Sprintf("object gc started every %s", interval))
+	}
+
+	reconcileCtx, stopReconcile := context.WithCancel(context.Background())
+	var reconcileDone <-chan struct{}
+	if interval := config.ReconcileInterval(); interval > 0 {
+		reconcileDone = runPeriodically(reconcileCtx, interval, func(ctx context.Context) error {
+			_, err := objectReconcileUsecase.Reconcile(ctx, !config.ReconcileFix())
+			return err
+		})
+		logrus.Info(fmt.Sprintf("object reconciler started every %s", interval))
+	}
+
+	verifyCtx, stopVerify := context.WithCancel(context.Background())
+	var verifyDone <-chan struct{}
+	if interval := config.VerifyInterval(); interval > 0 {
+		verifyDone = runPeriodically(verifyCtx, interval, func(ctx context.Context) error {
+			_, err := objectVerifyUsecase.Verify(ctx)
+			return err
+		})
+		logrus.Info(fmt.Sprintf("object verification started every %s", interval))
+	}
+
+	wait := gracefulShutdown(context.Background(), config.GracefulShutdownTimeOut(), []shutdownPhase{
+		{
+			name: "stop accepting traffic",
+			ops: map[string]operation{
+				"readiness": func(ctx context.Context) error {
+					healthChecker.SetDraining()
+					return utils.SleepContext(ctx, config.ShutdownDrainDelay())
+				},
+			},
 		},
-		"grpc": func(ctx context.Context) error {
-			return lis.Close()
+		{
+			name: "drain in-flight requests",
+			ops: map[string]operation{
+				"http": func(ctx context.Context) error {
+					return echo.Shutdown(ctx)
+				},
+				"grpc": func(ctx context.Context) error {
+					return infrastructure.GracefulStopGRPCServer(ctx, storageGrpcServer)
+				},
+				"object gc": func(ctx context.Context) error {
+					stopGC()
+					return waitDone(ctx, gcDone)
+				},
+				"object reconciler": func(ctx context.Context) error {
+					stopReconcile()
+					return waitDone(ctx, reconcileDone)
+				},
+				"object verification": func(ctx context.Context) error {
+					stopVerify()
+					return waitDone(ctx, verifyDone)
+				},
+			},
 		},
-		"nats connection": func(ctx context.Context) error {
-			return nc.Drain()
+		{
+			name: "flush outgoing messages and traces",
+			ops: map[string]operation{
+				"nats connection": func(ctx context.Context) error {
+					return infrastructure.DrainJetstream(ctx, nc)
+				},
+				"cache invalidation": func(ctx context.Context) error {
+					return cache.Close()
+				},
+				"trace provider": func(ctx context.Context) error {
+					return tp.Shutdown(ctx)
+				},
+			},
 		},
-		"trace provider": func(ctx context.Context) error {
-			return tp.Shutdown(ctx)
+		{
+			name: "close data stores",
+			ops: map[string]operation{
+				"redis connection": func(ctx context.Context) error {
+					return redisClient.Close()
+				},
+				"database connection": func(ctx context.Context) error {
+					infrastructure.StopTickerCh <- true
+					return db.Close()
+				},
+				"auth connection": func(ctx context.Context) error {
+					return authConn.Close()
+				},
+				"metrics server": func(ctx context.Context) error {
+					return metricsServer.Shutdown(ctx)
+				},
+			},
 		},
 	})
 
@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,6 +41,18 @@ func LogLevel() string {
 	return viper.GetString("log_level")
 }
 
+// ShutdownDrainDelay is how long the server keeps accepting traffic after being marked not ready,
+// giving load balancers time to stop routing to it.
+func ShutdownDrainDelay() time.Duration {
+	cfg := viper.GetString("shutdown_drain_delay")
+	return parseDuration(cfg, DefaultShutdownDrainDelay)
+}
+
+func HealthCheckTimeout() time.Duration {
+	cfg := viper.GetString("health.check_timeout")
+	return parseDuration(cfg, DefaultHealthCheckTimeout)
+}
+
 func PortHTTP() string {
 	return viper.GetString("ports.http")
 }
@@ -168,6 +182,22 @@ func RedisCacheTTL() time.Duration {
 	return parseDuration(cfg, DefaultRedisCacheTTL)
 }
 
+func LocalCacheEnabled() bool {
+	return viper.GetBool("redis.local_cache.enabled")
+}
+
+func LocalCacheSize() int {
+	if viper.GetInt("redis.local_cache.size") <= 0 {
+		return DefaultLocalCacheSize
+	}
+	return viper.GetInt("redis.local_cache.size")
+}
+
+func LocalCacheTTL() time.Duration {
+	cfg := viper.GetString("redis.local_cache.ttl")
+	return parseDuration(cfg, DefaultLocalCacheTTL)
+}
+
 func GetS3Region() string {
 	return viper.GetString("s3.region")
 }
@@ -180,6 +210,35 @@ func GetS3BucketName() string {
 	return viper.GetString("s3.bucket")
 }
 
+// TenantS3BucketName is the bucket of the tenant, tenants without one share the default bucket.
+func TenantS3BucketName(tenantID string) string {
+	bucket := viper.GetString(fmt.Sprintf("tenants.%s.bucket", tenantID))
+	if bucket == "" {
+		return GetS3BucketName()
+	}
+	return bucket
+}
+
+// S3BucketNames are the default bucket and the tenant buckets, without duplicates.
+func S3BucketNames() []string {
+	unique := map[string]bool{GetS3BucketName(): true}
+	for _, tenantID := range TenantIDs() {
+		unique[TenantS3BucketName(tenantID)] = true
+	}
+
+	buckets := make([]string, 0, len(unique))
+	for bucket := range unique {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	return buckets
+}
+
+// TenantS3KeyPrefix is prepended to the keys of the tenant objects.
+func TenantS3KeyPrefix(tenantID string) string {
+	return viper.GetString(fmt.Sprintf("tenants.%s.key_prefix", tenantID))
+}
+
 func GetS3AccessKey() string {
 	return viper.GetString("s3.access_key")
 }
@@ -193,6 +252,20 @@ func GetS3SignDuration() time.Duration {
 	return parseDuration(cfg, DefaultS3SignDuration)
 }
 
+// GetS3PresignSafetyMargin is the validity a presigned url must still have left
+// when handed out, on top of what the caller asked for.
+func GetS3PresignSafetyMargin() time.Duration {
+	cfg := viper.GetString("s3.presign_safety_margin")
+	return parseDuration(cfg, DefaultS3PresignSafetyMargin)
+}
+
+// GetS3PresignRefreshWindow is how long before expiry a cached presigned url
+// is re-signed by the next request that hits it.
+func GetS3PresignRefreshWindow() time.Duration {
+	cfg := viper.GetString("s3.presign_refresh_window")
+	return parseDuration(cfg, DefaultS3PresignRefreshWindow)
+}
+
 func GetS3Credential() *credentials.StaticCredentialsProvider {
 	accessKeyIDValue := GetS3AccessKey()
 	secretAccessKeyValue := GetS3SecretKey()
@@ -223,20 +296,223 @@ func AuthGRPCHost() string {
 	return viper.GetString("services.auth.grpc")
 }
 
-func JaegerProtocol() string {
-	return viper.GetString("jaeger.protocol")
+func AuthDecisionCacheTTL() time.Duration {
+	cfg := viper.GetString("services.auth.decision_cache_ttl")
+	return parseDuration(cfg, DefaultAuthDecisionCacheTTL)
+}
+
+func AuthRetryAttempts() int {
+	if viper.GetInt("services.auth.retry_attempts") <= 0 {
+		return DefaultAuthRetryAttempts
+	}
+	return viper.GetInt("services.auth.retry_attempts")
+}
+
+func AuthRetryMinBackoff() time.Duration {
+	cfg := viper.GetString("services.auth.retry_min_backoff")
+	return parseDuration(cfg, DefaultAuthRetryMinBackoff)
+}
+
+func AuthRetryMaxBackoff() time.Duration {
+	cfg := viper.GetString("services.auth.retry_max_backoff")
+	return parseDuration(cfg, DefaultAuthRetryMaxBackoff)
+}
+
+func AuthBreakerFailureThreshold() int {
+	if viper.GetInt("services.auth.breaker_failure_threshold") <= 0 {
+		return DefaultAuthBreakerFailureThreshold
+	}
+	return viper.GetInt("services.auth.breaker_failure_threshold")
+}
+
+func AuthBreakerOpenTimeout() time.Duration {
+	cfg := viper.GetString("services.auth.breaker_open_timeout")
+	return parseDuration(cfg, DefaultAuthBreakerOpenTimeout)
+}
+
+// AuthFailOpenPermissions are granted while the auth service is unavailable,
+// every other permission fails closed.
+func AuthFailOpenPermissions() []string {
+	return viper.GetStringSlice("services.auth.fail_open_permissions")
+}
+
+// DownloadMode is either redirect or proxy.
+func DownloadMode() string {
+	if viper.GetString("download.mode") == "" {
+		return DefaultDownloadMode
+	}
+	return viper.GetString("download.mode")
+}
+
+// ShareLinkDefaultTTL applies when a share link is created without an expiry.
+func ShareLinkDefaultTTL() time.Duration {
+	cfg := viper.GetString("share_link.default_ttl")
+	return parseDuration(cfg, DefaultShareLinkTTL)
+}
+
+func ShareLinkMaxTTL() time.Duration {
+	cfg := viper.GetString("share_link.max_ttl")
+	return parseDuration(cfg, DefaultShareLinkMaxTTL)
+}
+
+// ShareLinkPresignTTL is the lifetime of the url a resolved share link redirects to,
+// it only has to outlive the redirect.
+func ShareLinkPresignTTL() time.Duration {
+	cfg := viper.GetString("share_link.presign_ttl")
+	return parseDuration(cfg, DefaultShareLinkPresignTTL)
+}
+
+// ShareLinkMaxPasswordAttempts is how many wrong passwords lock a share link.
+func ShareLinkMaxPasswordAttempts() int {
+	if viper.GetInt("share_link.max_password_attempts") <= 0 {
+		return DefaultShareLinkMaxPasswordAttempts
+	}
+	return viper.GetInt("share_link.max_password_attempts")
+}
+
+func ShareLinkLockoutDuration() time.Duration {
+	cfg := viper.GetString("share_link.lockout_duration")
+	return parseDuration(cfg, DefaultShareLinkLockoutDuration)
+}
+
+// TemporaryObjectTTL is how long a temporary upload waits for a reference before it is purged.
+func TemporaryObjectTTL() time.Duration {
+	cfg := viper.GetString("gc.temporary_ttl")
+	return parseDuration(cfg, DefaultTemporaryObjectTTL)
+}
+
+// GCInterval is the period of the temporary object purge, 0 disables it in the server.
+func GCInterval() time.Duration {
+	cfg := viper.GetString("gc.interval")
+	return parseDuration(cfg, DefaultGCInterval)
+}
+
+func GCBatchSize() int {
+	if viper.GetInt("gc.batch_size") <= 0 {
+		return DefaultGCBatchSize
+	}
+	return viper.GetInt("gc.batch_size")
+}
+
+// ReconcileInterval is the period of the reconciler in the server, 0 disables it.
+func ReconcileInterval() time.Duration {
+	return parseDuration(viper.GetString("reconcile.interval"), 0)
+}
+
+// ReconcileGracePeriod is how old an orphan blob must be before it is deleted.
+func ReconcileGracePeriod() time.Duration {
+	cfg := viper.GetString("reconcile.grace_period")
+	return parseDuration(cfg, DefaultReconcileGracePeriod)
+}
+
+// ReconcileFix lets the scheduled reconciler delete orphans instead of only reporting them.
+func ReconcileFix() bool {
+	return viper.GetBool("reconcile.fix")
+}
+
+func ReconcileBatchSize() int {
+	if viper.GetInt("reconcile.batch_size") <= 0 {
+		return DefaultReconcileBatchSize
+	}
+	return viper.GetInt("reconcile.batch_size")
+}
+
+// VerifyInterval is the period of the content verification in the server, 0 disables it.
+func VerifyInterval() time.Duration {
+	return parseDuration(viper.GetString("verify.interval"), 0)
+}
+
+func VerifyBatchSize() int {
+	if viper.GetInt("verify.batch_size") <= 0 {
+		return DefaultVerifyBatchSize
+	}
+	return viper.GetInt("verify.batch_size")
+}
+
+// EncryptionActiveKeyID is the master key that wraps new data keys.
+func EncryptionActiveKeyID() string {
+	return viper.GetString("encryption.active_key")
+}
+
+// EncryptionKeys are the base64 encoded master keys by id.
+func EncryptionKeys() map[string]string {
+	return viper.GetStringMapString("encryption.keys")
+}
+
+// TenantIDs are the tenants with settings in the config.
+func TenantIDs() []string {
+	tenants := viper.GetStringMap("tenants")
+	tenantIDs := make([]string, 0, len(tenants))
+	for tenantID := range tenants {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+	sort.Strings(tenantIDs)
+	return tenantIDs
+}
+
+// TenantIDByHost is the tenant serving the host, hosts of no tenant belong to the default tenant.
+func TenantIDByHost(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	for _, tenantID := range TenantIDs() {
+		for _, tenantHost := range viper.GetStringSlice(fmt.Sprintf("tenants.%s.hosts", tenantID)) {
+			if strings.EqualFold(tenantHost, host) {
+				return tenantID
+			}
+		}
+	}
+	return ""
+}
+
+// QuotaMaxBytes is the byte limit of the user, a per user override wins over
+// the default, 0 means unlimited.
+func QuotaMaxBytes(userID string) int64 {
+	key := fmt.Sprintf("quota.overrides.%s.max_bytes", userID)
+	if viper.IsSet(key) {
+		return viper.GetInt64(key)
+	}
+	return viper.GetInt64("quota.max_bytes")
+}
+
+// QuotaMaxObjects is the object count limit of the user, resolved like QuotaMaxBytes.
+func QuotaMaxObjects(userID string) int64 {
+	key := fmt.Sprintf("quota.overrides.%s.max_objects", userID)
+	if viper.IsSet(key) {
+		return viper.GetInt64(key)
+	}
+	return viper.GetInt64("quota.max_objects")
+}
+
+// TenantQuotaMaxBytes caps the bytes stored by every user of the tenant together, 0 means unlimited.
+func TenantQuotaMaxBytes(tenantID string) int64 {
+	return viper.GetInt64(fmt.Sprintf("tenants.%s.quota.max_bytes", tenantID))
+}
+
+// TenantQuotaMaxObjects caps the objects stored by every user of the tenant together, 0 means unlimited.
+func TenantQuotaMaxObjects(tenantID string) int64 {
+	return viper.GetInt64(fmt.Sprintf("tenants.%s.quota.max_objects", tenantID))
+}
+
+// AuditMirrorToJetstream also publishes every audit record to the AUDIT stream.
+func AuditMirrorToJetstream() bool {
+	return viper.GetBool("audit.mirror_to_jetstream")
+}
+
+func TracerExporter() string {
+	return viper.GetString("tracer.exporter")
 }
 
-func JaegerHost() string {
-	return viper.GetString("jaeger.host")
+func TracerEndpoint() string {
+	return viper.GetString("tracer.endpoint")
 }
 
-func JaegerPort() string {
-	return viper.GetString("jaeger.port")
+func TracerInsecure() bool {
+	return viper.GetBool("tracer.insecure")
 }
 
-func JaegerSampleRate() float64 {
-	return viper.GetFloat64("jaeger.sample_rate")
+func TracerSampleRate() float64 {
+	return viper.GetFloat64("tracer.sample_rate")
 }
 
 func parseDuration(in string, defaultDuration time.Duration) time.Duration {
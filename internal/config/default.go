@@ -4,6 +4,8 @@ import "time"
 
 const (
 	DefaultGracefulShutdownTimeOut = 30 * time.Second
+	DefaultHealthCheckTimeout      = 2 * time.Second
+	DefaultShutdownDrainDelay      = 0 * time.Second
 
 	DefaultDatabaseMaxIdleConns       = 3
 	DefaultDatabaseMaxOpenConns       = 5
@@ -19,7 +21,36 @@ const (
 	DefaultRedisReadTimeout  = 2 * time.Second
 	DefaultRedisCacheTTL     = 15 * time.Minute
 
-	DefaultS3SignDuration = 1 * time.Hour
+	DefaultLocalCacheSize = 10000
+	DefaultLocalCacheTTL  = 5 * time.Second
+
+	DefaultS3SignDuration         = 1 * time.Hour
+	DefaultS3PresignSafetyMargin  = 1 * time.Minute
+	DefaultS3PresignRefreshWindow = 15 * time.Minute
+
+	DefaultAuthDecisionCacheTTL        = 10 * time.Second
+	DefaultAuthRetryAttempts           = 3
+	DefaultAuthRetryMinBackoff         = 50 * time.Millisecond
+	DefaultAuthRetryMaxBackoff         = 500 * time.Millisecond
+	DefaultAuthBreakerFailureThreshold = 5
+	DefaultAuthBreakerOpenTimeout      = 30 * time.Second
+
+	DefaultDownloadMode = "redirect"
+
+	DefaultShareLinkTTL                 = 24 * time.Hour
+	DefaultShareLinkMaxTTL              = 30 * 24 * time.Hour
+	DefaultShareLinkMaxPasswordAttempts = 5
+	DefaultShareLinkPresignTTL          = 1 * time.Minute
+	DefaultShareLinkLockoutDuration     = 15 * time.Minute
+
+	DefaultTemporaryObjectTTL = 24 * time.Hour
+	DefaultGCInterval         = 1 * time.Hour
+	DefaultGCBatchSize        = 100
+
+	DefaultReconcileGracePeriod = 24 * time.Hour
+	DefaultReconcileBatchSize   = 1000
+
+	DefaultVerifyBatchSize = 100
 
 	DefaultJetstreamMaxPending = 256
 	DefaultJetstreamMaxAge     = 24 * time.Hour
@@ -5,7 +5,14 @@ type ctxKey string
 const (
 	KeyDBCtx     ctxKey = "DB"
 	KeyUserIDCtx ctxKey = "USERID"
+	// KeyTenantIDCtx scopes every repository query and cache key to one tenant.
+	KeyTenantIDCtx ctxKey = "TENANTID"
+	// KeyClientIPCtx and KeyUserAgentCtx describe the caller for the audit trail.
+	KeyClientIPCtx  ctxKey = "CLIENTIP"
+	KeyUserAgentCtx ctxKey = "USERAGENT"
 
 	SystemID = string("SYSTEM")
 	GuestID  = string("GUEST")
+
+	DefaultTenantID = string("default")
 )
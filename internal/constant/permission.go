@@ -8,4 +8,9 @@ const (
 	PermissionObjectCreate      = "OBJECT_CREATE"
 	PermissionObjectRead        = "OBJECT_READ"
 	PermissionObjectReadPrivate = "OBJECT_READ_PRIVATE"
+	PermissionObjectUpdate      = "OBJECT_UPDATE"
+	PermissionObjectDelete      = "OBJECT_DELETE"
+
+	PermissionAuditRead = "AUDIT_READ"
+	PermissionUsageRead = "USAGE_READ"
 )
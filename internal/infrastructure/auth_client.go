@@ -0,0 +1,151 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/jpillora/backoff"
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const authRetryBackoffFactor = 2
+
+// authClient guards HasAccess with a short lived decision cache, retries and a circuit breaker,
+// every other rpc goes straight to the embedded client.
+type authClient struct {
+	authPB.AuthServiceClient
+
+	cache   model.Cache
+	breaker *circuitBreaker
+}
+
+func NewAuthClient(client authPB.AuthServiceClient, cache model.Cache) authPB.AuthServiceClient {
+	return &authClient{
+		AuthServiceClient: client,
+		cache:             cache,
+		breaker:           newCircuitBreaker(config.AuthBreakerFailureThreshold(), config.AuthBreakerOpenTimeout()),
+	}
+}
+
+// HasAccess returns model.ErrAuthServiceUnavailable when no decision could be made,
+// unless one of the permissions is configured to fail open. Errors the auth service
+// answered with, like PermissionDenied, are returned unchanged.
+func (c *authClient) HasAccess(ctx context.Context, in *authPB.HasAccessRequest, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"userID":      in.GetUserId(),
+		"permissions": in.GetPermissions(),
+	})
+
+	cacheKey := model.NewAuthDecisionCacheKey(in.GetUserId(), in.GetPermissions())
+	cachedData, err := c.cache.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	if cachedData != nil {
+		allowed := false
+		if err := json.Unmarshal(cachedData, &allowed); err == nil {
+			return wrapperspb.Bool(allowed), nil
+		}
+	}
+
+	res, err := c.hasAccessWithRetry(ctx, in, opts...)
+	if err != nil {
+		logger.Error(err.Error())
+		if !isUnavailableAuthError(err) {
+			return nil, err
+		}
+		if failOpen(in.GetPermissions()) {
+			logger.Warn("auth service unavailable, failing open")
+			return wrapperspb.Bool(true), nil
+		}
+		return nil, model.ErrAuthServiceUnavailable
+	}
+
+	cacheData, err := json.Marshal(res.GetValue())
+	if err == nil {
+		err = c.cache.Set(ctx, cacheKey, cacheData, config.AuthDecisionCacheTTL())
+	}
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return res, nil
+}
+
+func (c *authClient) hasAccessWithRetry(ctx context.Context, in *authPB.HasAccessRequest, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
+	b := &backoff.Backoff{
+		Factor: authRetryBackoffFactor,
+		Jitter: true,
+		Min:    config.AuthRetryMinBackoff(),
+		Max:    config.AuthRetryMaxBackoff(),
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		res, err := c.AuthServiceClient.HasAccess(ctx, in, opts...)
+		metrics.ObserveAuthHasAccess(start, err == nil && res.GetValue(), err)
+		if err == nil {
+			c.breaker.Success()
+			return wrapperspb.Bool(res.GetValue()), nil
+		}
+		if !isRetryableAuthError(err) {
+			// the service answered, it is reachable even though the call failed
+			c.breaker.Success()
+			return nil, err
+		}
+
+		c.breaker.Failure()
+		if attempt >= config.AuthRetryAttempts() || ctx.Err() != nil {
+			return nil, err
+		}
+
+		err = utils.SleepContext(ctx, b.Duration())
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isRetryableAuthError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnavailableAuthError reports whether err means the auth service could not be reached,
+// as opposed to an answer from it.
+func isUnavailableAuthError(err error) bool {
+	return isRetryableAuthError(err) || errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// failOpen reports whether any requested permission may be granted while the auth service is down.
+func failOpen(permissions []string) bool {
+	failOpenPermissions := config.AuthFailOpenPermissions()
+	for _, permission := range permissions {
+		for _, failOpenPermission := range failOpenPermissions {
+			if permission == failOpenPermission {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestAuthClient_HasAccess(t *testing.T) {
+	errUnavailable := status.Error(codes.Unavailable, "connection refused")
+
+	type mockHasAccess struct {
+		res *wrapperspb.BoolValue
+		err error
+	}
+	tests := []struct {
+		name                string
+		permissions         []string
+		failOpenPermissions []string
+		calls               int
+		mockHasAccess       []mockHasAccess
+		want                bool
+		wantErr             error
+		wantCode            codes.Code
+	}{
+		{
+			name:        "allowed decision is cached",
+			permissions: []string{constant.PermissionObjectCreate, constant.PermissionFullAccess},
+			calls:       2,
+			mockHasAccess: []mockHasAccess{
+				{res: wrapperspb.Bool(true)},
+			},
+			want: true,
+		},
+		{
+			name:        "denied decision is cached",
+			permissions: []string{constant.PermissionObjectCreate},
+			calls:       2,
+			mockHasAccess: []mockHasAccess{
+				{res: wrapperspb.Bool(false)},
+			},
+			want: false,
+		},
+		{
+			name:        "retry transient error",
+			permissions: []string{constant.PermissionObjectCreate},
+			calls:       1,
+			mockHasAccess: []mockHasAccess{
+				{err: errUnavailable},
+				{res: wrapperspb.Bool(true)},
+			},
+			want: true,
+		},
+		{
+			name:        "fail closed once retries are exhausted",
+			permissions: []string{constant.PermissionObjectCreate},
+			calls:       1,
+			mockHasAccess: []mockHasAccess{
+				{err: errUnavailable},
+				{err: errUnavailable},
+				{err: errUnavailable},
+			},
+			wantErr: model.ErrAuthServiceUnavailable,
+		},
+		{
+			name:                "fail open once retries are exhausted",
+			permissions:         []string{constant.PermissionObjectReadPrivate},
+			failOpenPermissions: []string{constant.PermissionObjectReadPrivate},
+			calls:               1,
+			mockHasAccess: []mockHasAccess{
+				{err: errUnavailable},
+				{err: errUnavailable},
+				{err: errUnavailable},
+			},
+			want: true,
+		},
+		{
+			name:        "non transient error is not retried",
+			permissions: []string{constant.PermissionObjectCreate},
+			calls:       1,
+			mockHasAccess: []mockHasAccess{
+				{err: status.Error(codes.InvalidArgument, "invalid user id")},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:                "fail open permission is still denied",
+			permissions:         []string{constant.PermissionObjectReadPrivate},
+			failOpenPermissions: []string{constant.PermissionObjectReadPrivate},
+			calls:               1,
+			mockHasAccess: []mockHasAccess{
+				{err: status.Error(codes.PermissionDenied, "permission denied")},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("services.auth.retry_attempts", 3)
+			viper.Set("services.auth.retry_min_backoff", "1ms")
+			viper.Set("services.auth.retry_max_backoff", "1ms")
+			viper.Set("services.auth.fail_open_permissions", tt.failOpenPermissions)
+			defer viper.Set("services.auth.fail_open_permissions", nil)
+
+			redisClient, _ := newTestRedisClient(t)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+
+			calls := make([]*gomock.Call, 0, len(tt.mockHasAccess))
+			for _, mock := range tt.mockHasAccess {
+				calls = append(calls, authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(mock.res, mock.err))
+			}
+			gomock.InOrder(calls...)
+
+			client := NewAuthClient(authClientMock, NewRedisCache(redisClient))
+			for i := 0; i < tt.calls; i++ {
+				res, err := client.HasAccess(context.TODO(), &authPB.HasAccessRequest{
+					UserId:      "user",
+					Permissions: tt.permissions,
+				})
+				if tt.wantErr != nil {
+					assert.True(t, errors.Is(err, tt.wantErr))
+					continue
+				}
+				if tt.wantCode != codes.OK {
+					assert.Equal(t, tt.wantCode, status.Code(err))
+					assert.Nil(t, res)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, res.GetValue())
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(2, 50*time.Millisecond)
+
+	assert.NoError(t, b.Allow())
+	b.Failure()
+	assert.NoError(t, b.Allow())
+	b.Failure()
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	// a single probe goes through once the open timeout elapsed
+	assert.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	b.Failure()
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.NoError(t, b.Allow())
+	b.Success()
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+}
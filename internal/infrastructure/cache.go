@@ -0,0 +1,366 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// invalidateTagScript drops every key recorded under a tag and then the tag itself,
+// atomically so a key tagged while invalidating is never left behind untracked.
+// The dropped keys are returned so the local tiers can forget them too.
+var invalidateTagScript = goredis.NewScript(`
+local members = redis.call("SMEMBERS", KEYS[1])
+for i = 1, #members, 500 do
+	redis.call("DEL", unpack(members, i, math.min(i + 499, #members)))
+end
+redis.call("DEL", KEYS[1])
+return members
+`)
+
+// tagKeyScript adds a member to a tag and only ever extends the tag ttl, so a
+// short lived member never expires the tag of a longer lived one. A member
+// without ttl makes the tag persistent.
+var tagKeyScript = goredis.NewScript(`
+local existed = redis.call("EXISTS", KEYS[1])
+redis.call("SADD", KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl <= 0 then
+	redis.call("PERSIST", KEYS[1])
+	return 0
+end
+local current = redis.call("PTTL", KEYS[1])
+if existed == 0 or (current >= 0 and current < ttl) then
+	redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return 0
+`)
+
+// NewCache return cache based on config, caching can be disabled entirely
+// or fronted by an in-process lru to offload redis on hot keys.
+func NewCache(redisClient *goredis.Client) (model.Cache, error) {
+	if config.DisableCaching() {
+		return NewNoopCache(), nil
+	}
+	redisCache := NewRedisCache(redisClient)
+	if !config.LocalCacheEnabled() {
+		return redisCache, nil
+	}
+	return NewTieredCache(redisClient, config.LocalCacheSize(), config.LocalCacheTTL())
+}
+
+type noopCache struct{}
+
+func NewNoopCache() model.Cache {
+	return new(noopCache)
+}
+
+func (*noopCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (*noopCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	return nil
+}
+
+func (*noopCache) HGet(ctx context.Context, key string, field string) ([]byte, error) {
+	return nil, nil
+}
+
+func (*noopCache) HSet(ctx context.Context, key string, field string, value []byte, ttl time.Duration, tags ...string) error {
+	return nil
+}
+
+func (*noopCache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (*noopCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	return nil
+}
+
+func (*noopCache) Close() error {
+	return nil
+}
+
+type redisCache struct {
+	client *goredis.Client
+}
+
+func NewRedisCache(client *goredis.Client) model.Cache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		metrics.ObserveCacheLookup(model.CacheTierRedis, false)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveCacheLookup(model.CacheTierRedis, true)
+	return data, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, key, value, ttl)
+		tagKey(ctx, pipe, tags, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (c *redisCache) HGet(ctx context.Context, key string, field string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, key, field).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		metrics.ObserveCacheLookup(model.CacheTierRedis, false)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.ObserveCacheLookup(model.CacheTierRedis, true)
+	return data, nil
+}
+
+func (c *redisCache) HSet(ctx context.Context, key string, field string, value []byte, ttl time.Duration, tags ...string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, key, field, value)
+		pipe.ExpireNX(ctx, key, ttl)
+		tagKey(ctx, pipe, tags, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *redisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	_, err := c.invalidateTags(ctx, tags...)
+	return err
+}
+
+func (*redisCache) Close() error {
+	return nil
+}
+
+func (c *redisCache) invalidateTags(ctx context.Context, tags ...string) ([]string, error) {
+	droppedKeys := make([]string, 0)
+	for _, tag := range tags {
+		keys, err := invalidateTagScript.Run(ctx, c.client, []string{tag}).StringSlice()
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			return droppedKeys, err
+		}
+		droppedKeys = append(droppedKeys, keys...)
+	}
+	return droppedKeys, nil
+}
+
+// tagKey records key as a member of every tag, the tag ttl is only ever
+// extended so the tag outlives its longest lived member.
+func tagKey(ctx context.Context, pipe goredis.Pipeliner, tags []string, key string, ttl time.Duration) {
+	for _, tag := range tags {
+		tagKeyScript.Eval(ctx, pipe, []string{tag}, key, ttl.Milliseconds())
+	}
+}
+
+type localEntry struct {
+	value     []byte
+	fields    map[string][]byte
+	expiredAt time.Time
+}
+
+// tieredCache keeps hot entries in process and falls back to redis,
+// writes and invalidations are broadcast so every other instance drops its local copy.
+type tieredCache struct {
+	id       string
+	local    *lru.Cache[string, *localEntry]
+	remote   *redisCache
+	localTTL time.Duration
+	pubsub   *goredis.PubSub
+	mu       sync.Mutex
+}
+
+func NewTieredCache(client *goredis.Client, size int, localTTL time.Duration) (model.Cache, error) {
+	local, err := lru.New[string, *localEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	c := &tieredCache{
+		id:       utils.GenerateUUID(),
+		local:    local,
+		remote:   &redisCache{client: client},
+		localTTL: localTTL,
+		pubsub:   client.Subscribe(context.Background(), model.CacheInvalidationChannel),
+	}
+	go c.listenInvalidation()
+
+	return c, nil
+}
+
+// Close stops listening for invalidations, the redis client stays open.
+func (c *tieredCache) Close() error {
+	return c.pubsub.Close()
+}
+
+func (c *tieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if entry, ok := c.getLocal(key); ok && entry.value != nil {
+		metrics.ObserveCacheLookup(model.CacheTierLocal, true)
+		return entry.value, nil
+	}
+	metrics.ObserveCacheLookup(model.CacheTierLocal, false)
+
+	data, err := c.remote.Get(ctx, key)
+	if err != nil || data == nil {
+		return data, err
+	}
+	c.local.Add(key, &localEntry{value: data, expiredAt: time.Now().Add(c.localTTL)})
+	return data, nil
+}
+
+func (c *tieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	err := c.remote.Set(ctx, key, value, ttl, tags...)
+	if err != nil {
+		return err
+	}
+	c.local.Add(key, &localEntry{value: value, expiredAt: time.Now().Add(c.ttl(ttl))})
+	c.broadcast(ctx, []string{key})
+	return nil
+}
+
+func (c *tieredCache) HGet(ctx context.Context, key string, field string) ([]byte, error) {
+	if entry, ok := c.getLocal(key); ok {
+		c.mu.Lock()
+		data, found := entry.fields[field]
+		c.mu.Unlock()
+		if found {
+			metrics.ObserveCacheLookup(model.CacheTierLocal, true)
+			return data, nil
+		}
+	}
+	metrics.ObserveCacheLookup(model.CacheTierLocal, false)
+
+	data, err := c.remote.HGet(ctx, key, field)
+	if err != nil || data == nil {
+		return data, err
+	}
+	c.setLocalField(key, field, data, c.localTTL)
+	return data, nil
+}
+
+func (c *tieredCache) HSet(ctx context.Context, key string, field string, value []byte, ttl time.Duration, tags ...string) error {
+	err := c.remote.HSet(ctx, key, field, value, ttl, tags...)
+	if err != nil {
+		return err
+	}
+	c.setLocalField(key, field, value, c.ttl(ttl))
+	c.broadcast(ctx, []string{key})
+	return nil
+}
+
+func (c *tieredCache) Delete(ctx context.Context, keys ...string) error {
+	c.removeLocal(keys)
+	err := c.remote.Delete(ctx, keys...)
+	if err != nil {
+		return err
+	}
+	c.broadcast(ctx, keys)
+	return nil
+}
+
+func (c *tieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	keys, err := c.remote.invalidateTags(ctx, tags...)
+	c.removeLocal(keys)
+	c.broadcast(ctx, keys)
+	return err
+}
+
+func (c *tieredCache) getLocal(key string) (*localEntry, bool) {
+	entry, ok := c.local.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiredAt) {
+		c.local.Remove(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+func (c *tieredCache) setLocalField(key string, field string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// an entry cached by Set holds a value and no fields, it is replaced like an expired one
+	entry, ok := c.local.Peek(key)
+	if !ok || entry.fields == nil || time.Now().After(entry.expiredAt) {
+		entry = &localEntry{fields: map[string][]byte{}, expiredAt: time.Now().Add(ttl)}
+	}
+	entry.fields[field] = value
+	c.local.Add(key, entry)
+}
+
+func (c *tieredCache) removeLocal(keys []string) {
+	for _, key := range keys {
+		c.local.Remove(key)
+	}
+}
+
+// ttl keeps local entries no longer than remote entries.
+func (c *tieredCache) ttl(ttl time.Duration) time.Duration {
+	if ttl > 0 && ttl < c.localTTL {
+		return ttl
+	}
+	return c.localTTL
+}
+
+func (c *tieredCache) broadcast(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	payload, err := json.Marshal(model.CacheInvalidationPayload{Origin: c.id, Keys: keys})
+	if err != nil {
+		logrus.Error(err.Error())
+		return
+	}
+	err = c.remote.client.Publish(ctx, model.CacheInvalidationChannel, payload).Err()
+	if err != nil {
+		// other instances catch up once their local ttl elapsed
+		logrus.WithField("keys", keys).Error(err.Error())
+	}
+}
+
+// listenInvalidation runs until Close.
+func (c *tieredCache) listenInvalidation() {
+	for msg := range c.pubsub.Channel() {
+		payload := new(model.CacheInvalidationPayload)
+		if err := json.Unmarshal([]byte(msg.Payload), payload); err != nil {
+			logrus.Error(err.Error())
+			continue
+		}
+		if payload.Origin == c.id {
+			continue
+		}
+		c.removeLocal(payload.Keys)
+	}
+}
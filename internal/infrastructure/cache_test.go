@@ -0,0 +1,201 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisClient(t *testing.T) (*goredis.Client, *miniredis.Miniredis) {
+	miniRedis := miniredis.RunT(t)
+	return goredis.NewClient(&goredis.Options{Addr: miniRedis.Addr()}), miniRedis
+}
+
+func TestNewCache(t *testing.T) {
+	tests := []struct {
+		name              string
+		disableCaching    bool
+		localCacheEnabled bool
+		want              any
+	}{
+		{
+			name:           "caching disabled",
+			disableCaching: true,
+			want:           &noopCache{},
+		},
+		{
+			name: "redis only",
+			want: &redisCache{},
+		},
+		{
+			name:              "local tier in front of redis",
+			localCacheEnabled: true,
+			want:              &tieredCache{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("redis.disable_caching", tt.disableCaching)
+			viper.Set("redis.local_cache.enabled", tt.localCacheEnabled)
+			defer viper.Reset()
+
+			redisClient, _ := newTestRedisClient(t)
+			cache, err := NewCache(redisClient)
+			assert.NoError(t, err)
+			assert.IsType(t, tt.want, cache)
+		})
+	}
+}
+
+func Test_noopCache(t *testing.T) {
+	ctx := context.TODO()
+	cache := NewNoopCache()
+
+	assert.NoError(t, cache.Set(ctx, "key", []byte("data"), time.Minute))
+	data, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func Test_redisCache_miss(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, _ := newTestRedisClient(t)
+	cache := NewRedisCache(redisClient)
+
+	data, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = cache.HGet(ctx, "bucket", "field")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func Test_redisCache_tagTTL(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, miniRedis := newTestRedisClient(t)
+	cache := NewRedisCache(redisClient)
+
+	assert.NoError(t, cache.Set(ctx, "long", []byte("data"), time.Hour, "tags:a"))
+	assert.Equal(t, time.Hour, miniRedis.TTL("tags:a"))
+
+	// a shorter lived member never shortens the tag
+	assert.NoError(t, cache.Set(ctx, "short", []byte("data"), time.Minute, "tags:a"))
+	assert.NoError(t, cache.HSet(ctx, "bucket", "field", []byte("data"), time.Second, "tags:a"))
+	assert.Equal(t, time.Hour, miniRedis.TTL("tags:a"))
+
+	// a longer lived member extends it
+	assert.NoError(t, cache.Set(ctx, "longer", []byte("data"), 2*time.Hour, "tags:a"))
+	assert.Equal(t, 2*time.Hour, miniRedis.TTL("tags:a"))
+
+	// a member without ttl keeps the tag for good
+	assert.NoError(t, cache.Set(ctx, "forever", []byte("data"), 0, "tags:a"))
+	assert.NoError(t, cache.Set(ctx, "short", []byte("data"), time.Minute, "tags:a"))
+	assert.Equal(t, time.Duration(0), miniRedis.TTL("tags:a"))
+
+	members, err := miniRedis.Members("tags:a")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"long", "short", "bucket", "longer", "forever"}, members)
+}
+
+func Test_tieredCache_localHit(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, miniRedis := newTestRedisClient(t)
+	cache, err := NewTieredCache(redisClient, 10, time.Minute)
+	assert.NoError(t, err)
+
+	assert.NoError(t, cache.Set(ctx, "key", []byte("data"), time.Minute, "tags:a"))
+	assert.NoError(t, cache.HSet(ctx, "bucket", "field", []byte("field-data"), time.Minute, "tags:a"))
+
+	// served from process memory even when redis lost the entries
+	miniRedis.FlushAll()
+
+	data, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	data, err = cache.HGet(ctx, "bucket", "field")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("field-data"), data)
+}
+
+func Test_tieredCache_hsetOverValue(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, _ := newTestRedisClient(t)
+	cache, err := NewTieredCache(redisClient, 10, time.Minute)
+	assert.NoError(t, err)
+
+	assert.NoError(t, cache.Set(ctx, "key", []byte("data"), time.Minute))
+
+	// redis dropped the value while the local value entry of the key is still around
+	assert.NoError(t, redisClient.Del(ctx, "key").Err())
+	assert.NoError(t, cache.HSet(ctx, "key", "field", []byte("field-data"), time.Minute))
+
+	data, err := cache.HGet(ctx, "key", "field")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("field-data"), data)
+}
+
+func Test_tieredCache_localExpiry(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, _ := newTestRedisClient(t)
+	cache, err := NewTieredCache(redisClient, 10, time.Millisecond)
+	assert.NoError(t, err)
+
+	assert.NoError(t, cache.Set(ctx, "key", []byte("data"), time.Minute))
+	time.Sleep(5 * time.Millisecond)
+
+	// local copy expired, value comes back from redis
+	data, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
+
+func Test_tieredCache_invalidationBroadcast(t *testing.T) {
+	ctx := context.TODO()
+	redisClient, _ := newTestRedisClient(t)
+
+	instanceA, err := NewTieredCache(redisClient, 10, time.Minute)
+	assert.NoError(t, err)
+	instanceB, err := NewTieredCache(redisClient, 10, time.Minute)
+	assert.NoError(t, err)
+
+	assert.NoError(t, instanceA.Set(ctx, "key:1", []byte("data"), time.Minute, "tags:a"))
+	assert.NoError(t, instanceA.Set(ctx, "key:2", []byte("data"), time.Minute))
+
+	// warm the local tier of the other instance
+	for _, key := range []string{"key:1", "key:2"} {
+		data, err := instanceB.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+	}
+
+	assert.NoError(t, instanceA.InvalidateTags(ctx, "tags:a"))
+	assert.NoError(t, instanceA.Delete(ctx, "key:2"))
+
+	for _, key := range []string{"key:1", "key:2"} {
+		assert.Eventually(t, func() bool {
+			data, err := instanceB.Get(ctx, key)
+			return err == nil && data == nil
+		}, time.Second, 10*time.Millisecond, "key %s still cached on other instance", key)
+	}
+
+	// an overwrite drops the stale copy of the other instance but not the own one
+	assert.NoError(t, instanceA.Set(ctx, "key:3", []byte("old"), time.Minute))
+	data, err := instanceB.Get(ctx, "key:3")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("old"), data)
+	assert.NoError(t, instanceA.Set(ctx, "key:3", []byte("new"), time.Minute))
+	assert.Eventually(t, func() bool {
+		data, err := instanceB.Get(ctx, "key:3")
+		return err == nil && string(data) == "new"
+	}, time.Second, 10*time.Millisecond, "overwritten key still stale on other instance")
+
+	assert.NoError(t, instanceA.Close())
+	assert.NoError(t, instanceB.Close())
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker opens after consecutive failures and lets a single probe
+// through once the open timeout elapsed, the probe result closes or reopens it.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	failures         int
+	openedAt         time.Time
+	probing          bool
+}
+
+func newCircuitBreaker(failureThreshold int, openTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+func (b *circuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.failureThreshold {
+		return nil
+	}
+	if b.probing || time.Since(b.openedAt) < b.openTimeout {
+		return ErrCircuitOpen
+	}
+	b.probing = true
+	return nil
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.failureThreshold {
+		b.openedAt = time.Now()
+	}
+}
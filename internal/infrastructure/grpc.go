@@ -0,0 +1,48 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func NewGRPCClientConn(target string) (*grpc.ClientConn, error) {
+	return grpc.Dial(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(unaryTraceClientInterceptor()),
+	)
+}
+
+// unaryTraceClientInterceptor forwards the current trace context to the callee.
+func unaryTraceClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		utils.InjectTraceContext(ctx, utils.MetadataCarrier(md))
+		return invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	}
+}
+
+// GracefulStopGRPCServer waits for in-flight rpcs and cuts them off once ctx is done.
+func GracefulStopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
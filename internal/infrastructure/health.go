@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNATSNotConnected = errors.New("nats not connected")
+	ErrGRPCNotReady     = errors.New("grpc connection not ready")
+)
+
+// HealthChecker serves liveness and readiness over http and grpc.health.v1,
+// readiness runs every dependency check concurrently, each under its own timeout.
+type HealthChecker struct {
+	*health.Server
+
+	checks   map[string]model.HealthCheck
+	timeout  time.Duration
+	draining int32
+}
+
+func NewHealthChecker(timeout time.Duration) *HealthChecker {
+	return &HealthChecker{
+		Server:  health.NewServer(),
+		checks:  map[string]model.HealthCheck{},
+		timeout: timeout,
+	}
+}
+
+func (h *HealthChecker) AddCheck(name string, check model.HealthCheck) {
+	h.checks[name] = check
+}
+
+// SetDraining fails readiness from now on so traffic is routed away before shutdown.
+func (h *HealthChecker) SetDraining() {
+	atomic.StoreInt32(&h.draining, 1)
+	h.Server.Shutdown()
+}
+
+func (h *HealthChecker) IsDraining() bool {
+	return atomic.LoadInt32(&h.draining) == 1
+}
+
+// Ready returns the result of every check, ready is false when any of them failed.
+func (h *HealthChecker) Ready(ctx context.Context) (*model.HealthResponse, bool) {
+	if h.IsDraining() {
+		return &model.HealthResponse{Status: model.HealthStatusDraining}, false
+	}
+
+	res := &model.HealthResponse{
+		Status: model.HealthStatusOK,
+		Checks: make(map[string]string, len(h.checks)),
+	}
+	ready := true
+
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check model.HealthCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			// the detail stays in the logs, the endpoint is unauthenticated
+			status := model.HealthStatusOK
+			if err := check(checkCtx); err != nil {
+				logrus.WithField("check", name).Warn(err.Error())
+				status = model.HealthStatusFailed
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			res.Checks[name] = status
+			if status != model.HealthStatusOK {
+				ready = false
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if !ready {
+		res.Status = model.HealthStatusFailed
+	}
+	return res, ready
+}
+
+func (h *HealthChecker) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, http.StatusOK, &model.HealthResponse{Status: model.HealthStatusOK})
+}
+
+func (h *HealthChecker) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	res, ready := h.Ready(r.Context())
+	if !ready {
+		writeHealthResponse(w, http.StatusServiceUnavailable, res)
+		return
+	}
+	writeHealthResponse(w, http.StatusOK, res)
+}
+
+// Check reports readiness for the whole server, named services keep the static status.
+func (h *HealthChecker) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" {
+		return h.Server.Check(ctx, req)
+	}
+	if _, ready := h.Ready(ctx); !ready {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func writeHealthResponse(w http.ResponseWriter, code int, res *model.HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func NewDatabaseHealthCheck(db *gorm.DB) model.HealthCheck {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func NewRedisHealthCheck(client *goredis.Client) model.HealthCheck {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// NewS3HealthCheck heads every bucket the service writes to, the default and tenant
+// buckets from the config and the buckets routed to by object types.
+func NewS3HealthCheck(client model.S3Client, objectTypeRepo model.ObjectTypeRepository) model.HealthCheck {
+	return func(ctx context.Context) error {
+		typeBuckets, err := objectTypeRepo.FindBuckets(ctx)
+		if err != nil {
+			return err
+		}
+
+		unique := make(map[string]bool)
+		for _, bucket := range append(config.S3BucketNames(), typeBuckets...) {
+			if unique[bucket] {
+				continue
+			}
+			unique[bucket] = true
+
+			bucketName := bucket
+			_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+				Bucket: &bucketName,
+			})
+			if err != nil {
+				return fmt.Errorf("bucket %s: %w", bucket, err)
+			}
+		}
+		return nil
+	}
+}
+
+func NewNATSHealthCheck(nc *nats.Conn) model.HealthCheck {
+	return func(ctx context.Context) error {
+		if nc.Status() != nats.CONNECTED {
+			return ErrNATSNotConnected
+		}
+		return nil
+	}
+}
+
+// NewGRPCConnHealthCheck waits for the connection to become ready,
+// an idle connection is kicked so a lazy dial does not report unhealthy.
+func NewGRPCConnHealthCheck(conn *grpc.ClientConn) model.HealthCheck {
+	return func(ctx context.Context) error {
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Idle:
+				conn.Connect()
+			case connectivity.Shutdown:
+				return ErrGRPCNotReady
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return ErrGRPCNotReady
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]model.HealthCheck
+		draining   bool
+		wantReady  bool
+		wantStatus string
+		wantCode   int
+	}{
+		{
+			name: "ready",
+			checks: map[string]model.HealthCheck{
+				"database": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return nil },
+			},
+			wantReady:  true,
+			wantStatus: model.HealthStatusOK,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "failed check",
+			checks: map[string]model.HealthCheck{
+				"database": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+			wantReady:  false,
+			wantStatus: model.HealthStatusFailed,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name: "check exceeds timeout",
+			checks: map[string]model.HealthCheck{
+				"s3": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			wantReady:  false,
+			wantStatus: model.HealthStatusFailed,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name: "draining",
+			checks: map[string]model.HealthCheck{
+				"database": func(ctx context.Context) error { return nil },
+			},
+			draining:   true,
+			wantReady:  false,
+			wantStatus: model.HealthStatusDraining,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthChecker(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				h.AddCheck(name, check)
+			}
+			if tt.draining {
+				h.SetDraining()
+			}
+
+			res, ready := h.Ready(context.TODO())
+			assert.Equal(t, tt.wantReady, ready)
+			assert.Equal(t, tt.wantStatus, res.Status)
+			for _, status := range res.Checks {
+				assert.Contains(t, []string{model.HealthStatusOK, model.HealthStatusFailed}, status)
+			}
+
+			rec := httptest.NewRecorder()
+			h.HandleReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+
+			rec = httptest.NewRecorder()
+			h.HandleLiveness(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			wantServing := healthpb.HealthCheckResponse_NOT_SERVING
+			if tt.wantReady {
+				wantServing = healthpb.HealthCheckResponse_SERVING
+			}
+			grpcRes, err := h.Check(context.TODO(), &healthpb.HealthCheckRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, wantServing, grpcRes.GetStatus())
+		})
+	}
+}
+
+func TestNewS3HealthCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		typeBuckets     []string
+		findBucketsErr  error
+		failedBucket    string
+		wantHeadBuckets []string
+		wantErr         bool
+	}{
+		{
+			name:            "success every bucket once",
+			typeBuckets:     []string{"media", "tenant-acme"},
+			wantHeadBuckets: []string{"default", "tenant-acme", "media"},
+			wantErr:         false,
+		},
+		{
+			name:            "error type bucket missing",
+			typeBuckets:     []string{"media"},
+			failedBucket:    "media",
+			wantHeadBuckets: []string{"default", "tenant-acme", "media"},
+			wantErr:         true,
+		},
+		{
+			name:           "error find type buckets",
+			findBucketsErr: errors.New("db error"),
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("s3.bucket", "default")
+			viper.Set("tenants", map[string]any{"acme": map[string]any{"bucket": "tenant-acme"}})
+			defer viper.Set("s3.bucket", nil)
+			defer viper.Set("tenants", nil)
+
+			s3Client := mock.NewMockS3Client(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			objectTypeRepo.EXPECT().
+				FindBuckets(gomock.Any()).
+				Times(1).
+				Return(tt.typeBuckets, tt.findBucketsErr)
+
+			headed := make([]string, 0)
+			s3Client.EXPECT().
+				HeadBucket(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				DoAndReturn(func(_ context.Context, input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+					headed = append(headed, *input.Bucket)
+					if *input.Bucket == tt.failedBucket {
+						return nil, errors.New("not found")
+					}
+					return &s3.HeadBucketOutput{}, nil
+				})
+
+			err := NewS3HealthCheck(s3Client, objectTypeRepo)(context.TODO())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.ElementsMatch(t, tt.wantHeadBuckets, headed)
+		})
+	}
+}
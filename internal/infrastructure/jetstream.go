@@ -1,11 +1,16 @@
 package infrastructure
 
 import (
+	"context"
+	"time"
+
 	"github.com/krobus00/storage-service/internal/config"
 	"github.com/nats-io/nats.go"
 	"github.com/sirupsen/logrus"
 )
 
+const jetstreamDrainPollInterval = 50 * time.Millisecond
+
 func NewJetstreamClient() (*nats.Conn, nats.JetStreamContext, error) {
 	// Connect to NATS
 	nc, err := nats.Connect(config.JetstreamHost(),
@@ -30,3 +35,22 @@ func NewJetstreamClient() (*nats.Conn, nats.JetStreamContext, error) {
 
 	return nc, js, nil
 }
+
+// DrainJetstream flushes pending publishes and waits until the connection is closed.
+func DrainJetstream(ctx context.Context, nc *nats.Conn) error {
+	err := nc.Drain()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(jetstreamDrainPollInterval)
+	defer ticker.Stop()
+	for !nc.IsClosed() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+type localKeyring struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// NewLocalKeyring loads the master keys from the config, retired keys stay
+// configured until every data key they wrap is rewrapped. Key ids are case
+// insensitive, viper lowercases the map keys.
+func NewLocalKeyring() (model.Keyring, error) {
+	keyring := &localKeyring{
+		activeKeyID: strings.ToLower(config.EncryptionActiveKeyID()),
+		keys:        make(map[string][]byte),
+	}
+	for keyID, encoded := range config.EncryptionKeys() {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != utils.DataKeySize {
+			return nil, fmt.Errorf("encryption key %s must be %d base64 encoded bytes", keyID, utils.DataKeySize)
+		}
+		keyring.keys[strings.ToLower(keyID)] = key
+	}
+	if _, ok := keyring.keys[keyring.activeKeyID]; keyring.activeKeyID != "" && !ok {
+		return nil, fmt.Errorf("active encryption key %s is not configured", keyring.activeKeyID)
+	}
+	return keyring, nil
+}
+
+func (k *localKeyring) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+func (k *localKeyring) WrapKey(dataKey []byte) (string, []byte, error) {
+	key, ok := k.keys[k.activeKeyID]
+	if !ok {
+		return "", nil, model.ErrEncryptionKeyNotFound
+	}
+	wrapped, err := utils.SealAESGCM(key, dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return k.activeKeyID, wrapped, nil
+}
+
+func (k *localKeyring) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[strings.ToLower(keyID)]
+	if !ok {
+		return nil, model.ErrEncryptionKeyNotFound
+	}
+	return utils.OpenAESGCM(key, wrapped)
+}
@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKeyRetired = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+	testKeyActive  = "HxwdHhscGRoXGBUWExQREg8QDQ4LDAkKBwgFBgMEAQI="
+)
+
+func TestNewLocalKeyring(t *testing.T) {
+	tests := []struct {
+		name      string
+		activeKey string
+		keys      map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "success mixed case key ids",
+			activeKey: "Key2024",
+			keys:      map[string]string{"Key2023": testKeyRetired, "Key2024": testKeyActive},
+		},
+		{
+			name:      "success without keys",
+			activeKey: "",
+			keys:      map[string]string{},
+		},
+		{
+			name:      "error active key not configured",
+			activeKey: "key2025",
+			keys:      map[string]string{"key2024": testKeyActive},
+			wantErr:   true,
+		},
+		{
+			name:      "error invalid key size",
+			activeKey: "key2024",
+			keys:      map[string]string{"key2024": "AAECAw=="},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("encryption.active_key", tt.activeKey)
+			viper.Set("encryption.keys", tt.keys)
+			defer viper.Set("encryption", nil)
+
+			_, err := NewLocalKeyring()
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestLocalKeyring_WrapUnwrap(t *testing.T) {
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	// wrapped before the rotation
+	viper.Set("encryption.active_key", "key2023")
+	viper.Set("encryption.keys", map[string]string{"key2023": testKeyRetired})
+	defer viper.Set("encryption", nil)
+	keyring, err := NewLocalKeyring()
+	assert.NoError(t, err)
+	retiredKeyID, retiredWrapped, err := keyring.WrapKey(dataKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "key2023", retiredKeyID)
+
+	// rotated, the retired key is kept to unwrap older data keys
+	viper.Set("encryption.active_key", "Key2024")
+	viper.Set("encryption.keys", map[string]string{"key2023": testKeyRetired, "Key2024": testKeyActive})
+	keyring, err = NewLocalKeyring()
+	assert.NoError(t, err)
+	assert.Equal(t, "key2024", keyring.ActiveKeyID())
+
+	got, err := keyring.UnwrapKey(retiredKeyID, retiredWrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, dataKey, got)
+
+	keyID, wrapped, err := keyring.WrapKey(dataKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "key2024", keyID)
+	got, err = keyring.UnwrapKey("KEY2024", wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, dataKey, got)
+
+	_, err = keyring.UnwrapKey("key2022", wrapped)
+	assert.Equal(t, model.ErrEncryptionKeyNotFound, err)
+	_, err = keyring.UnwrapKey(retiredKeyID, wrapped)
+	assert.Error(t, err, "wrapped with another key")
+}
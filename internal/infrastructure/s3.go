@@ -3,11 +3,13 @@ package infrastructure
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
 )
 
@@ -35,9 +37,43 @@ func NewS3Client() (model.S3Client, error) {
 }
 
 func (i *s3Client) PutObject(ctx context.Context, params *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
-	return i.client.PutObject(ctx, params)
+	start := time.Now()
+	res, err := i.client.PutObject(ctx, params)
+	metrics.ObserveS3Request(metrics.S3OperationPutObject, start, err)
+	return res, err
 }
 
-func (i *s3Client) PresignGetObject(ctx context.Context, params *s3.GetObjectInput) (*v4.PresignedHTTPRequest, error) {
-	return s3.NewPresignClient(i.client).PresignGetObject(ctx, params)
+func (i *s3Client) GetObject(ctx context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	start := time.Now()
+	res, err := i.client.GetObject(ctx, params)
+	metrics.ObserveS3Request(metrics.S3OperationGetObject, start, err)
+	return res, err
+}
+
+func (i *s3Client) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	start := time.Now()
+	res, err := i.client.DeleteObject(ctx, params)
+	metrics.ObserveS3Request(metrics.S3OperationDeleteObject, start, err)
+	return res, err
+}
+
+func (i *s3Client) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	start := time.Now()
+	res, err := i.client.ListObjectsV2(ctx, params)
+	metrics.ObserveS3Request(metrics.S3OperationListObjectsV2, start, err)
+	return res, err
+}
+
+func (i *s3Client) HeadBucket(ctx context.Context, params *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	start := time.Now()
+	res, err := i.client.HeadBucket(ctx, params)
+	metrics.ObserveS3Request(metrics.S3OperationHeadBucket, start, err)
+	return res, err
+}
+
+func (i *s3Client) PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	start := time.Now()
+	res, err := s3.NewPresignClient(i.client).PresignGetObject(ctx, params, optFns...)
+	metrics.ObserveS3Request(metrics.S3OperationPresignGetObject, start, err)
+	return res, err
 }
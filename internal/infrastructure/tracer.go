@@ -1,52 +1,36 @@
 package infrastructure
 
 import (
-	"fmt"
-	"net"
+	"context"
 
 	"github.com/krobus00/storage-service/internal/config"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-func JaegerTraceProvider() (tp *sdktrace.TracerProvider, err error) {
-	var exp *jaeger.Exporter
-
-	jaegerURL := net.JoinHostPort(config.JaegerHost(), config.JaegerPort())
-	switch config.JaegerProtocol() {
-	case "http":
-		exp, err = jaeger.New(
-			jaeger.WithCollectorEndpoint(
-				jaeger.WithEndpoint(fmt.Sprintf("http://%s/api/traces", jaegerURL)),
-			),
-		)
-	case "grpc":
-		exp, err = jaeger.New(
-			jaeger.WithAgentEndpoint(
-				jaeger.WithAgentHost(config.JaegerHost()),
-				jaeger.WithAgentPort(config.JaegerPort()),
-			),
-		)
-	default:
-		exp, err = jaeger.New(
-			jaeger.WithCollectorEndpoint(
-				jaeger.WithEndpoint(fmt.Sprintf("http://%s/api/traces", jaegerURL)),
-			),
-		)
-	}
+const (
+	TracerExporterGRPC = "grpc"
+	TracerExporterHTTP = "http"
+)
 
+// NewTraceProvider exports spans over otlp and registers the w3c propagator,
+// so incoming trace context is continued instead of starting a new trace.
+func NewTraceProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	exp, err := newTraceExporter(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	tp = sdktrace.NewTracerProvider(
+	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exp),
 		sdktrace.WithSampler(sdktrace.ParentBased(
-			sdktrace.TraceIDRatioBased(config.JaegerSampleRate())),
+			sdktrace.TraceIDRatioBased(config.TracerSampleRate())),
 		),
 		sdktrace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -61,3 +45,24 @@ func JaegerTraceProvider() (tp *sdktrace.TracerProvider, err error) {
 
 	return tp, nil
 }
+
+func newTraceExporter(ctx context.Context) (*otlptrace.Exporter, error) {
+	switch config.TracerExporter() {
+	case TracerExporterHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.TracerEndpoint()),
+		}
+		if config.TracerInsecure() {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.TracerEndpoint()),
+		}
+		if config.TracerInsecure() {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	}
+}
@@ -0,0 +1,249 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "storage_service"
+
+const (
+	ResultHit  = "hit"
+	ResultMiss = "miss"
+
+	OutcomeSuccess      = "success"
+	OutcomeError        = "error"
+	OutcomeNotFound     = "not_found"
+	OutcomeUnauthorized = "unauthorized"
+	OutcomeRejected     = "rejected"
+	OutcomeUnavailable  = "unavailable"
+	OutcomeAllowed      = "allowed"
+	OutcomeDenied       = "denied"
+
+	RejectReasonUnknownType         = "unknown_type"
+	RejectReasonUnknownContentType  = "unknown_content_type"
+	RejectReasonExtensionNotAllowed = "extension_not_allowed"
+
+	RepositoryObject              = "object"
+	RepositoryObjectType          = "object_type"
+	RepositoryObjectWhitelistType = "object_whitelist_type"
+	RepositoryObjectGrant         = "object_grant"
+
+	S3OperationPutObject        = "PutObject"
+	S3OperationGetObject        = "GetObject"
+	S3OperationDeleteObject     = "DeleteObject"
+	S3OperationListObjectsV2    = "ListObjectsV2"
+	S3OperationHeadBucket       = "HeadBucket"
+	S3OperationPresignGetObject = "PresignGetObject"
+
+	// UnknownLabel stands in for user supplied values that did not resolve,
+	// so an arbitrary type name never becomes a label value.
+	UnknownLabel = "unknown"
+)
+
+var (
+	CacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Total number of cache lookups by tier and result.",
+	}, []string{"tier", "result"})
+
+	RepositoryCacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repository_cache_requests_total",
+		Help:      "Total number of repository cache lookups by repository and result.",
+	}, []string{"repository", "result"})
+
+	UploadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "uploads_total",
+		Help:      "Total number of uploads by object type and outcome.",
+	}, []string{"type", "outcome"})
+
+	UploadBytesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upload_bytes_total",
+		Help:      "Total number of bytes stored by successful uploads by object type.",
+	}, []string{"type"})
+
+	UploadDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upload_duration_seconds",
+		Help:      "Upload latency by object type and outcome.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"type", "outcome"})
+
+	PresignDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "presign_duration_seconds",
+		Help:      "Presigned url generation latency by object type and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "outcome"})
+
+	DeletesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deletes_total",
+		Help:      "Total number of object deletions by object type and outcome.",
+	}, []string{"type", "outcome"})
+
+	ValidationRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "validation_rejections_total",
+		Help:      "Total number of uploads rejected by validation by object type and reason.",
+	}, []string{"type", "reason"})
+
+	S3RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "s3_request_duration_seconds",
+		Help:      "S3 call latency by operation and outcome.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"operation", "outcome"})
+
+	AuthHasAccessDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "auth_has_access_duration_seconds",
+		Help:      "Auth service HasAccess latency by outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"outcome"})
+
+	AuthHasAccessErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_has_access_errors_total",
+		Help:      "Total number of failed auth service HasAccess calls by grpc code.",
+	}, []string{"code"})
+
+	GCPurgedObjectsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gc_purged_objects_total",
+		Help:      "Total number of purged temporary objects by outcome.",
+	}, []string{"outcome"})
+
+	VerifyFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "verify_failures_total",
+		Help:      "Total number of objects failing verification by status.",
+	}, []string{"status"})
+
+	JetstreamPublishFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jetstream_publish_failures_total",
+		Help:      "Total number of failed jetstream publishes by subject.",
+	}, []string{"subject"})
+
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of http requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Http request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Outcome maps an usecase error to a low cardinality outcome label.
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, model.ErrObjectNotFound):
+		return OutcomeNotFound
+	case errors.Is(err, model.ErrUnauthorizeAccess):
+		return OutcomeUnauthorized
+	case errors.Is(err, model.ErrAuthServiceUnavailable):
+		return OutcomeUnavailable
+	case errors.Is(err, model.ErrObjectTypeNotFound),
+		errors.Is(err, model.ErrExtensionNotAllowed),
+		errors.Is(err, model.ErrInvalidMinValidity),
+		errors.Is(err, model.ErrInvalidRange):
+		return OutcomeRejected
+	default:
+		return OutcomeError
+	}
+}
+
+func ObserveCacheLookup(tier string, hit bool) {
+	CacheRequestsTotal.WithLabelValues(tier, result(hit)).Inc()
+}
+
+func ObserveRepositoryCacheLookup(repository string, hit bool) {
+	RepositoryCacheRequestsTotal.WithLabelValues(repository, result(hit)).Inc()
+}
+
+func ObserveUpload(objectType string, size int, start time.Time, err error) {
+	outcome := Outcome(err)
+	UploadsTotal.WithLabelValues(objectType, outcome).Inc()
+	UploadDuration.WithLabelValues(objectType, outcome).Observe(time.Since(start).Seconds())
+	if err == nil {
+		UploadBytesTotal.WithLabelValues(objectType).Add(float64(size))
+	}
+}
+
+func ObservePresign(objectType string, start time.Time, err error) {
+	PresignDuration.WithLabelValues(objectType, Outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+func ObserveDelete(objectType string, err error) {
+	DeletesTotal.WithLabelValues(objectType, Outcome(err)).Inc()
+}
+
+func ObserveValidationRejection(objectType string, reason string) {
+	ValidationRejectionsTotal.WithLabelValues(objectType, reason).Inc()
+}
+
+func ObserveS3Request(operation string, start time.Time, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+	}
+	S3RequestDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+func ObserveAuthHasAccess(start time.Time, allowed bool, err error) {
+	outcome := OutcomeDenied
+	switch {
+	case err != nil:
+		outcome = OutcomeError
+		AuthHasAccessErrorsTotal.WithLabelValues(status.Code(err).String()).Inc()
+	case allowed:
+		outcome = OutcomeAllowed
+	}
+	AuthHasAccessDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+}
+
+func ObserveGCPurge(err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeError
+	}
+	GCPurgedObjectsTotal.WithLabelValues(outcome).Inc()
+}
+
+func ObserveVerifyFailure(status string) {
+	VerifyFailuresTotal.WithLabelValues(status).Inc()
+}
+
+func ObserveJetstreamPublishFailure(subject string) {
+	JetstreamPublishFailuresTotal.WithLabelValues(subject).Inc()
+}
+
+func ObserveHTTPRequest(method string, route string, status int, start time.Time) {
+	HTTPRequestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+}
+
+func result(hit bool) string {
+	if hit {
+		return ResultHit
+	}
+	return ResultMiss
+}
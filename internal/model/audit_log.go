@@ -0,0 +1,208 @@
+//go:generate mockgen -destination=mock/mock_audit_log_repository.go -package=mock github.com/krobus00/storage-service/internal/model AuditLogRepository
+//go:generate mockgen -destination=mock/mock_audit_log_usecase.go -package=mock github.com/krobus00/storage-service/internal/model AuditLogUsecase
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"github.com/nats-io/nats.go"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditStreamName     = "AUDIT"
+	AuditStreamSubjects = "AUDIT.*"
+	AuditLogSubject     = "AUDIT.objectAccess"
+
+	AuditActionUpload           = "upload"
+	AuditActionPresign          = "presign"
+	AuditActionDownload         = "download"
+	AuditActionUpdate           = "update"
+	AuditActionDelete           = "delete"
+	AuditActionVisibilityChange = "visibility_change"
+	AuditActionGrant            = "grant"
+	AuditActionRevoke           = "revoke"
+	AuditActionShare            = "share"
+	AuditActionShareRevoke      = "share_revoke"
+	AuditActionShareDownload    = "share_download"
+	AuditActionReference        = "reference"
+	AuditActionUnreference      = "unreference"
+
+	AuditOutcomeSuccess  = "success"
+	AuditOutcomeDenied   = "denied"
+	AuditOutcomeNotFound = "not_found"
+	AuditOutcomeError    = "error"
+
+	DefaultListAuditLogsLimit = 50
+	MaxListAuditLogsLimit     = 500
+)
+
+var (
+	ErrInvalidAuditLogFilter = errors.New("invalid audit log filter")
+)
+
+// AuditLog is an append-only record of an action taken on an object.
+type AuditLog struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenantID"`
+	ActorID   string    `json:"actorID"`
+	ObjectID  string    `json:"objectID"`
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	ClientIP  string    `json:"clientIP"`
+	UserAgent string    `json:"userAgent"`
+	TraceID   string    `json:"traceID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditOutcome maps an usecase error to the outcome recorded in the audit trail.
+func AuditOutcome(err error) string {
+	switch {
+	case err == nil:
+		return AuditOutcomeSuccess
+	case errors.Is(err, ErrUnauthorizeAccess), errors.Is(err, ErrShareLinkWrongPassword):
+		return AuditOutcomeDenied
+	case errors.Is(err, ErrObjectNotFound),
+		errors.Is(err, ErrShareLinkNotFound),
+		errors.Is(err, ErrShareLinkUnavailable):
+		return AuditOutcomeNotFound
+	default:
+		return AuditOutcomeError
+	}
+}
+
+type AuditLogFilter struct {
+	ObjectID string
+	ActorID  string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
+
+// Normalize clamps the page to sane bounds and rejects an inverted time range.
+func (m *AuditLogFilter) Normalize() error {
+	if m.From != nil && m.To != nil && m.From.After(*m.To) {
+		return ErrInvalidAuditLogFilter
+	}
+	if m.Limit <= 0 {
+		m.Limit = DefaultListAuditLogsLimit
+	}
+	if m.Limit > MaxListAuditLogsLimit {
+		m.Limit = MaxListAuditLogsLimit
+	}
+	if m.Offset < 0 {
+		m.Offset = 0
+	}
+	return nil
+}
+
+type HTTPListAuditLogsRequest struct {
+	ObjectID string `query:"objectID"`
+	ActorID  string `query:"actorID"`
+	// From and To are RFC3339 timestamps.
+	From   string `query:"from"`
+	To     string `query:"to"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (m *HTTPListAuditLogsRequest) ToFilter() (*AuditLogFilter, error) {
+	return NewAuditLogFilter(m.ObjectID, m.ActorID, m.From, m.To, m.Limit, m.Offset)
+}
+
+// NewAuditLogFilter builds a filter from RFC3339 bounds, an empty bound is open.
+func NewAuditLogFilter(objectID, actorID, from, to string, limit, offset int) (*AuditLogFilter, error) {
+	filter := &AuditLogFilter{
+		ObjectID: objectID,
+		ActorID:  actorID,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	if from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, ErrInvalidAuditLogFilter
+		}
+		filter.From = &t
+	}
+	if to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, ErrInvalidAuditLogFilter
+		}
+		filter.To = &t
+	}
+	return filter, nil
+}
+
+type HTTPAuditLogResponse struct {
+	ID        string `json:"id"`
+	ActorID   string `json:"actorID"`
+	ObjectID  string `json:"objectID"`
+	Action    string `json:"action"`
+	Outcome   string `json:"outcome"`
+	ClientIP  string `json:"clientIP"`
+	UserAgent string `json:"userAgent"`
+	TraceID   string `json:"traceID"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func (m *AuditLog) ToHTTPResponse() *HTTPAuditLogResponse {
+	return &HTTPAuditLogResponse{
+		ID:        m.ID,
+		ActorID:   m.ActorID,
+		ObjectID:  m.ObjectID,
+		Action:    m.Action,
+		Outcome:   m.Outcome,
+		ClientIP:  m.ClientIP,
+		UserAgent: m.UserAgent,
+		TraceID:   m.TraceID,
+		CreatedAt: m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func (m *AuditLog) ToGRPCResponse() *pb.AuditLog {
+	return &pb.AuditLog{
+		Id:        m.ID,
+		ActorId:   m.ActorID,
+		ObjectId:  m.ObjectID,
+		Action:    m.Action,
+		Outcome:   m.Outcome,
+		ClientIp:  m.ClientIP,
+		UserAgent: m.UserAgent,
+		TraceId:   m.TraceID,
+		CreatedAt: m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	FindAll(ctx context.Context, filter *AuditLogFilter) ([]*AuditLog, error)
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
+
+type AuditLogUsecase interface {
+	// Record never fails the caller, a failed write is only logged.
+	Record(ctx context.Context, action string, objectID string, err error)
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter) ([]*AuditLog, error)
+
+	// DI
+	InjectAuditLogRepo(repo AuditLogRepository) error
+	InjectAuthClient(client authPB.AuthServiceClient) error
+	InjectJetstreamClient(client nats.JetStreamContext) error
+
+	// Jetstream
+	CreateStream() error
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrAuthServiceUnavailable = errors.New("auth service unavailable")
+)
+
+// NewAuthDecisionCacheKey is keyed by the permission set regardless of its order.
+func NewAuthDecisionCacheKey(userID string, permissions []string) string {
+	sorted := append([]string(nil), permissions...)
+	sort.Strings(sorted)
+	return fmt.Sprintf("auth:has-access:userID:%s:permissions:%s", userID, strings.Join(sorted, ","))
+}
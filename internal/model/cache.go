@@ -0,0 +1,35 @@
+//go:generate mockgen -destination=mock/mock_cache.go -package=mock github.com/krobus00/storage-service/internal/model Cache
+
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	CacheTierNoop  = "noop"
+	CacheTierLocal = "local"
+	CacheTierRedis = "redis"
+
+	CacheInvalidationChannel = "cache:invalidation"
+)
+
+// Cache is a key value cache with tag based invalidation,
+// a miss is reported as nil data without error.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+	HGet(ctx context.Context, key string, field string) ([]byte, error)
+	HSet(ctx context.Context, key string, field string, value []byte, ttl time.Duration, tags ...string) error
+	Delete(ctx context.Context, keys ...string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
+	// Close releases what the cache holds besides the redis client.
+	Close() error
+}
+
+type CacheInvalidationPayload struct {
+	// Origin is the instance that published the invalidation, it keeps its own copy.
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxFileNameLength is in bytes, the common file system limit.
+	MaxFileNameLength = 255
+	// DefaultFileName stands in for an empty or fully stripped file name.
+	DefaultFileName = "file"
+)
+
+// SanitizeFileName keeps the last path segment of a client supplied file name,
+// NFC normalized, without control or format characters and leading dots,
+// truncated to MaxFileNameLength while keeping its extension.
+func SanitizeFileName(fileName string) string {
+	fileName = norm.NFC.String(strings.ToValidUTF8(fileName, ""))
+
+	// clients send both separators, never keep a directory part
+	if i := strings.LastIndexAny(fileName, `/\`); i >= 0 {
+		fileName = fileName[i+1:]
+	}
+
+	fileName = strings.Map(func(r rune) rune {
+		// format characters include the bidi overrides used to fake extensions
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, fileName)
+
+	// leading dots make hidden files and "..", trailing ones are dropped by windows
+	fileName = strings.TrimLeft(strings.TrimSpace(fileName), ".")
+	fileName = strings.TrimRight(fileName, ". ")
+
+	return truncateFileName(fileName, MaxFileNameLength)
+}
+
+// WithExtension appends the first of the sniffed extensions unless the name
+// already ends with one of them, an empty name becomes DefaultFileName.
+func WithExtension(fileName string, exts []string) string {
+	if fileName == "" {
+		fileName = DefaultFileName
+	}
+	if len(exts) == 0 {
+		return fileName
+	}
+
+	current := strings.ToLower(filepath.Ext(fileName))
+	for _, ext := range exts {
+		if current == strings.ToLower(ext) {
+			return fileName
+		}
+	}
+
+	base := truncateFileName(fileName, MaxFileNameLength-len(exts[0]))
+	return base + exts[0]
+}
+
+// truncateFileName cuts the base name on a rune boundary so the extension survives.
+func truncateFileName(fileName string, max int) string {
+	if len(fileName) <= max {
+		return fileName
+	}
+
+	ext := filepath.Ext(fileName)
+	if len(ext) >= max {
+		ext = ""
+	}
+	base := strings.TrimSuffix(fileName, ext)
+
+	limit := max - len(ext)
+	for limit > 0 && !utf8.RuneStart(base[limit]) {
+		limit--
+	}
+	return base[:limit] + ext
+}
@@ -0,0 +1,17 @@
+package model
+
+import "context"
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDraining = "draining"
+	HealthStatusFailed   = "failed"
+)
+
+// HealthCheck reports whether a dependency is usable, it must honour ctx deadline.
+type HealthCheck func(ctx context.Context) error
+
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
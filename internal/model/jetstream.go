@@ -5,5 +5,7 @@ type PublisherUsecase interface {
 }
 
 type JSDeleteObjectPayload struct {
-	ObjectID string `json:"objectID"`
+	TenantID  string `json:"tenantID"`
+	ObjectID  string `json:"objectID"`
+	DeletedBy string `json:"deletedBy"`
 }
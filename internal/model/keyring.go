@@ -0,0 +1,20 @@
+//go:generate mockgen -destination=mock/mock_keyring.go -package=mock github.com/krobus00/storage-service/internal/model Keyring
+
+package model
+
+import "errors"
+
+var (
+	ErrEncryptionKeyNotFound = errors.New("encryption key not found")
+	ErrContentTampered       = errors.New("encrypted content failed authentication")
+	// ErrObjectEncrypted is returned for presigned urls, s3 would hand out the ciphertext.
+	ErrObjectEncrypted = errors.New("encrypted objects are only served through the proxy")
+)
+
+// Keyring holds the master keys that wrap the per object data keys.
+type Keyring interface {
+	// ActiveKeyID is the key new data keys are wrapped with, empty when none is configured.
+	ActiveKeyID() string
+	WrapKey(dataKey []byte) (keyID string, wrapped []byte, err error)
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: AuditLogRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockAuditLogRepository is a mock of AuditLogRepository interface.
+type MockAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepositoryMockRecorder
+}
+
+// MockAuditLogRepositoryMockRecorder is the mock recorder for MockAuditLogRepository.
+type MockAuditLogRepositoryMockRecorder struct {
+	mock *MockAuditLogRepository
+}
+
+// NewMockAuditLogRepository creates a new mock instance.
+func NewMockAuditLogRepository(ctrl *gomock.Controller) *MockAuditLogRepository {
+	mock := &MockAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepository) EXPECT() *MockAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuditLogRepository) Create(arg0 context.Context, arg1 *model.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogRepository)(nil).Create), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockAuditLogRepository) FindAll(arg0 context.Context, arg1 *model.AuditLogFilter) ([]*model.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuditLogRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuditLogRepository)(nil).FindAll), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockAuditLogRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockAuditLogRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockAuditLogRepository)(nil).InjectDB), arg0)
+}
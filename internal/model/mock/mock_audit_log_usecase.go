@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: AuditLogUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	auth "github.com/krobus00/auth-service/pb/auth"
+	model "github.com/krobus00/storage-service/internal/model"
+	nats "github.com/nats-io/nats.go"
+)
+
+// MockAuditLogUsecase is a mock of AuditLogUsecase interface.
+type MockAuditLogUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogUsecaseMockRecorder
+}
+
+// MockAuditLogUsecaseMockRecorder is the mock recorder for MockAuditLogUsecase.
+type MockAuditLogUsecaseMockRecorder struct {
+	mock *MockAuditLogUsecase
+}
+
+// NewMockAuditLogUsecase creates a new mock instance.
+func NewMockAuditLogUsecase(ctrl *gomock.Controller) *MockAuditLogUsecase {
+	mock := &MockAuditLogUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuditLogUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogUsecase) EXPECT() *MockAuditLogUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateStream mocks base method.
+func (m *MockAuditLogUsecase) CreateStream() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStream")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStream indicates an expected call of CreateStream.
+func (mr *MockAuditLogUsecaseMockRecorder) CreateStream() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStream", reflect.TypeOf((*MockAuditLogUsecase)(nil).CreateStream))
+}
+
+// InjectAuditLogRepo mocks base method.
+func (m *MockAuditLogUsecase) InjectAuditLogRepo(arg0 model.AuditLogRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectAuditLogRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectAuditLogRepo indicates an expected call of InjectAuditLogRepo.
+func (mr *MockAuditLogUsecaseMockRecorder) InjectAuditLogRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectAuditLogRepo", reflect.TypeOf((*MockAuditLogUsecase)(nil).InjectAuditLogRepo), arg0)
+}
+
+// InjectAuthClient mocks base method.
+func (m *MockAuditLogUsecase) InjectAuthClient(arg0 auth.AuthServiceClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectAuthClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectAuthClient indicates an expected call of InjectAuthClient.
+func (mr *MockAuditLogUsecaseMockRecorder) InjectAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectAuthClient", reflect.TypeOf((*MockAuditLogUsecase)(nil).InjectAuthClient), arg0)
+}
+
+// InjectJetstreamClient mocks base method.
+func (m *MockAuditLogUsecase) InjectJetstreamClient(arg0 nats.JetStreamContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectJetstreamClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectJetstreamClient indicates an expected call of InjectJetstreamClient.
+func (mr *MockAuditLogUsecaseMockRecorder) InjectJetstreamClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectJetstreamClient", reflect.TypeOf((*MockAuditLogUsecase)(nil).InjectJetstreamClient), arg0)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockAuditLogUsecase) ListAuditLogs(arg0 context.Context, arg1 *model.AuditLogFilter) ([]*model.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockAuditLogUsecaseMockRecorder) ListAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockAuditLogUsecase)(nil).ListAuditLogs), arg0, arg1)
+}
+
+// Record mocks base method.
+func (m *MockAuditLogUsecase) Record(arg0 context.Context, arg1, arg2 string, arg3 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", arg0, arg1, arg2, arg3)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditLogUsecaseMockRecorder) Record(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditLogUsecase)(nil).Record), arg0, arg1, arg2, arg3)
+}
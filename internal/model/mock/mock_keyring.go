@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: Keyring)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKeyring is a mock of Keyring interface.
+type MockKeyring struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyringMockRecorder
+}
+
+// MockKeyringMockRecorder is the mock recorder for MockKeyring.
+type MockKeyringMockRecorder struct {
+	mock *MockKeyring
+}
+
+// NewMockKeyring creates a new mock instance.
+func NewMockKeyring(ctrl *gomock.Controller) *MockKeyring {
+	mock := &MockKeyring{ctrl: ctrl}
+	mock.recorder = &MockKeyringMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyring) EXPECT() *MockKeyringMockRecorder {
+	return m.recorder
+}
+
+// ActiveKeyID mocks base method.
+func (m *MockKeyring) ActiveKeyID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveKeyID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ActiveKeyID indicates an expected call of ActiveKeyID.
+func (mr *MockKeyringMockRecorder) ActiveKeyID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveKeyID", reflect.TypeOf((*MockKeyring)(nil).ActiveKeyID))
+}
+
+// UnwrapKey mocks base method.
+func (m *MockKeyring) UnwrapKey(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwrapKey", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwrapKey indicates an expected call of UnwrapKey.
+func (mr *MockKeyringMockRecorder) UnwrapKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwrapKey", reflect.TypeOf((*MockKeyring)(nil).UnwrapKey), arg0, arg1)
+}
+
+// WrapKey mocks base method.
+func (m *MockKeyring) WrapKey(arg0 []byte) (string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrapKey", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WrapKey indicates an expected call of WrapKey.
+func (mr *MockKeyringMockRecorder) WrapKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrapKey", reflect.TypeOf((*MockKeyring)(nil).WrapKey), arg0)
+}
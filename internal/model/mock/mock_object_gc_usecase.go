@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectGCUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	nats "github.com/nats-io/nats.go"
+)
+
+// MockObjectGCUsecase is a mock of ObjectGCUsecase interface.
+type MockObjectGCUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectGCUsecaseMockRecorder
+}
+
+// MockObjectGCUsecaseMockRecorder is the mock recorder for MockObjectGCUsecase.
+type MockObjectGCUsecaseMockRecorder struct {
+	mock *MockObjectGCUsecase
+}
+
+// NewMockObjectGCUsecase creates a new mock instance.
+func NewMockObjectGCUsecase(ctrl *gomock.Controller) *MockObjectGCUsecase {
+	mock := &MockObjectGCUsecase{ctrl: ctrl}
+	mock.recorder = &MockObjectGCUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectGCUsecase) EXPECT() *MockObjectGCUsecaseMockRecorder {
+	return m.recorder
+}
+
+// InjectJetstreamClient mocks base method.
+func (m *MockObjectGCUsecase) InjectJetstreamClient(arg0 nats.JetStreamContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectJetstreamClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectJetstreamClient indicates an expected call of InjectJetstreamClient.
+func (mr *MockObjectGCUsecaseMockRecorder) InjectJetstreamClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectJetstreamClient", reflect.TypeOf((*MockObjectGCUsecase)(nil).InjectJetstreamClient), arg0)
+}
+
+// InjectObjectRepo mocks base method.
+func (m *MockObjectGCUsecase) InjectObjectRepo(arg0 model.ObjectRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectRepo indicates an expected call of InjectObjectRepo.
+func (mr *MockObjectGCUsecaseMockRecorder) InjectObjectRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectRepo", reflect.TypeOf((*MockObjectGCUsecase)(nil).InjectObjectRepo), arg0)
+}
+
+// InjectPendingDeletionRepo mocks base method.
+func (m *MockObjectGCUsecase) InjectPendingDeletionRepo(arg0 model.PendingDeletionRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectPendingDeletionRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectPendingDeletionRepo indicates an expected call of InjectPendingDeletionRepo.
+func (mr *MockObjectGCUsecaseMockRecorder) InjectPendingDeletionRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectPendingDeletionRepo", reflect.TypeOf((*MockObjectGCUsecase)(nil).InjectPendingDeletionRepo), arg0)
+}
+
+// PurgeTemporaryObjects mocks base method.
+func (m *MockObjectGCUsecase) PurgeTemporaryObjects(arg0 context.Context, arg1 bool) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTemporaryObjects", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTemporaryObjects indicates an expected call of PurgeTemporaryObjects.
+func (mr *MockObjectGCUsecaseMockRecorder) PurgeTemporaryObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTemporaryObjects", reflect.TypeOf((*MockObjectGCUsecase)(nil).PurgeTemporaryObjects), arg0, arg1)
+}
+
+// RetryPendingDeletions mocks base method.
+func (m *MockObjectGCUsecase) RetryPendingDeletions(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPendingDeletions", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryPendingDeletions indicates an expected call of RetryPendingDeletions.
+func (mr *MockObjectGCUsecaseMockRecorder) RetryPendingDeletions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPendingDeletions", reflect.TypeOf((*MockObjectGCUsecase)(nil).RetryPendingDeletions), arg0)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectGrantRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockObjectGrantRepository is a mock of ObjectGrantRepository interface.
+type MockObjectGrantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectGrantRepositoryMockRecorder
+}
+
+// MockObjectGrantRepositoryMockRecorder is the mock recorder for MockObjectGrantRepository.
+type MockObjectGrantRepositoryMockRecorder struct {
+	mock *MockObjectGrantRepository
+}
+
+// NewMockObjectGrantRepository creates a new mock instance.
+func NewMockObjectGrantRepository(ctrl *gomock.Controller) *MockObjectGrantRepository {
+	mock := &MockObjectGrantRepository{ctrl: ctrl}
+	mock.recorder = &MockObjectGrantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectGrantRepository) EXPECT() *MockObjectGrantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockObjectGrantRepository) Create(arg0 context.Context, arg1 *model.ObjectGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockObjectGrantRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockObjectGrantRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteByID mocks base method.
+func (m *MockObjectGrantRepository) DeleteByID(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockObjectGrantRepositoryMockRecorder) DeleteByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockObjectGrantRepository)(nil).DeleteByID), arg0, arg1, arg2)
+}
+
+// FindByObjectID mocks base method.
+func (m *MockObjectGrantRepository) FindByObjectID(arg0 context.Context, arg1 string) ([]*model.ObjectGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByObjectID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ObjectGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByObjectID indicates an expected call of FindByObjectID.
+func (mr *MockObjectGrantRepositoryMockRecorder) FindByObjectID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByObjectID", reflect.TypeOf((*MockObjectGrantRepository)(nil).FindByObjectID), arg0, arg1)
+}
+
+// InjectCache mocks base method.
+func (m *MockObjectGrantRepository) InjectCache(arg0 model.Cache) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectCache", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectCache indicates an expected call of InjectCache.
+func (mr *MockObjectGrantRepositoryMockRecorder) InjectCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectCache", reflect.TypeOf((*MockObjectGrantRepository)(nil).InjectCache), arg0)
+}
+
+// InjectDB mocks base method.
+func (m *MockObjectGrantRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockObjectGrantRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectGrantRepository)(nil).InjectDB), arg0)
+}
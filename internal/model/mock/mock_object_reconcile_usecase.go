@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectReconcileUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+)
+
+// MockObjectReconcileUsecase is a mock of ObjectReconcileUsecase interface.
+type MockObjectReconcileUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectReconcileUsecaseMockRecorder
+}
+
+// MockObjectReconcileUsecaseMockRecorder is the mock recorder for MockObjectReconcileUsecase.
+type MockObjectReconcileUsecaseMockRecorder struct {
+	mock *MockObjectReconcileUsecase
+}
+
+// NewMockObjectReconcileUsecase creates a new mock instance.
+func NewMockObjectReconcileUsecase(ctrl *gomock.Controller) *MockObjectReconcileUsecase {
+	mock := &MockObjectReconcileUsecase{ctrl: ctrl}
+	mock.recorder = &MockObjectReconcileUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectReconcileUsecase) EXPECT() *MockObjectReconcileUsecaseMockRecorder {
+	return m.recorder
+}
+
+// InjectObjectRepo mocks base method.
+func (m *MockObjectReconcileUsecase) InjectObjectRepo(arg0 model.ObjectRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectRepo indicates an expected call of InjectObjectRepo.
+func (mr *MockObjectReconcileUsecaseMockRecorder) InjectObjectRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectRepo", reflect.TypeOf((*MockObjectReconcileUsecase)(nil).InjectObjectRepo), arg0)
+}
+
+// InjectObjectTypeRepo mocks base method.
+func (m *MockObjectReconcileUsecase) InjectObjectTypeRepo(arg0 model.ObjectTypeRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectTypeRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectTypeRepo indicates an expected call of InjectObjectTypeRepo.
+func (mr *MockObjectReconcileUsecaseMockRecorder) InjectObjectTypeRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectTypeRepo", reflect.TypeOf((*MockObjectReconcileUsecase)(nil).InjectObjectTypeRepo), arg0)
+}
+
+// Reconcile mocks base method.
+func (m *MockObjectReconcileUsecase) Reconcile(arg0 context.Context, arg1 bool) (*model.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(*model.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockObjectReconcileUsecaseMockRecorder) Reconcile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockObjectReconcileUsecase)(nil).Reconcile), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectReferenceRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockObjectReferenceRepository is a mock of ObjectReferenceRepository interface.
+type MockObjectReferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectReferenceRepositoryMockRecorder
+}
+
+// MockObjectReferenceRepositoryMockRecorder is the mock recorder for MockObjectReferenceRepository.
+type MockObjectReferenceRepositoryMockRecorder struct {
+	mock *MockObjectReferenceRepository
+}
+
+// NewMockObjectReferenceRepository creates a new mock instance.
+func NewMockObjectReferenceRepository(ctrl *gomock.Controller) *MockObjectReferenceRepository {
+	mock := &MockObjectReferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockObjectReferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectReferenceRepository) EXPECT() *MockObjectReferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockObjectReferenceRepository) Create(arg0 context.Context, arg1 *model.ObjectReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockObjectReferenceRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockObjectReferenceRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockObjectReferenceRepository) Delete(arg0 context.Context, arg1 *model.ObjectReferencePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockObjectReferenceRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockObjectReferenceRepository)(nil).Delete), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockObjectReferenceRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockObjectReferenceRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectReferenceRepository)(nil).InjectDB), arg0)
+}
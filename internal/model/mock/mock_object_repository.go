@@ -7,8 +7,8 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
-	redis "github.com/go-redis/redis/v8"
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/krobus00/storage-service/internal/model"
 	gorm "gorm.io/gorm"
@@ -65,6 +65,65 @@ func (mr *MockObjectRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockObjectRepository)(nil).DeleteByID), arg0, arg1)
 }
 
+// DeleteContent mocks base method.
+func (m *MockObjectRepository) DeleteContent(arg0 context.Context, arg1 *model.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContent indicates an expected call of DeleteContent.
+func (mr *MockObjectRepositoryMockRecorder) DeleteContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContent", reflect.TypeOf((*MockObjectRepository)(nil).DeleteContent), arg0, arg1)
+}
+
+// DeleteUnreferencedTemporary mocks base method.
+func (m *MockObjectRepository) DeleteUnreferencedTemporary(arg0 context.Context, arg1 string, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnreferencedTemporary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnreferencedTemporary indicates an expected call of DeleteUnreferencedTemporary.
+func (mr *MockObjectRepositoryMockRecorder) DeleteUnreferencedTemporary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnreferencedTemporary", reflect.TypeOf((*MockObjectRepository)(nil).DeleteUnreferencedTemporary), arg0, arg1, arg2)
+}
+
+// FindAll mocks base method.
+func (m *MockObjectRepository) FindAll(arg0 context.Context, arg1 *model.ObjectFilter) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockObjectRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockObjectRepository)(nil).FindAll), arg0, arg1)
+}
+
+// FindAllAfterID mocks base method.
+func (m *MockObjectRepository) FindAllAfterID(arg0 context.Context, arg1 string, arg2 int) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllAfterID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllAfterID indicates an expected call of FindAllAfterID.
+func (mr *MockObjectRepositoryMockRecorder) FindAllAfterID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllAfterID", reflect.TypeOf((*MockObjectRepository)(nil).FindAllAfterID), arg0, arg1, arg2)
+}
+
 // FindByID mocks base method.
 func (m *MockObjectRepository) FindByID(arg0 context.Context, arg1 string) (*model.Object, error) {
 	m.ctrl.T.Helper()
@@ -80,19 +139,93 @@ func (mr *MockObjectRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockObjectRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindByIdempotencyKey mocks base method.
+func (m *MockObjectRepository) FindByIdempotencyKey(arg0 context.Context, arg1, arg2 string) (*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdempotencyKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdempotencyKey indicates an expected call of FindByIdempotencyKey.
+func (mr *MockObjectRepositoryMockRecorder) FindByIdempotencyKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdempotencyKey", reflect.TypeOf((*MockObjectRepository)(nil).FindByIdempotencyKey), arg0, arg1, arg2)
+}
+
+// FindByTypeID mocks base method.
+func (m *MockObjectRepository) FindByTypeID(arg0 context.Context, arg1, arg2 string, arg3 int) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTypeID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTypeID indicates an expected call of FindByTypeID.
+func (mr *MockObjectRepositoryMockRecorder) FindByTypeID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTypeID", reflect.TypeOf((*MockObjectRepository)(nil).FindByTypeID), arg0, arg1, arg2, arg3)
+}
+
+// FindUnreferencedTemporary mocks base method.
+func (m *MockObjectRepository) FindUnreferencedTemporary(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnreferencedTemporary", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnreferencedTemporary indicates an expected call of FindUnreferencedTemporary.
+func (mr *MockObjectRepositoryMockRecorder) FindUnreferencedTemporary(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnreferencedTemporary", reflect.TypeOf((*MockObjectRepository)(nil).FindUnreferencedTemporary), arg0, arg1, arg2, arg3)
+}
+
 // GeneratePresignedURL mocks base method.
-func (m *MockObjectRepository) GeneratePresignedURL(arg0 context.Context, arg1 *model.Object) (*model.GetPresignedURLResponse, error) {
+func (m *MockObjectRepository) GeneratePresignedURL(arg0 context.Context, arg1 *model.Object, arg2 time.Duration) (*model.GetPresignedURLResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GeneratePresignedURL", arg0, arg1)
+	ret := m.ctrl.Call(m, "GeneratePresignedURL", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.GetPresignedURLResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GeneratePresignedURL indicates an expected call of GeneratePresignedURL.
-func (mr *MockObjectRepositoryMockRecorder) GeneratePresignedURL(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockObjectRepositoryMockRecorder) GeneratePresignedURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedURL", reflect.TypeOf((*MockObjectRepository)(nil).GeneratePresignedURL), arg0, arg1, arg2)
+}
+
+// GetContent mocks base method.
+func (m *MockObjectRepository) GetContent(arg0 context.Context, arg1 *model.Object, arg2 *model.GetObjectContentPayload) (*model.ObjectContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ObjectContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockObjectRepositoryMockRecorder) GetContent(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedURL", reflect.TypeOf((*MockObjectRepository)(nil).GeneratePresignedURL), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockObjectRepository)(nil).GetContent), arg0, arg1, arg2)
+}
+
+// InjectCache mocks base method.
+func (m *MockObjectRepository) InjectCache(arg0 model.Cache) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectCache", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectCache indicates an expected call of InjectCache.
+func (mr *MockObjectRepositoryMockRecorder) InjectCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectCache", reflect.TypeOf((*MockObjectRepository)(nil).InjectCache), arg0)
 }
 
 // InjectDB mocks base method.
@@ -109,18 +242,18 @@ func (mr *MockObjectRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectRepository)(nil).InjectDB), arg0)
 }
 
-// InjectRedisClient mocks base method.
-func (m *MockObjectRepository) InjectRedisClient(arg0 *redis.Client) error {
+// InjectKeyring mocks base method.
+func (m *MockObjectRepository) InjectKeyring(arg0 model.Keyring) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InjectRedisClient", arg0)
+	ret := m.ctrl.Call(m, "InjectKeyring", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InjectRedisClient indicates an expected call of InjectRedisClient.
-func (mr *MockObjectRepositoryMockRecorder) InjectRedisClient(arg0 interface{}) *gomock.Call {
+// InjectKeyring indicates an expected call of InjectKeyring.
+func (mr *MockObjectRepositoryMockRecorder) InjectKeyring(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectRedisClient", reflect.TypeOf((*MockObjectRepository)(nil).InjectRedisClient), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectKeyring", reflect.TypeOf((*MockObjectRepository)(nil).InjectKeyring), arg0)
 }
 
 // InjectS3Client mocks base method.
@@ -136,3 +269,62 @@ func (mr *MockObjectRepositoryMockRecorder) InjectS3Client(arg0 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectS3Client", reflect.TypeOf((*MockObjectRepository)(nil).InjectS3Client), arg0)
 }
+
+// ListContent mocks base method.
+func (m *MockObjectRepository) ListContent(arg0 context.Context, arg1, arg2 string) (*model.StoredContentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.StoredContentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContent indicates an expected call of ListContent.
+func (mr *MockObjectRepositoryMockRecorder) ListContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContent", reflect.TypeOf((*MockObjectRepository)(nil).ListContent), arg0, arg1, arg2)
+}
+
+// SignPresignedURL mocks base method.
+func (m *MockObjectRepository) SignPresignedURL(arg0 context.Context, arg1 *model.Object, arg2 time.Duration) (*model.GetPresignedURLResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignPresignedURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GetPresignedURLResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignPresignedURL indicates an expected call of SignPresignedURL.
+func (mr *MockObjectRepositoryMockRecorder) SignPresignedURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignPresignedURL", reflect.TypeOf((*MockObjectRepository)(nil).SignPresignedURL), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockObjectRepository) Update(arg0 context.Context, arg1 *model.Object, arg2 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockObjectRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockObjectRepository)(nil).Update), arg0, arg1, arg2)
+}
+
+// UpdateWrappedDataKey mocks base method.
+func (m *MockObjectRepository) UpdateWrappedDataKey(arg0 context.Context, arg1 *model.Object, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWrappedDataKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWrappedDataKey indicates an expected call of UpdateWrappedDataKey.
+func (mr *MockObjectRepositoryMockRecorder) UpdateWrappedDataKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWrappedDataKey", reflect.TypeOf((*MockObjectRepository)(nil).UpdateWrappedDataKey), arg0, arg1, arg2)
+}
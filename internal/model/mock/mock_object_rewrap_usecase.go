@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectRewrapUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+)
+
+// MockObjectRewrapUsecase is a mock of ObjectRewrapUsecase interface.
+type MockObjectRewrapUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectRewrapUsecaseMockRecorder
+}
+
+// MockObjectRewrapUsecaseMockRecorder is the mock recorder for MockObjectRewrapUsecase.
+type MockObjectRewrapUsecaseMockRecorder struct {
+	mock *MockObjectRewrapUsecase
+}
+
+// NewMockObjectRewrapUsecase creates a new mock instance.
+func NewMockObjectRewrapUsecase(ctrl *gomock.Controller) *MockObjectRewrapUsecase {
+	mock := &MockObjectRewrapUsecase{ctrl: ctrl}
+	mock.recorder = &MockObjectRewrapUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectRewrapUsecase) EXPECT() *MockObjectRewrapUsecaseMockRecorder {
+	return m.recorder
+}
+
+// InjectKeyring mocks base method.
+func (m *MockObjectRewrapUsecase) InjectKeyring(arg0 model.Keyring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectKeyring", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectKeyring indicates an expected call of InjectKeyring.
+func (mr *MockObjectRewrapUsecaseMockRecorder) InjectKeyring(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectKeyring", reflect.TypeOf((*MockObjectRewrapUsecase)(nil).InjectKeyring), arg0)
+}
+
+// InjectObjectRepo mocks base method.
+func (m *MockObjectRewrapUsecase) InjectObjectRepo(arg0 model.ObjectRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectRepo indicates an expected call of InjectObjectRepo.
+func (mr *MockObjectRewrapUsecaseMockRecorder) InjectObjectRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectRepo", reflect.TypeOf((*MockObjectRewrapUsecase)(nil).InjectObjectRepo), arg0)
+}
+
+// Rewrap mocks base method.
+func (m *MockObjectRewrapUsecase) Rewrap(arg0 context.Context, arg1 bool) (*model.ObjectRewrapReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrap", arg0, arg1)
+	ret0, _ := ret[0].(*model.ObjectRewrapReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rewrap indicates an expected call of Rewrap.
+func (mr *MockObjectRewrapUsecaseMockRecorder) Rewrap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrap", reflect.TypeOf((*MockObjectRewrapUsecase)(nil).Rewrap), arg0, arg1)
+}
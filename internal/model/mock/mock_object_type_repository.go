@@ -8,7 +8,6 @@ import (
 	context "context"
 	reflect "reflect"
 
-	redis "github.com/go-redis/redis/v8"
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/krobus00/storage-service/internal/model"
 	gorm "gorm.io/gorm"
@@ -65,6 +64,36 @@ func (mr *MockObjectTypeRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockObjectTypeRepository)(nil).DeleteByID), arg0, arg1)
 }
 
+// FindAll mocks base method.
+func (m *MockObjectTypeRepository) FindAll(arg0 context.Context) ([]*model.ObjectType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*model.ObjectType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockObjectTypeRepositoryMockRecorder) FindAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockObjectTypeRepository)(nil).FindAll), arg0)
+}
+
+// FindBuckets mocks base method.
+func (m *MockObjectTypeRepository) FindBuckets(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBuckets", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBuckets indicates an expected call of FindBuckets.
+func (mr *MockObjectTypeRepositoryMockRecorder) FindBuckets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBuckets", reflect.TypeOf((*MockObjectTypeRepository)(nil).FindBuckets), arg0)
+}
+
 // FindByID mocks base method.
 func (m *MockObjectTypeRepository) FindByID(arg0 context.Context, arg1 string) (*model.ObjectType, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +124,20 @@ func (mr *MockObjectTypeRepositoryMockRecorder) FindByName(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockObjectTypeRepository)(nil).FindByName), arg0, arg1)
 }
 
+// InjectCache mocks base method.
+func (m *MockObjectTypeRepository) InjectCache(arg0 model.Cache) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectCache", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectCache indicates an expected call of InjectCache.
+func (mr *MockObjectTypeRepositoryMockRecorder) InjectCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectCache", reflect.TypeOf((*MockObjectTypeRepository)(nil).InjectCache), arg0)
+}
+
 // InjectDB mocks base method.
 func (m *MockObjectTypeRepository) InjectDB(arg0 *gorm.DB) error {
 	m.ctrl.T.Helper()
@@ -109,16 +152,16 @@ func (mr *MockObjectTypeRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectTypeRepository)(nil).InjectDB), arg0)
 }
 
-// InjectRedisClient mocks base method.
-func (m *MockObjectTypeRepository) InjectRedisClient(arg0 *redis.Client) error {
+// Update mocks base method.
+func (m *MockObjectTypeRepository) Update(arg0 context.Context, arg1 *model.ObjectType) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InjectRedisClient", arg0)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InjectRedisClient indicates an expected call of InjectRedisClient.
-func (mr *MockObjectTypeRepositoryMockRecorder) InjectRedisClient(arg0 interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockObjectTypeRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectRedisClient", reflect.TypeOf((*MockObjectTypeRepository)(nil).InjectRedisClient), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockObjectTypeRepository)(nil).Update), arg0, arg1)
 }
@@ -37,6 +37,36 @@ func (m *MockObjectUsecase) EXPECT() *MockObjectUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddReference mocks base method.
+func (m *MockObjectUsecase) AddReference(arg0 context.Context, arg1 *model.ObjectReferencePayload) (*model.ObjectReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReference", arg0, arg1)
+	ret0, _ := ret[0].(*model.ObjectReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReference indicates an expected call of AddReference.
+func (mr *MockObjectUsecaseMockRecorder) AddReference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReference", reflect.TypeOf((*MockObjectUsecase)(nil).AddReference), arg0, arg1)
+}
+
+// CreateShareLink mocks base method.
+func (m *MockObjectUsecase) CreateShareLink(arg0 context.Context, arg1 *model.CreateShareLinkPayload) (*model.CreatedShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareLink", arg0, arg1)
+	ret0, _ := ret[0].(*model.CreatedShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShareLink indicates an expected call of CreateShareLink.
+func (mr *MockObjectUsecaseMockRecorder) CreateShareLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareLink", reflect.TypeOf((*MockObjectUsecase)(nil).CreateShareLink), arg0, arg1)
+}
+
 // CreateStream mocks base method.
 func (m *MockObjectUsecase) CreateStream() error {
 	m.ctrl.T.Helper()
@@ -80,6 +110,50 @@ func (mr *MockObjectUsecaseMockRecorder) GeneratePresignedURL(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePresignedURL", reflect.TypeOf((*MockObjectUsecase)(nil).GeneratePresignedURL), arg0, arg1)
 }
 
+// GetObjectContent mocks base method.
+func (m *MockObjectUsecase) GetObjectContent(arg0 context.Context, arg1 *model.GetObjectContentPayload) (*model.ObjectContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectContent", arg0, arg1)
+	ret0, _ := ret[0].(*model.ObjectContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectContent indicates an expected call of GetObjectContent.
+func (mr *MockObjectUsecaseMockRecorder) GetObjectContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectContent", reflect.TypeOf((*MockObjectUsecase)(nil).GetObjectContent), arg0, arg1)
+}
+
+// GrantAccess mocks base method.
+func (m *MockObjectUsecase) GrantAccess(arg0 context.Context, arg1 *model.GrantObjectAccessPayload) (*model.ObjectGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantAccess", arg0, arg1)
+	ret0, _ := ret[0].(*model.ObjectGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantAccess indicates an expected call of GrantAccess.
+func (mr *MockObjectUsecaseMockRecorder) GrantAccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAccess", reflect.TypeOf((*MockObjectUsecase)(nil).GrantAccess), arg0, arg1)
+}
+
+// InjectAuditLogUsecase mocks base method.
+func (m *MockObjectUsecase) InjectAuditLogUsecase(arg0 model.AuditLogUsecase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectAuditLogUsecase", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectAuditLogUsecase indicates an expected call of InjectAuditLogUsecase.
+func (mr *MockObjectUsecaseMockRecorder) InjectAuditLogUsecase(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectAuditLogUsecase", reflect.TypeOf((*MockObjectUsecase)(nil).InjectAuditLogUsecase), arg0)
+}
+
 // InjectAuthClient mocks base method.
 func (m *MockObjectUsecase) InjectAuthClient(arg0 auth.AuthServiceClient) error {
 	m.ctrl.T.Helper()
@@ -108,6 +182,34 @@ func (mr *MockObjectUsecaseMockRecorder) InjectJetstreamClient(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectJetstreamClient", reflect.TypeOf((*MockObjectUsecase)(nil).InjectJetstreamClient), arg0)
 }
 
+// InjectObjectGrantRepo mocks base method.
+func (m *MockObjectUsecase) InjectObjectGrantRepo(arg0 model.ObjectGrantRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectGrantRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectGrantRepo indicates an expected call of InjectObjectGrantRepo.
+func (mr *MockObjectUsecaseMockRecorder) InjectObjectGrantRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectGrantRepo", reflect.TypeOf((*MockObjectUsecase)(nil).InjectObjectGrantRepo), arg0)
+}
+
+// InjectObjectReferenceRepo mocks base method.
+func (m *MockObjectUsecase) InjectObjectReferenceRepo(arg0 model.ObjectReferenceRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectReferenceRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectReferenceRepo indicates an expected call of InjectObjectReferenceRepo.
+func (mr *MockObjectUsecaseMockRecorder) InjectObjectReferenceRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectReferenceRepo", reflect.TypeOf((*MockObjectUsecase)(nil).InjectObjectReferenceRepo), arg0)
+}
+
 // InjectObjectRepo mocks base method.
 func (m *MockObjectUsecase) InjectObjectRepo(arg0 model.ObjectRepository) error {
 	m.ctrl.T.Helper()
@@ -150,6 +252,180 @@ func (mr *MockObjectUsecaseMockRecorder) InjectObjectWhitelistTypeRepo(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectWhitelistTypeRepo", reflect.TypeOf((*MockObjectUsecase)(nil).InjectObjectWhitelistTypeRepo), arg0)
 }
 
+// InjectPendingDeletionRepo mocks base method.
+func (m *MockObjectUsecase) InjectPendingDeletionRepo(arg0 model.PendingDeletionRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectPendingDeletionRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectPendingDeletionRepo indicates an expected call of InjectPendingDeletionRepo.
+func (mr *MockObjectUsecaseMockRecorder) InjectPendingDeletionRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectPendingDeletionRepo", reflect.TypeOf((*MockObjectUsecase)(nil).InjectPendingDeletionRepo), arg0)
+}
+
+// InjectShareLinkRepo mocks base method.
+func (m *MockObjectUsecase) InjectShareLinkRepo(arg0 model.ShareLinkRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectShareLinkRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectShareLinkRepo indicates an expected call of InjectShareLinkRepo.
+func (mr *MockObjectUsecaseMockRecorder) InjectShareLinkRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectShareLinkRepo", reflect.TypeOf((*MockObjectUsecase)(nil).InjectShareLinkRepo), arg0)
+}
+
+// InjectStorageUsageUsecase mocks base method.
+func (m *MockObjectUsecase) InjectStorageUsageUsecase(arg0 model.StorageUsageUsecase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectStorageUsageUsecase", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectStorageUsageUsecase indicates an expected call of InjectStorageUsageUsecase.
+func (mr *MockObjectUsecaseMockRecorder) InjectStorageUsageUsecase(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectStorageUsageUsecase", reflect.TypeOf((*MockObjectUsecase)(nil).InjectStorageUsageUsecase), arg0)
+}
+
+// ListGrants mocks base method.
+func (m *MockObjectUsecase) ListGrants(arg0 context.Context, arg1 string) ([]*model.ObjectGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrants", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ObjectGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrants indicates an expected call of ListGrants.
+func (mr *MockObjectUsecaseMockRecorder) ListGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrants", reflect.TypeOf((*MockObjectUsecase)(nil).ListGrants), arg0, arg1)
+}
+
+// ListObjects mocks base method.
+func (m *MockObjectUsecase) ListObjects(arg0 context.Context, arg1 *model.ListObjectsPayload) ([]*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockObjectUsecaseMockRecorder) ListObjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockObjectUsecase)(nil).ListObjects), arg0, arg1)
+}
+
+// ListShareLinks mocks base method.
+func (m *MockObjectUsecase) ListShareLinks(arg0 context.Context, arg1 string) ([]*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShareLinks", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShareLinks indicates an expected call of ListShareLinks.
+func (mr *MockObjectUsecaseMockRecorder) ListShareLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShareLinks", reflect.TypeOf((*MockObjectUsecase)(nil).ListShareLinks), arg0, arg1)
+}
+
+// PurgeObject mocks base method.
+func (m *MockObjectUsecase) PurgeObject(arg0 context.Context, arg1 string) (*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeObject", arg0, arg1)
+	ret0, _ := ret[0].(*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeObject indicates an expected call of PurgeObject.
+func (mr *MockObjectUsecaseMockRecorder) PurgeObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeObject", reflect.TypeOf((*MockObjectUsecase)(nil).PurgeObject), arg0, arg1)
+}
+
+// RemoveReference mocks base method.
+func (m *MockObjectUsecase) RemoveReference(arg0 context.Context, arg1 *model.ObjectReferencePayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReference indicates an expected call of RemoveReference.
+func (mr *MockObjectUsecaseMockRecorder) RemoveReference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReference", reflect.TypeOf((*MockObjectUsecase)(nil).RemoveReference), arg0, arg1)
+}
+
+// ResolveShareLink mocks base method.
+func (m *MockObjectUsecase) ResolveShareLink(arg0 context.Context, arg1 *model.ResolveShareLinkPayload) (*model.ObjectContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveShareLink", arg0, arg1)
+	ret0, _ := ret[0].(*model.ObjectContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveShareLink indicates an expected call of ResolveShareLink.
+func (mr *MockObjectUsecaseMockRecorder) ResolveShareLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveShareLink", reflect.TypeOf((*MockObjectUsecase)(nil).ResolveShareLink), arg0, arg1)
+}
+
+// RevokeAccess mocks base method.
+func (m *MockObjectUsecase) RevokeAccess(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccess indicates an expected call of RevokeAccess.
+func (mr *MockObjectUsecaseMockRecorder) RevokeAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccess", reflect.TypeOf((*MockObjectUsecase)(nil).RevokeAccess), arg0, arg1, arg2)
+}
+
+// RevokeShareLink mocks base method.
+func (m *MockObjectUsecase) RevokeShareLink(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareLink indicates an expected call of RevokeShareLink.
+func (mr *MockObjectUsecaseMockRecorder) RevokeShareLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareLink", reflect.TypeOf((*MockObjectUsecase)(nil).RevokeShareLink), arg0, arg1, arg2)
+}
+
+// UpdateObject mocks base method.
+func (m *MockObjectUsecase) UpdateObject(arg0 context.Context, arg1 *model.UpdateObjectPayload) (*model.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateObject", arg0, arg1)
+	ret0, _ := ret[0].(*model.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateObject indicates an expected call of UpdateObject.
+func (mr *MockObjectUsecaseMockRecorder) UpdateObject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateObject", reflect.TypeOf((*MockObjectUsecase)(nil).UpdateObject), arg0, arg1)
+}
+
 // Upload mocks base method.
 func (m *MockObjectUsecase) Upload(arg0 context.Context, arg1 *model.ObjectPayload) (*model.Object, error) {
 	m.ctrl.T.Helper()
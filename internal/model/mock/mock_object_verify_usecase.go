@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ObjectVerifyUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+)
+
+// MockObjectVerifyUsecase is a mock of ObjectVerifyUsecase interface.
+type MockObjectVerifyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockObjectVerifyUsecaseMockRecorder
+}
+
+// MockObjectVerifyUsecaseMockRecorder is the mock recorder for MockObjectVerifyUsecase.
+type MockObjectVerifyUsecaseMockRecorder struct {
+	mock *MockObjectVerifyUsecase
+}
+
+// NewMockObjectVerifyUsecase creates a new mock instance.
+func NewMockObjectVerifyUsecase(ctrl *gomock.Controller) *MockObjectVerifyUsecase {
+	mock := &MockObjectVerifyUsecase{ctrl: ctrl}
+	mock.recorder = &MockObjectVerifyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObjectVerifyUsecase) EXPECT() *MockObjectVerifyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// InjectObjectRepo mocks base method.
+func (m *MockObjectVerifyUsecase) InjectObjectRepo(arg0 model.ObjectRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectRepo indicates an expected call of InjectObjectRepo.
+func (mr *MockObjectVerifyUsecaseMockRecorder) InjectObjectRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectRepo", reflect.TypeOf((*MockObjectVerifyUsecase)(nil).InjectObjectRepo), arg0)
+}
+
+// Verify mocks base method.
+func (m *MockObjectVerifyUsecase) Verify(arg0 context.Context) (*model.ObjectVerifyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(*model.ObjectVerifyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockObjectVerifyUsecaseMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockObjectVerifyUsecase)(nil).Verify), arg0)
+}
@@ -8,7 +8,6 @@ import (
 	context "context"
 	reflect "reflect"
 
-	redis "github.com/go-redis/redis/v8"
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/krobus00/storage-service/internal/model"
 	gorm "gorm.io/gorm"
@@ -80,30 +79,30 @@ func (mr *MockObjectWhitelistTypeRepositoryMockRecorder) FindByTypeIDAndExt(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTypeIDAndExt", reflect.TypeOf((*MockObjectWhitelistTypeRepository)(nil).FindByTypeIDAndExt), arg0, arg1, arg2)
 }
 
-// InjectDB mocks base method.
-func (m *MockObjectWhitelistTypeRepository) InjectDB(arg0 *gorm.DB) error {
+// InjectCache mocks base method.
+func (m *MockObjectWhitelistTypeRepository) InjectCache(arg0 model.Cache) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret := m.ctrl.Call(m, "InjectCache", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InjectDB indicates an expected call of InjectDB.
-func (mr *MockObjectWhitelistTypeRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+// InjectCache indicates an expected call of InjectCache.
+func (mr *MockObjectWhitelistTypeRepositoryMockRecorder) InjectCache(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectWhitelistTypeRepository)(nil).InjectDB), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectCache", reflect.TypeOf((*MockObjectWhitelistTypeRepository)(nil).InjectCache), arg0)
 }
 
-// InjectRedisClient mocks base method.
-func (m *MockObjectWhitelistTypeRepository) InjectRedisClient(arg0 *redis.Client) error {
+// InjectDB mocks base method.
+func (m *MockObjectWhitelistTypeRepository) InjectDB(arg0 *gorm.DB) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InjectRedisClient", arg0)
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InjectRedisClient indicates an expected call of InjectRedisClient.
-func (mr *MockObjectWhitelistTypeRepositoryMockRecorder) InjectRedisClient(arg0 interface{}) *gomock.Call {
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockObjectWhitelistTypeRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectRedisClient", reflect.TypeOf((*MockObjectWhitelistTypeRepository)(nil).InjectRedisClient), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockObjectWhitelistTypeRepository)(nil).InjectDB), arg0)
 }
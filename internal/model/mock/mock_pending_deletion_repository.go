@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: PendingDeletionRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockPendingDeletionRepository is a mock of PendingDeletionRepository interface.
+type MockPendingDeletionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPendingDeletionRepositoryMockRecorder
+}
+
+// MockPendingDeletionRepositoryMockRecorder is the mock recorder for MockPendingDeletionRepository.
+type MockPendingDeletionRepositoryMockRecorder struct {
+	mock *MockPendingDeletionRepository
+}
+
+// NewMockPendingDeletionRepository creates a new mock instance.
+func NewMockPendingDeletionRepository(ctrl *gomock.Controller) *MockPendingDeletionRepository {
+	mock := &MockPendingDeletionRepository{ctrl: ctrl}
+	mock.recorder = &MockPendingDeletionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingDeletionRepository) EXPECT() *MockPendingDeletionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPendingDeletionRepository) Create(arg0 context.Context, arg1 *model.PendingDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPendingDeletionRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPendingDeletionRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteByID mocks base method.
+func (m *MockPendingDeletionRepository) DeleteByID(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockPendingDeletionRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPendingDeletionRepository)(nil).DeleteByID), arg0, arg1)
+}
+
+// FindAll mocks base method.
+func (m *MockPendingDeletionRepository) FindAll(arg0 context.Context, arg1 int) ([]*model.PendingDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0, arg1)
+	ret0, _ := ret[0].([]*model.PendingDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPendingDeletionRepositoryMockRecorder) FindAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPendingDeletionRepository)(nil).FindAll), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockPendingDeletionRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockPendingDeletionRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockPendingDeletionRepository)(nil).InjectDB), arg0)
+}
+
+// MarkFailed mocks base method.
+func (m *MockPendingDeletionRepository) MarkFailed(arg0 context.Context, arg1 string, arg2 error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockPendingDeletionRepositoryMockRecorder) MarkFailed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockPendingDeletionRepository)(nil).MarkFailed), arg0, arg1, arg2)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: ShareLinkRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockShareLinkRepository is a mock of ShareLinkRepository interface.
+type MockShareLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkRepositoryMockRecorder
+}
+
+// MockShareLinkRepositoryMockRecorder is the mock recorder for MockShareLinkRepository.
+type MockShareLinkRepositoryMockRecorder struct {
+	mock *MockShareLinkRepository
+}
+
+// NewMockShareLinkRepository creates a new mock instance.
+func NewMockShareLinkRepository(ctrl *gomock.Controller) *MockShareLinkRepository {
+	mock := &MockShareLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockShareLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkRepository) EXPECT() *MockShareLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDownload mocks base method.
+func (m *MockShareLinkRepository) CountDownload(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDownload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CountDownload indicates an expected call of CountDownload.
+func (mr *MockShareLinkRepositoryMockRecorder) CountDownload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDownload", reflect.TypeOf((*MockShareLinkRepository)(nil).CountDownload), arg0, arg1)
+}
+
+// CountFailedAttempt mocks base method.
+func (m *MockShareLinkRepository) CountFailedAttempt(arg0 context.Context, arg1 string, arg2 int, arg3 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedAttempt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CountFailedAttempt indicates an expected call of CountFailedAttempt.
+func (mr *MockShareLinkRepositoryMockRecorder) CountFailedAttempt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedAttempt", reflect.TypeOf((*MockShareLinkRepository)(nil).CountFailedAttempt), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockShareLinkRepository) Create(arg0 context.Context, arg1 *model.ShareLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShareLinkRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShareLinkRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByObjectID mocks base method.
+func (m *MockShareLinkRepository) FindByObjectID(arg0 context.Context, arg1 string) ([]*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByObjectID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByObjectID indicates an expected call of FindByObjectID.
+func (mr *MockShareLinkRepositoryMockRecorder) FindByObjectID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByObjectID", reflect.TypeOf((*MockShareLinkRepository)(nil).FindByObjectID), arg0, arg1)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockShareLinkRepository) FindByTokenHash(arg0 context.Context, arg1 string) (*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockShareLinkRepositoryMockRecorder) FindByTokenHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockShareLinkRepository)(nil).FindByTokenHash), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockShareLinkRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockShareLinkRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockShareLinkRepository)(nil).InjectDB), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockShareLinkRepository) Revoke(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareLinkRepositoryMockRecorder) Revoke(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareLinkRepository)(nil).Revoke), arg0, arg1, arg2)
+}
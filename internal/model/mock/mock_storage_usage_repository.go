@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: StorageUsageRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/krobus00/storage-service/internal/model"
+	gorm "gorm.io/gorm"
+)
+
+// MockStorageUsageRepository is a mock of StorageUsageRepository interface.
+type MockStorageUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageUsageRepositoryMockRecorder
+}
+
+// MockStorageUsageRepositoryMockRecorder is the mock recorder for MockStorageUsageRepository.
+type MockStorageUsageRepositoryMockRecorder struct {
+	mock *MockStorageUsageRepository
+}
+
+// NewMockStorageUsageRepository creates a new mock instance.
+func NewMockStorageUsageRepository(ctrl *gomock.Controller) *MockStorageUsageRepository {
+	mock := &MockStorageUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockStorageUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageUsageRepository) EXPECT() *MockStorageUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByUserID mocks base method.
+func (m *MockStorageUsageRepository) FindByUserID(arg0 context.Context, arg1 string) ([]*model.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockStorageUsageRepositoryMockRecorder) FindByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockStorageUsageRepository)(nil).FindByUserID), arg0, arg1)
+}
+
+// InjectDB mocks base method.
+func (m *MockStorageUsageRepository) InjectDB(arg0 *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectDB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectDB indicates an expected call of InjectDB.
+func (mr *MockStorageUsageRepositoryMockRecorder) InjectDB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectDB", reflect.TypeOf((*MockStorageUsageRepository)(nil).InjectDB), arg0)
+}
+
+// Recompute mocks base method.
+func (m *MockStorageUsageRepository) Recompute(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recompute", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recompute indicates an expected call of Recompute.
+func (mr *MockStorageUsageRepositoryMockRecorder) Recompute(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recompute", reflect.TypeOf((*MockStorageUsageRepository)(nil).Recompute), arg0, arg1)
+}
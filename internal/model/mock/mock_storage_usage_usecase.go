@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/krobus00/storage-service/internal/model (interfaces: StorageUsageUsecase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	auth "github.com/krobus00/auth-service/pb/auth"
+	model "github.com/krobus00/storage-service/internal/model"
+)
+
+// MockStorageUsageUsecase is a mock of StorageUsageUsecase interface.
+type MockStorageUsageUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageUsageUsecaseMockRecorder
+}
+
+// MockStorageUsageUsecaseMockRecorder is the mock recorder for MockStorageUsageUsecase.
+type MockStorageUsageUsecaseMockRecorder struct {
+	mock *MockStorageUsageUsecase
+}
+
+// NewMockStorageUsageUsecase creates a new mock instance.
+func NewMockStorageUsageUsecase(ctrl *gomock.Controller) *MockStorageUsageUsecase {
+	mock := &MockStorageUsageUsecase{ctrl: ctrl}
+	mock.recorder = &MockStorageUsageUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageUsageUsecase) EXPECT() *MockStorageUsageUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckQuota mocks base method.
+func (m *MockStorageUsageUsecase) CheckQuota(arg0 context.Context, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckQuota", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckQuota indicates an expected call of CheckQuota.
+func (mr *MockStorageUsageUsecaseMockRecorder) CheckQuota(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckQuota", reflect.TypeOf((*MockStorageUsageUsecase)(nil).CheckQuota), arg0, arg1, arg2)
+}
+
+// GetUsage mocks base method.
+func (m *MockStorageUsageUsecase) GetUsage(arg0 context.Context, arg1 string) (*model.UserStorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserStorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockStorageUsageUsecaseMockRecorder) GetUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockStorageUsageUsecase)(nil).GetUsage), arg0, arg1)
+}
+
+// InjectAuthClient mocks base method.
+func (m *MockStorageUsageUsecase) InjectAuthClient(arg0 auth.AuthServiceClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectAuthClient", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectAuthClient indicates an expected call of InjectAuthClient.
+func (mr *MockStorageUsageUsecaseMockRecorder) InjectAuthClient(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectAuthClient", reflect.TypeOf((*MockStorageUsageUsecase)(nil).InjectAuthClient), arg0)
+}
+
+// InjectObjectTypeRepo mocks base method.
+func (m *MockStorageUsageUsecase) InjectObjectTypeRepo(arg0 model.ObjectTypeRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectObjectTypeRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectObjectTypeRepo indicates an expected call of InjectObjectTypeRepo.
+func (mr *MockStorageUsageUsecaseMockRecorder) InjectObjectTypeRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectObjectTypeRepo", reflect.TypeOf((*MockStorageUsageUsecase)(nil).InjectObjectTypeRepo), arg0)
+}
+
+// InjectStorageUsageRepo mocks base method.
+func (m *MockStorageUsageUsecase) InjectStorageUsageRepo(arg0 model.StorageUsageRepository) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InjectStorageUsageRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InjectStorageUsageRepo indicates an expected call of InjectStorageUsageRepo.
+func (mr *MockStorageUsageUsecaseMockRecorder) InjectStorageUsageRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InjectStorageUsageRepo", reflect.TypeOf((*MockStorageUsageUsecase)(nil).InjectStorageUsageRepo), arg0)
+}
@@ -8,10 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
-	"regexp"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	authPB "github.com/krobus00/auth-service/pb/auth"
 	pb "github.com/krobus00/storage-service/pb/storage"
 	"github.com/nats-io/nats.go"
@@ -21,12 +19,16 @@ import (
 const (
 	ObjectStreamName     = "OBJECTS"
 	ObjectStreamSubjects = "OBJECTS.*"
-
-	DefaultPath = "DEFAULT-PATH"
 )
 
 var (
-	ErrObjectNotFound = errors.New("object not found")
+	ErrObjectNotFound     = errors.New("object not found")
+	ErrInvalidMinValidity = errors.New("min validity exceeds presigned url lifetime")
+
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key reused for a different upload")
+	// ErrIdempotencyKeyTaken is returned by Create when a concurrent upload with the key won.
+	ErrIdempotencyKeyTaken = errors.New("idempotency key already used")
 
 	ObjectDeleteStreamSubjects = []string{
 		"PRODUCTS.thumbnailDeleted",
@@ -34,38 +36,84 @@ var (
 )
 
 type Object struct {
-	ID         string
-	FileName   string
+	ID       string
+	TenantID string
+	FileName string
+	// OriginalFileName is the sanitized name as uploaded, empty for older objects.
+	OriginalFileName string
+	// Bucket is where the content was written, empty for objects stored before
+	// types could route them, those live in the tenant bucket.
+	Bucket     string
 	Key        string
 	UploadedBy string
 	IsPublic   bool
 	TypeID     string
 	Type       string `gorm:"-"`
-	CreatedAt  time.Time
+	Size       int64
+	// ChecksumSHA256 is the hex digest of the stored bytes, empty for older objects.
+	ChecksumSHA256 string
+	// EncryptionKeyID is the master key wrapping WrappedDataKey (base64), both are
+	// empty for plaintext objects.
+	EncryptionKeyID string
+	WrappedDataKey  string
+	// Version is bumped on every update for optimistic concurrency.
+	Version  int64
+	Metadata ObjectMetadata `gorm:"type:jsonb"`
+	// TemporaryUntil is set on temporary uploads, past it an unreferenced object is purged.
+	TemporaryUntil *time.Time
+	// IdempotencyKey is unique per uploader, a retried upload returns the object it created.
+	IdempotencyKey string
+	CreatedAt      time.Time
 }
 
 func (Object) TableName() string {
 	return "objects"
 }
 
-func NewObjectCacheKey(id string) string {
-	return fmt.Sprintf("objects:objectID:%s", id)
+func NewObjectCacheKey(tenantID string, id string) string {
+	return fmt.Sprintf("tenants:%s:objects:objectID:%s", tenantID, id)
 }
 
-func NewObjectPresignedURLCacheKey(id string) string {
-	return fmt.Sprintf("objects:objectID:%s:presignedURL", id)
+func NewObjectPresignedURLCacheKey(tenantID string, id string) string {
+	return fmt.Sprintf("tenants:%s:objects:objectID:%s:presignedURL", tenantID, id)
 }
 
-func GetObjectCacheKeys(id string) []string {
+func GetObjectCacheKeys(tenantID string, id string) []string {
 	return []string{
-		NewObjectCacheKey(id),
-		NewObjectPresignedURLCacheKey(id),
+		NewObjectCacheKey(tenantID, id),
+		NewObjectPresignedURLCacheKey(tenantID, id),
+	}
+}
+
+func NewObjectCacheTag(tenantID string, id string) string {
+	return fmt.Sprintf("tags:tenants:%s:objects:objectID:%s", tenantID, id)
+}
+
+// GetObjectCacheTags return tags for object cache entries, an object is also tagged
+// with its type since deleting a type cascades to its objects.
+func GetObjectCacheTags(tenantID string, id string, typeID string) []string {
+	tags := []string{NewObjectCacheTag(tenantID, id)}
+	if typeID != "" {
+		tags = append(tags, NewObjectTypeCacheTag(tenantID, typeID))
 	}
+	return tags
 }
 
 type ObjectPayload struct {
 	Src    []byte
 	Object *Object
+	// ObjectType routes the upload to its bucket and key layout.
+	ObjectType *ObjectType
+	// Temporary uploads are purged unless a reference claims them in time.
+	Temporary bool
+	// IdempotencyKey makes retries of the upload return the first object.
+	IdempotencyKey string
+	// ContentMD5 (base64) and ChecksumSHA256 (hex or base64) are digests sent by
+	// the caller, the upload is rejected when Src does not match them.
+	ContentMD5     string
+	ChecksumSHA256 string
+	// Quota is checked against the usage in the transaction writing the object.
+	Quota UploadQuota
 }
 
 func (m *ObjectPayload) SetObject(object *Object) *ObjectPayload {
@@ -73,8 +121,26 @@ func (m *ObjectPayload) SetObject(object *Object) *ObjectPayload {
 	return m
 }
 
+// ValidateIdempotencyKey accepts up to 255 printable ascii characters, an empty key is no key.
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > 255 {
+		return ErrInvalidIdempotencyKey
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return ErrInvalidIdempotencyKey
+		}
+	}
+	return nil
+}
+
+func (m *ObjectPayload) SetObjectType(objectType *ObjectType) *ObjectPayload {
+	m.ObjectType = objectType
+	return m
+}
+
 func NewObject() *Object {
-	return new(Object)
+	return &Object{Version: 1}
 }
 
 func (m *Object) SetID(id string) *Object {
@@ -92,19 +158,20 @@ func (m *Object) SetType(name string) *Object {
 	return m
 }
 
-func (m *Object) SetKey(key string) *Object {
-	ran := time.Now().UnixNano()
-	if key == DefaultPath {
-		key = m.UploadedBy
-	}
-	m.Key = fmt.Sprintf("%s/%d", key, ran)
+// SetFileName keeps the sanitized client file name, the stored FileName gets
+// its extension from the content once it is sniffed.
+func (m *Object) SetFileName(fileName string) *Object {
+	m.OriginalFileName = SanitizeFileName(fileName)
+	m.FileName = m.OriginalFileName
 	return m
 }
 
-func (m *Object) SetFileName(fileName string) *Object {
-	re := regexp.MustCompile(`\.`)
-	m.FileName = re.ReplaceAllString(fileName, "")
-	return m
+// DownloadFileName is the name clients should save the object as.
+func (m *Object) DownloadFileName() string {
+	if m.OriginalFileName != "" {
+		return m.OriginalFileName
+	}
+	return m.FileName
 }
 
 func (m *Object) SetUploadedBy(uploadedBy string) *Object {
@@ -117,24 +184,67 @@ func (m *Object) SetIsPublic(isPublic bool) *Object {
 	return m
 }
 
+func (m *Object) SetMetadata(metadata ObjectMetadata) *Object {
+	m.Metadata = metadata
+	return m
+}
+
+func (m *Object) SetSize(size int64) *Object {
+	m.Size = size
+	return m
+}
+
+// Clone copies the object without sharing its metadata or pointer fields.
+func (m *Object) Clone() *Object {
+	clone := *m
+	clone.Metadata = m.Metadata.Clone()
+	if m.TemporaryUntil != nil {
+		temporaryUntil := *m.TemporaryUntil
+		clone.TemporaryUntil = &temporaryUntil
+	}
+	return &clone
+}
+
+func (m *Object) IsEncrypted() bool {
+	return m.EncryptionKeyID != ""
+}
+
+func (m *Object) SetChecksumSHA256(checksum string) *Object {
+	m.ChecksumSHA256 = checksum
+	return m
+}
+
+func (m *Object) SetTemporaryUntil(temporaryUntil *time.Time) *Object {
+	m.TemporaryUntil = temporaryUntil
+	return m
+}
+
 type HTTPFileUploadRequest struct {
 	Src      *multipart.FileHeader `form:"file"`
 	Type     string                `form:"type"`
 	Filename string                `form:"fileName"`
 	IsPublic bool                  `form:"isPublic"`
+	// Metadata is a json object of string values.
+	Metadata  string `form:"metadata"`
+	Temporary bool   `form:"temporary"`
 }
 
 type GetPresignedURLPayload struct {
 	ObjectID string
+	// MinValidity is how long the returned url must at least stay valid.
+	MinValidity time.Duration
 }
 
 type HTTPGetPresignedURLRequest struct {
 	ObjectID string `query:"id"`
+	// MinValidity in seconds.
+	MinValidity int64 `query:"minValidity"`
 }
 
 func (m *HTTPGetPresignedURLRequest) ToPayload() *GetPresignedURLPayload {
 	return &GetPresignedURLPayload{
-		ObjectID: m.ObjectID,
+		ObjectID:    m.ObjectID,
+		MinValidity: time.Duration(m.MinValidity) * time.Second,
 	}
 }
 
@@ -146,9 +256,16 @@ type GetPresignedURLResponse struct {
 	ExpiredAt  time.Time
 	IsPublic   bool
 	UploadedBy string
+	Version    int64
+	Metadata   ObjectMetadata
 	CreatedAt  time.Time
 }
 
+// ValidFor reports whether the url is still valid for at least d.
+func (m *GetPresignedURLResponse) ValidFor(d time.Duration) bool {
+	return time.Until(m.ExpiredAt) >= d
+}
+
 func (m *GetPresignedURLResponse) ToHTTPResponse() *HTTPGetPresignedURLResponse {
 	expiredAt := m.ExpiredAt.UTC().Format(time.RFC3339Nano)
 	createdAt := m.CreatedAt.UTC().Format(time.RFC3339Nano)
@@ -160,6 +277,8 @@ func (m *GetPresignedURLResponse) ToHTTPResponse() *HTTPGetPresignedURLResponse
 		ExpiredAt:  expiredAt,
 		IsPublic:   m.IsPublic,
 		UploadedBy: m.UploadedBy,
+		Version:    m.Version,
+		Metadata:   m.Metadata,
 		CreatedAt:  createdAt,
 	}
 }
@@ -175,68 +294,200 @@ func (m *GetPresignedURLResponse) ToGRPCResponse() *pb.Object {
 		ExpiredAt:  expiredAt,
 		IsPublic:   m.IsPublic,
 		UploadedBy: m.UploadedBy,
+		Version:    m.Version,
+		Metadata:   m.Metadata,
 		CreatedAt:  createdAt,
 	}
 }
 
 type HTTPGetPresignedURLResponse struct {
-	ID         string `json:"id"`
-	Filename   string `json:"filename"`
-	URL        string `json:"url"`
-	Type       string `json:"type"`
-	ExpiredAt  string `json:"expiredAt"`
-	IsPublic   bool   `json:"isPublic"`
-	UploadedBy string `json:"uploadedby"`
-	CreatedAt  string `json:"createdAt"`
+	ID         string         `json:"id"`
+	Filename   string         `json:"filename"`
+	URL        string         `json:"url"`
+	Type       string         `json:"type"`
+	ExpiredAt  string         `json:"expiredAt"`
+	IsPublic   bool           `json:"isPublic"`
+	UploadedBy string         `json:"uploadedby"`
+	Version    int64          `json:"version"`
+	Metadata   ObjectMetadata `json:"metadata"`
+	CreatedAt  string         `json:"createdAt"`
 }
 
 type HTTPUploadObjectResponse struct {
-	ID         string    `json:"id"`
-	FileName   string    `json:"filename"`
-	Key        string    `json:"key"`
-	UploadedBy string    `json:"uploadedBy"`
-	IsPublic   bool      `json:"isPublic"`
-	TypeID     string    `json:"typeID"`
-	Type       string    `json:"type"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID               string         `json:"id"`
+	FileName         string         `json:"filename"`
+	OriginalFileName string         `json:"originalFileName"`
+	Key              string         `json:"key"`
+	UploadedBy       string         `json:"uploadedBy"`
+	IsPublic         bool           `json:"isPublic"`
+	TypeID           string         `json:"typeID"`
+	Size             int64          `json:"size"`
+	ChecksumSHA256   string         `json:"checksumSHA256"`
+	Encrypted        bool           `json:"encrypted"`
+	Type             string         `json:"type"`
+	Version          int64          `json:"version"`
+	Metadata         ObjectMetadata `json:"metadata"`
+	TemporaryUntil   *time.Time     `json:"temporaryUntil,omitempty"`
+	CreatedAt        time.Time      `json:"createdAt"`
 }
 
 func (m *Object) ToHTTPResponse() *HTTPUploadObjectResponse {
 	return &HTTPUploadObjectResponse{
-		ID:         m.ID,
-		FileName:   m.FileName,
-		Key:        m.Key,
-		UploadedBy: m.UploadedBy,
-		IsPublic:   m.IsPublic,
-		TypeID:     m.TypeID,
-		Type:       m.Type,
-		CreatedAt:  m.CreatedAt,
+		ID:               m.ID,
+		FileName:         m.FileName,
+		OriginalFileName: m.DownloadFileName(),
+		Key:              m.Key,
+		UploadedBy:       m.UploadedBy,
+		IsPublic:         m.IsPublic,
+		TypeID:           m.TypeID,
+		Size:             m.Size,
+		ChecksumSHA256:   m.ChecksumSHA256,
+		Encrypted:        m.IsEncrypted(),
+		Type:             m.Type,
+		Version:          m.Version,
+		Metadata:         m.Metadata,
+		TemporaryUntil:   m.TemporaryUntil,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+const (
+	DefaultListObjectsLimit = 20
+	MaxListObjectsLimit     = 100
+)
+
+// ObjectFilter selects the objects a user can read, owned or granted to the user or its groups.
+type ObjectFilter struct {
+	UserID   string
+	GroupIDs []string
+	// Metadata only keeps objects having all of these key value pairs.
+	Metadata ObjectMetadata
+	Limit    int
+	Offset   int
+}
+
+type ListObjectsPayload struct {
+	Metadata ObjectMetadata
+	Limit    int
+	Offset   int
+}
+
+// Normalize clamps the page to sane bounds.
+func (m *ListObjectsPayload) Normalize() *ListObjectsPayload {
+	if m.Limit <= 0 {
+		m.Limit = DefaultListObjectsLimit
+	}
+	if m.Limit > MaxListObjectsLimit {
+		m.Limit = MaxListObjectsLimit
+	}
+	if m.Offset < 0 {
+		m.Offset = 0
+	}
+	return m
+}
+
+type HTTPDeleteObjectRequest struct {
+	ObjectID string `param:"id"`
+}
+
+type HTTPListObjectsRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+	// Metadata is filled from the HTTPMetadataFilterPrefix query parameters.
+	Metadata ObjectMetadata `json:"-"`
+}
+
+func (m *HTTPListObjectsRequest) ToPayload() *ListObjectsPayload {
+	return &ListObjectsPayload{
+		Metadata: m.Metadata,
+		Limit:    m.Limit,
+		Offset:   m.Offset,
+	}
+}
+
+func (m *Object) ToGRPCResponse() *pb.Object {
+	res := &pb.Object{
+		Id:               m.ID,
+		FileName:         m.FileName,
+		OriginalFileName: m.DownloadFileName(),
+		Type:             m.Type,
+		IsPublic:         m.IsPublic,
+		UploadedBy:       m.UploadedBy,
+		Size:             m.Size,
+		ChecksumSha256:   m.ChecksumSHA256,
+		Encrypted:        m.IsEncrypted(),
+		Version:          m.Version,
+		Metadata:         m.Metadata,
+		CreatedAt:        m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if m.TemporaryUntil != nil {
+		res.TemporaryUntil = m.TemporaryUntil.UTC().Format(time.RFC3339Nano)
 	}
+	return res
 }
 
 type ObjectRepository interface {
 	Create(ctx context.Context, data *ObjectPayload) error
 	FindByID(ctx context.Context, id string) (*Object, error)
-	GeneratePresignedURL(ctx context.Context, object *Object) (*GetPresignedURLResponse, error)
+	FindAll(ctx context.Context, filter *ObjectFilter) ([]*Object, error)
+	// FindByTypeID pages through the objects of the type in the context tenant ordered by id.
+	FindByTypeID(ctx context.Context, typeID string, afterID string, limit int) ([]*Object, error)
+	GeneratePresignedURL(ctx context.Context, object *Object, minValidity time.Duration) (*GetPresignedURLResponse, error)
+	// SignPresignedURL signs an uncached url valid for ttl.
+	SignPresignedURL(ctx context.Context, object *Object, ttl time.Duration) (*GetPresignedURLResponse, error)
+	GetContent(ctx context.Context, object *Object, payload *GetObjectContentPayload) (*ObjectContent, error)
+	Update(ctx context.Context, object *Object, changes map[string]any) error
 	DeleteByID(ctx context.Context, id string) error
+	FindUnreferencedTemporary(ctx context.Context, before time.Time, limit int, offset int) ([]*Object, error)
+	DeleteUnreferencedTemporary(ctx context.Context, id string, before time.Time) (bool, error)
+	DeleteContent(ctx context.Context, object *Object) error
+	FindByIdempotencyKey(ctx context.Context, userID string, idempotencyKey string) (*Object, error)
+	// FindAllAfterID pages through the objects of every tenant ordered by id,
+	// their Bucket is resolved to the bucket the content lives in.
+	FindAllAfterID(ctx context.Context, afterID string, limit int) ([]*Object, error)
+	ListContent(ctx context.Context, bucket string, continuationToken string) (*StoredContentPage, error)
+	// UpdateWrappedDataKey stores the rewrapped data key of the object unless its
+	// key was changed since it was read with previousKeyID.
+	UpdateWrappedDataKey(ctx context.Context, object *Object, previousKeyID string) (bool, error)
 
 	// DI
 	InjectS3Client(client S3Client) error
+	InjectKeyring(keyring Keyring) error
 	InjectDB(db *gorm.DB) error
-	InjectRedisClient(client *redis.Client) error
+	InjectCache(cache Cache) error
 }
 
 type ObjectUsecase interface {
 	Upload(ctx context.Context, payload *ObjectPayload) (*Object, error)
 	GeneratePresignedURL(ctx context.Context, payload *GetPresignedURLPayload) (*GetPresignedURLResponse, error)
+	GetObjectContent(ctx context.Context, payload *GetObjectContentPayload) (*ObjectContent, error)
+	UpdateObject(ctx context.Context, payload *UpdateObjectPayload) (*Object, error)
 	DeleteObject(ctx context.Context, id string) error
+	// PurgeObject is the operator delete of the cli, it skips the access checks.
+	PurgeObject(ctx context.Context, id string) (*Object, error)
+	ListObjects(ctx context.Context, payload *ListObjectsPayload) ([]*Object, error)
+	GrantAccess(ctx context.Context, payload *GrantObjectAccessPayload) (*ObjectGrant, error)
+	RevokeAccess(ctx context.Context, objectID string, grantID string) error
+	ListGrants(ctx context.Context, objectID string) ([]*ObjectGrant, error)
+	CreateShareLink(ctx context.Context, payload *CreateShareLinkPayload) (*CreatedShareLink, error)
+	ListShareLinks(ctx context.Context, objectID string) ([]*ShareLink, error)
+	RevokeShareLink(ctx context.Context, objectID string, id string) error
+	ResolveShareLink(ctx context.Context, payload *ResolveShareLinkPayload) (*ObjectContent, error)
+	AddReference(ctx context.Context, payload *ObjectReferencePayload) (*ObjectReference, error)
+	RemoveReference(ctx context.Context, payload *ObjectReferencePayload) error
 
 	// DI
 	InjectObjectRepo(repo ObjectRepository) error
 	InjectObjectTypeRepo(repo ObjectTypeRepository) error
 	InjectObjectWhitelistTypeRepo(repo ObjectWhitelistTypeRepository) error
+	InjectObjectGrantRepo(repo ObjectGrantRepository) error
+	InjectShareLinkRepo(repo ShareLinkRepository) error
+	InjectObjectReferenceRepo(repo ObjectReferenceRepository) error
+	InjectPendingDeletionRepo(repo PendingDeletionRepository) error
+	InjectStorageUsageUsecase(storageUsageUC StorageUsageUsecase) error
 	InjectAuthClient(client authPB.AuthServiceClient) error
 	InjectJetstreamClient(client nats.JetStreamContext) error
+	InjectAuditLogUsecase(auditLogUC AuditLogUsecase) error
 
 	// Jetstream
 	CreateStream() error
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // Content-MD5 is an integrity check, not a security one
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+var (
+	ErrInvalidChecksum  = errors.New("invalid checksum")
+	ErrChecksumMismatch = errors.New("content does not match the checksum")
+)
+
+// SHA256Hex is the digest stored on objects.
+func SHA256Hex(src []byte) string {
+	sum := sha256.Sum256(src)
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA256HexToBase64 converts a stored digest to the encoding s3 expects.
+func SHA256HexToBase64(checksum string) string {
+	sum, err := hex.DecodeString(checksum)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(sum)
+}
+
+// VerifyChecksums checks src against the digests sent by the caller, empty digests are skipped.
+// contentMD5 is base64 as in the Content-MD5 header, checksumSHA256 is hex or base64.
+func VerifyChecksums(src []byte, contentMD5 string, checksumSHA256 string) error {
+	if contentMD5 != "" {
+		want, err := base64.StdEncoding.DecodeString(contentMD5)
+		if err != nil || len(want) != md5.Size {
+			return ErrInvalidChecksum
+		}
+		sum := md5.Sum(src) //nolint:gosec
+		if !bytes.Equal(sum[:], want) {
+			return ErrChecksumMismatch
+		}
+	}
+
+	if checksumSHA256 != "" {
+		want, err := decodeSHA256(checksumSHA256)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(src)
+		if !bytes.Equal(sum[:], want) {
+			return ErrChecksumMismatch
+		}
+	}
+	return nil
+}
+
+func decodeSHA256(checksum string) ([]byte, error) {
+	if len(checksum) == hex.EncodedLen(sha256.Size) {
+		if sum, err := hex.DecodeString(checksum); err == nil {
+			return sum, nil
+		}
+	}
+	sum, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(sum) != sha256.Size {
+		return nil, ErrInvalidChecksum
+	}
+	return sum, nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	DownloadModeRedirect = "redirect"
+	DownloadModeProxy    = "proxy"
+)
+
+var (
+	ErrInvalidDownloadMode = errors.New("invalid download mode")
+	ErrInvalidRange        = errors.New("requested range not satisfiable")
+	ErrContentNotFound     = errors.New("object content not found")
+)
+
+type GetObjectContentPayload struct {
+	ObjectID string
+	// Range and IfNoneMatch are passed through from the request headers.
+	Range       string
+	IfNoneMatch string
+	// Redirect asks for a presigned url instead of the body,
+	// encrypted objects are streamed regardless.
+	Redirect bool
+}
+
+// ObjectContent is a streamed object, Body is nil when NotModified is set
+// or when the object is served through RedirectURL.
+type ObjectContent struct {
+	Object        *Object
+	RedirectURL   string
+	Body          io.ReadCloser
+	ContentType   string
+	ContentLength int64
+	ContentRange  string
+	ETag          string
+	CacheControl  string
+	LastModified  *time.Time
+	NotModified   bool
+}
+
+// IsPartial reports whether only the requested range is returned.
+func (m *ObjectContent) IsPartial() bool {
+	return m.ContentRange != ""
+}
+
+type HTTPGetObjectContentRequest struct {
+	ObjectID string `param:"id"`
+	// Mode overrides the configured download mode.
+	Mode     string `query:"mode"`
+	Download bool   `query:"download"`
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -destination=mock/mock_object_gc_usecase.go -package=mock github.com/krobus00/storage-service/internal/model ObjectGCUsecase
+
+package model
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+type ObjectGCUsecase interface {
+	// PurgeTemporaryObjects deletes expired temporary objects nothing references,
+	// in dry run the candidates are only returned.
+	PurgeTemporaryObjects(ctx context.Context, dryRun bool) ([]*Object, error)
+	// RetryPendingDeletions deletes the blobs left behind by failed writes and
+	// returns how many are gone.
+	RetryPendingDeletions(ctx context.Context) (int, error)
+
+	// DI
+	InjectObjectRepo(repo ObjectRepository) error
+	InjectPendingDeletionRepo(repo PendingDeletionRepository) error
+	InjectJetstreamClient(client nats.JetStreamContext) error
+}
@@ -0,0 +1,167 @@
+//go:generate mockgen -destination=mock/mock_object_grant_repository.go -package=mock github.com/krobus00/storage-service/internal/model ObjectGrantRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	GranteeTypeUser  = "user"
+	GranteeTypeGroup = "group"
+
+	GrantPermissionRead   = "read"
+	GrantPermissionDelete = "delete"
+)
+
+var (
+	ErrInvalidObjectGrant  = errors.New("invalid object grant")
+	ErrObjectGrantNotFound = errors.New("object grant not found")
+)
+
+// ObjectGrant gives a user, or every member of an auth group, access to a single object.
+type ObjectGrant struct {
+	ID          string
+	ObjectID    string
+	GranteeType string
+	GranteeID   string
+	Permission  string
+	GrantedBy   string
+	ExpiredAt   *time.Time
+	CreatedAt   time.Time
+}
+
+func (ObjectGrant) TableName() string {
+	return "object_grants"
+}
+
+func NewObjectGrantCacheKey(tenantID string, objectID string) string {
+	return fmt.Sprintf("tenants:%s:object-grants:objectID:%s", tenantID, objectID)
+}
+
+func (m *ObjectGrant) IsActive(now time.Time) bool {
+	return m.ExpiredAt == nil || m.ExpiredAt.After(now)
+}
+
+// Allows reports whether the grant gives permission to the user or one of its groups.
+func (m *ObjectGrant) Allows(permission string, userID string, groupIDs []string) bool {
+	if m.Permission != permission || !m.IsActive(time.Now()) {
+		return false
+	}
+	switch m.GranteeType {
+	case GranteeTypeUser:
+		return m.GranteeID == userID
+	case GranteeTypeGroup:
+		for _, groupID := range groupIDs {
+			if m.GranteeID == groupID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *ObjectGrant) Validate() error {
+	switch {
+	case m.GranteeType != GranteeTypeUser && m.GranteeType != GranteeTypeGroup,
+		m.Permission != GrantPermissionRead && m.Permission != GrantPermissionDelete,
+		m.GranteeID == "",
+		m.ExpiredAt != nil && !m.IsActive(time.Now()):
+		return ErrInvalidObjectGrant
+	}
+	return nil
+}
+
+type GrantObjectAccessPayload struct {
+	ObjectID    string
+	GranteeType string
+	GranteeID   string
+	Permission  string
+	ExpiredAt   *time.Time
+}
+
+type HTTPGrantObjectAccessRequest struct {
+	ObjectID    string     `param:"id"`
+	GranteeType string     `json:"granteeType"`
+	GranteeID   string     `json:"granteeID"`
+	Permission  string     `json:"permission"`
+	ExpiredAt   *time.Time `json:"expiredAt"`
+}
+
+func (m *HTTPGrantObjectAccessRequest) ToPayload() *GrantObjectAccessPayload {
+	return &GrantObjectAccessPayload{
+		ObjectID:    m.ObjectID,
+		GranteeType: m.GranteeType,
+		GranteeID:   m.GranteeID,
+		Permission:  m.Permission,
+		ExpiredAt:   m.ExpiredAt,
+	}
+}
+
+type HTTPRevokeObjectAccessRequest struct {
+	ObjectID string `param:"id"`
+	GrantID  string `param:"grantID"`
+}
+
+type HTTPListObjectGrantsRequest struct {
+	ObjectID string `param:"id"`
+}
+
+type HTTPObjectGrantResponse struct {
+	ID          string `json:"id"`
+	ObjectID    string `json:"objectID"`
+	GranteeType string `json:"granteeType"`
+	GranteeID   string `json:"granteeID"`
+	Permission  string `json:"permission"`
+	GrantedBy   string `json:"grantedBy"`
+	ExpiredAt   string `json:"expiredAt,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+func (m *ObjectGrant) ToHTTPResponse() *HTTPObjectGrantResponse {
+	res := &HTTPObjectGrantResponse{
+		ID:          m.ID,
+		ObjectID:    m.ObjectID,
+		GranteeType: m.GranteeType,
+		GranteeID:   m.GranteeID,
+		Permission:  m.Permission,
+		GrantedBy:   m.GrantedBy,
+		CreatedAt:   m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if m.ExpiredAt != nil {
+		res.ExpiredAt = m.ExpiredAt.UTC().Format(time.RFC3339Nano)
+	}
+	return res
+}
+
+func (m *ObjectGrant) ToGRPCResponse() *pb.ObjectGrant {
+	res := &pb.ObjectGrant{
+		Id:          m.ID,
+		ObjectId:    m.ObjectID,
+		GranteeType: m.GranteeType,
+		GranteeId:   m.GranteeID,
+		Permission:  m.Permission,
+		GrantedBy:   m.GrantedBy,
+		CreatedAt:   m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if m.ExpiredAt != nil {
+		res.ExpiredAt = m.ExpiredAt.UTC().Format(time.RFC3339Nano)
+	}
+	return res
+}
+
+type ObjectGrantRepository interface {
+	Create(ctx context.Context, grant *ObjectGrant) error
+	FindByObjectID(ctx context.Context, objectID string) ([]*ObjectGrant, error)
+	DeleteByID(ctx context.Context, objectID string, id string) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+	InjectCache(cache Cache) error
+}
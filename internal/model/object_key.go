@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultObjectKeyTemplate is used by types without a template, it keeps the
+// historical <uploadedBy>/ prefix with the object id in place of the timestamp.
+const DefaultObjectKeyTemplate = "{user}/{id}{ext}"
+
+var ErrInvalidObjectKeyTemplate = errors.New("invalid object key template")
+
+var objectKeyPlaceholderPattern = regexp.MustCompile(`\{[a-z]*\}`)
+
+// ObjectKeyVars are the values a key template is rendered with.
+type ObjectKeyVars struct {
+	Tenant string
+	Type   string
+	User   string
+	ID     string
+	// Ext is the detected extension including the dot, empty when unknown.
+	Ext string
+	At  time.Time
+}
+
+func (v ObjectKeyVars) lookup(placeholder string) (string, bool) {
+	switch placeholder {
+	case "{tenant}":
+		return v.Tenant, true
+	case "{type}":
+		return v.Type, true
+	case "{user}":
+		return v.User, true
+	case "{id}":
+		return v.ID, true
+	case "{uuid}":
+		return uuid.NewString(), true
+	case "{ext}":
+		return v.Ext, true
+	case "{yyyy}":
+		return fmt.Sprintf("%04d", v.At.Year()), true
+	case "{mm}":
+		return fmt.Sprintf("%02d", int(v.At.Month())), true
+	case "{dd}":
+		return fmt.Sprintf("%02d", v.At.Day()), true
+	case "{unixnano}":
+		return strconv.FormatInt(v.At.UnixNano(), 10), true
+	default:
+		return "", false
+	}
+}
+
+// ValidateObjectKeyTemplate rejects unknown placeholders, absolute or parent paths,
+// and templates without {id} or {uuid}, a timestamp alone can collide.
+func ValidateObjectKeyTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if strings.HasPrefix(template, "/") {
+		return ErrInvalidObjectKeyTemplate
+	}
+	for _, segment := range strings.Split(template, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidObjectKeyTemplate
+		}
+	}
+
+	unique := false
+	for _, placeholder := range objectKeyPlaceholderPattern.FindAllString(template, -1) {
+		if _, ok := (ObjectKeyVars{}).lookup(placeholder); !ok {
+			return ErrInvalidObjectKeyTemplate
+		}
+		switch placeholder {
+		case "{id}", "{uuid}":
+			unique = true
+		}
+	}
+	if !unique || strings.ContainsAny(objectKeyPlaceholderPattern.ReplaceAllString(template, ""), "{}") {
+		return ErrInvalidObjectKeyTemplate
+	}
+	return nil
+}
+
+// RenderObjectKey fills the template, an empty template renders the default one.
+func RenderObjectKey(template string, vars ObjectKeyVars) string {
+	if template == "" {
+		template = DefaultObjectKeyTemplate
+	}
+	return objectKeyPlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, _ := vars.lookup(placeholder)
+		return value
+	})
+}
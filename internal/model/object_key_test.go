@@ -0,0 +1,113 @@
+package model
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestValidateObjectKeyTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  error
+	}{
+		{
+			name:     "success empty template",
+			template: "",
+			wantErr:  nil,
+		},
+		{
+			name:     "success id",
+			template: "{tenant}/{type}/{yyyy}/{mm}/{dd}/{id}{ext}",
+			wantErr:  nil,
+		},
+		{
+			name:     "success uuid",
+			template: "{user}/{unixnano}-{uuid}{ext}",
+			wantErr:  nil,
+		},
+		{
+			name:     "error timestamp only",
+			template: "{type}/{unixnano}{ext}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error no unique placeholder",
+			template: "{user}/avatar{ext}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error unknown placeholder",
+			template: "{bucket}/{id}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error unbalanced brace",
+			template: "{user/{id}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error absolute path",
+			template: "/{id}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error parent path",
+			template: "../{id}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+		{
+			name:     "error empty segment",
+			template: "{type}//{id}",
+			wantErr:  ErrInvalidObjectKeyTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateObjectKeyTemplate(tt.template); err != tt.wantErr {
+				t.Errorf("ValidateObjectKeyTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderObjectKey(t *testing.T) {
+	vars := ObjectKeyVars{
+		Tenant: "acme",
+		Type:   "image",
+		User:   "user-1",
+		ID:     "object-1",
+		Ext:    ".png",
+		At:     time.Date(2023, time.April, 9, 10, 0, 0, 42, time.UTC),
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "default template",
+			template: "",
+			want:     `^user-1/object-1\.png$`,
+		},
+		{
+			name:     "date layout",
+			template: "{tenant}/{type}/{yyyy}/{mm}/{dd}/{id}{ext}",
+			want:     `^acme/image/2023/04/09/object-1\.png$`,
+		},
+		{
+			name:     "timestamp and uuid",
+			template: "{user}/{unixnano}-{uuid}{ext}",
+			want:     `^user-1/1681034400000000042-[0-9a-f-]{36}\.png$`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderObjectKey(tt.template, vars)
+			if !regexp.MustCompile(tt.want).MatchString(got) {
+				t.Errorf("RenderObjectKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
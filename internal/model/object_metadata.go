@@ -0,0 +1,116 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	MaxObjectMetadataKeys        = 32
+	MaxObjectMetadataKeyLength   = 64
+	MaxObjectMetadataValueLength = 512
+	// MaxObjectMetadataSize bounds the summed length of all keys and values.
+	MaxObjectMetadataSize = 4096
+
+	// HTTPMetadataFilterPrefix prefixes list query parameters filtering by metadata, e.g. metadata.sku=123.
+	HTTPMetadataFilterPrefix = "metadata."
+)
+
+var ErrInvalidObjectMetadata = errors.New("invalid object metadata")
+
+// ObjectMetadata holds custom labels of an object, stored as a jsonb column.
+type ObjectMetadata map[string]string
+
+// Validate rejects metadata exceeding the key count, key and value lengths or total size.
+func (m ObjectMetadata) Validate() error {
+	if len(m) > MaxObjectMetadataKeys {
+		return ErrInvalidObjectMetadata
+	}
+	size := 0
+	for key, value := range m {
+		size += len(key) + len(value)
+		if size > MaxObjectMetadataSize {
+			return ErrInvalidObjectMetadata
+		}
+		if key == "" || len(key) > MaxObjectMetadataKeyLength || !utf8.ValidString(key) {
+			return ErrInvalidObjectMetadata
+		}
+		if len(value) > MaxObjectMetadataValueLength || !utf8.ValidString(value) {
+			return ErrInvalidObjectMetadata
+		}
+	}
+	return nil
+}
+
+func (m ObjectMetadata) Clone() ObjectMetadata {
+	if m == nil {
+		return nil
+	}
+	clone := make(ObjectMetadata, len(m))
+	for key, value := range m {
+		clone[key] = value
+	}
+	return clone
+}
+
+func (m ObjectMetadata) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (m *ObjectMetadata) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported object metadata type %T", src)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// ParseObjectMetadata decodes the json object sent along an upload, an empty
+// string means no metadata.
+func ParseObjectMetadata(raw string) (ObjectMetadata, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	metadata := ObjectMetadata{}
+	err := json.Unmarshal([]byte(raw), &metadata)
+	if err != nil {
+		return nil, ErrInvalidObjectMetadata
+	}
+	return metadata, nil
+}
+
+// NewMetadataFilter collects the prefixed query parameters into a metadata filter.
+func NewMetadataFilter(params map[string][]string) ObjectMetadata {
+	var filter ObjectMetadata
+	for name, values := range params {
+		key := strings.TrimPrefix(name, HTTPMetadataFilterPrefix)
+		if key == name || len(values) == 0 {
+			continue
+		}
+		if filter == nil {
+			filter = ObjectMetadata{}
+		}
+		filter[key] = values[0]
+	}
+	return filter
+}
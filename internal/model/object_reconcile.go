@@ -0,0 +1,62 @@
+//go:generate mockgen -destination=mock/mock_object_reconcile_usecase.go -package=mock github.com/krobus00/storage-service/internal/model ObjectReconcileUsecase
+
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// StoredContent is a blob found in a bucket listing.
+type StoredContent struct {
+	Bucket       string    `json:"bucket"`
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type StoredContentPage struct {
+	Contents          []*StoredContent
+	ContinuationToken string
+}
+
+// ReconcileOrphan is a blob without an object row.
+type ReconcileOrphan struct {
+	*StoredContent
+	// WithinGrace orphans may still get their row, an upload writes the blob first.
+	WithinGrace bool   `json:"withinGrace"`
+	Deleted     bool   `json:"deleted"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ReconcileReport compares the object rows with the bucket listings.
+type ReconcileReport struct {
+	DryRun         bool               `json:"dryRun"`
+	GracePeriod    string             `json:"gracePeriod"`
+	Buckets        []string           `json:"buckets"`
+	RowsScanned    int                `json:"rowsScanned"`
+	BlobsScanned   int                `json:"blobsScanned"`
+	MissingContent []*Object          `json:"missingContent"`
+	Orphans        []*ReconcileOrphan `json:"orphans"`
+	OrphansDeleted int                `json:"orphansDeleted"`
+}
+
+func NewReconcileReport(dryRun bool, gracePeriod time.Duration) *ReconcileReport {
+	return &ReconcileReport{
+		DryRun:         dryRun,
+		GracePeriod:    gracePeriod.String(),
+		Buckets:        make([]string, 0),
+		MissingContent: make([]*Object, 0),
+		Orphans:        make([]*ReconcileOrphan, 0),
+	}
+}
+
+type ObjectReconcileUsecase interface {
+	// Reconcile reports rows whose content is missing and blobs without a row,
+	// orphans older than the grace period are deleted unless in dry run.
+	Reconcile(ctx context.Context, dryRun bool) (*ReconcileReport, error)
+
+	// DI
+	InjectObjectRepo(repo ObjectRepository) error
+	InjectObjectTypeRepo(repo ObjectTypeRepository) error
+}
@@ -0,0 +1,75 @@
+//go:generate mockgen -destination=mock/mock_object_reference_repository.go -package=mock github.com/krobus00/storage-service/internal/model ObjectReferenceRepository
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	MaxReferenceKeyLength = 64
+	MaxReferenceIDLength  = 128
+)
+
+var (
+	ErrInvalidObjectReference  = errors.New("invalid object reference")
+	ErrObjectReferenceNotFound = errors.New("object reference not found")
+)
+
+// ObjectReference claims an object for an entity of another service, a
+// temporary object with a reference is never purged.
+type ObjectReference struct {
+	ID           string
+	ObjectID     string
+	OwnerService string
+	EntityType   string
+	EntityID     string
+	CreatedBy    string
+	CreatedAt    time.Time
+}
+
+func (ObjectReference) TableName() string {
+	return "object_references"
+}
+
+type ObjectReferencePayload struct {
+	ObjectID     string
+	OwnerService string
+	EntityType   string
+	EntityID     string
+}
+
+func (m *ObjectReferencePayload) Validate() error {
+	switch {
+	case m.OwnerService == "", len(m.OwnerService) > MaxReferenceKeyLength,
+		m.EntityType == "", len(m.EntityType) > MaxReferenceKeyLength,
+		m.EntityID == "", len(m.EntityID) > MaxReferenceIDLength:
+		return ErrInvalidObjectReference
+	}
+	return nil
+}
+
+func (m *ObjectReference) ToGRPCResponse() *pb.ObjectReference {
+	return &pb.ObjectReference{
+		Id:           m.ID,
+		ObjectId:     m.ObjectID,
+		OwnerService: m.OwnerService,
+		EntityType:   m.EntityType,
+		EntityId:     m.EntityID,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+type ObjectReferenceRepository interface {
+	Create(ctx context.Context, reference *ObjectReference) error
+	Delete(ctx context.Context, payload *ObjectReferencePayload) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,40 @@
+//go:generate mockgen -destination=mock/mock_object_rewrap_usecase.go -package=mock github.com/krobus00/storage-service/internal/model ObjectRewrapUsecase
+
+package model
+
+import (
+	"context"
+)
+
+// ObjectRewrapFailure is an encrypted object whose data key could not be rewrapped.
+type ObjectRewrapFailure struct {
+	Object *Object `json:"object"`
+	Error  string  `json:"error"`
+}
+
+// ObjectRewrapReport summarizes a rewrap run, Pending counts the objects a dry
+// run would have rewrapped.
+type ObjectRewrapReport struct {
+	ActiveKeyID string                 `json:"activeKeyID"`
+	Checked     int                    `json:"checked"`
+	Pending     int                    `json:"pending"`
+	Rewrapped   int                    `json:"rewrapped"`
+	Failures    []*ObjectRewrapFailure `json:"failures"`
+}
+
+func NewObjectRewrapReport(activeKeyID string) *ObjectRewrapReport {
+	return &ObjectRewrapReport{
+		ActiveKeyID: activeKeyID,
+		Failures:    make([]*ObjectRewrapFailure, 0),
+	}
+}
+
+type ObjectRewrapUsecase interface {
+	// Rewrap wraps the data keys of objects encrypted under a retired master key
+	// with the active one, the content itself is not rewritten.
+	Rewrap(ctx context.Context, dryRun bool) (*ObjectRewrapReport, error)
+
+	// DI
+	InjectObjectRepo(repo ObjectRepository) error
+	InjectKeyring(keyring Keyring) error
+}
@@ -7,46 +7,102 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"gorm.io/gorm"
 )
 
 var (
 	ErrObjectTypeNotFound  = errors.New("object type not found")
 	ErrExtensionNotAllowed = errors.New("object extensions not allowed")
+	ErrInvalidStorageClass = errors.New("invalid storage class")
 )
 
 type ObjectType struct {
-	ID   string
-	Name string
+	ID       string
+	TenantID string
+	Name     string
+	// Bucket overrides the tenant bucket, KeyTemplate the default key layout.
+	Bucket       string
+	KeyTemplate  string
+	StorageClass string
+	CacheControl string
+	// Encrypted types get envelope encryption, their objects are only served through the proxy.
+	Encrypted bool
 }
 
 func (ObjectType) TableName() string {
 	return "object_types"
 }
 
-func NewObjectTypeCacheKeyByID(id string) string {
-	return fmt.Sprintf("objects:type:typeID:%s", id)
+func NewObjectTypeCacheKeyByID(tenantID string, id string) string {
+	return fmt.Sprintf("tenants:%s:objects:type:typeID:%s", tenantID, id)
 }
 
-func NewObjectTypeCacheKeyByName(name string) string {
-	return fmt.Sprintf("objects:type:typeName:%s", name)
+func NewObjectTypeCacheKeyByName(tenantID string, name string) string {
+	return fmt.Sprintf("tenants:%s:objects:type:typeName:%s", tenantID, name)
 }
 
-func GetObjectTypeCacheKeys(id string, name string) []string {
+func GetObjectTypeCacheKeys(tenantID string, id string, name string) []string {
 	return []string{
-		NewObjectTypeCacheKeyByID(id),
-		NewObjectTypeCacheKeyByName(name),
+		NewObjectTypeCacheKeyByID(tenantID, id),
+		NewObjectTypeCacheKeyByName(tenantID, name),
 	}
 }
 
+func NewObjectTypeCacheTag(tenantID string, id string) string {
+	return fmt.Sprintf("tags:tenants:%s:objects:type:typeID:%s", tenantID, id)
+}
+
+type HTTPObjectTypeResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Bucket       string `json:"bucket"`
+	KeyTemplate  string `json:"keyTemplate"`
+	StorageClass string `json:"storageClass"`
+	CacheControl string `json:"cacheControl"`
+	Encrypted    bool   `json:"encrypted"`
+}
+
+func (m *ObjectType) ToHTTPResponse() *HTTPObjectTypeResponse {
+	return &HTTPObjectTypeResponse{
+		ID:           m.ID,
+		Name:         m.Name,
+		Bucket:       m.Bucket,
+		KeyTemplate:  m.KeyTemplate,
+		StorageClass: m.StorageClass,
+		CacheControl: m.CacheControl,
+		Encrypted:    m.Encrypted,
+	}
+}
+
+// Validate checks the routing settings of the type.
+func (m *ObjectType) Validate() error {
+	if err := ValidateObjectKeyTemplate(m.KeyTemplate); err != nil {
+		return err
+	}
+	if m.StorageClass == "" {
+		return nil
+	}
+	for _, storageClass := range types.StorageClass("").Values() {
+		if string(storageClass) == m.StorageClass {
+			return nil
+		}
+	}
+	return ErrInvalidStorageClass
+}
+
 type ObjectTypeRepository interface {
 	Create(ctx context.Context, objectType *ObjectType) error
+	FindAll(ctx context.Context) ([]*ObjectType, error)
 	FindByID(ctx context.Context, id string) (*ObjectType, error)
 	FindByName(ctx context.Context, name string) (*ObjectType, error)
+	// Update writes the routing settings, objects already stored keep their bucket and key.
+	Update(ctx context.Context, objectType *ObjectType) error
 	DeleteByID(ctx context.Context, id string) error
+	// FindBuckets returns the buckets set on the types of every tenant.
+	FindBuckets(ctx context.Context) ([]string, error)
 
 	// DI
 	InjectDB(db *gorm.DB) error
-	InjectRedisClient(client *redis.Client) error
+	InjectCache(cache Cache) error
 }
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"path/filepath"
+)
+
+const ObjectUpdatedSubject = "OBJECTS.updated"
+
+var (
+	ErrInvalidObjectUpdate   = errors.New("invalid object update")
+	ErrObjectVersionConflict = errors.New("object was modified concurrently")
+)
+
+// UpdateObjectPayload changes only the fields that are set, Version must be
+// the version the caller read.
+type UpdateObjectPayload struct {
+	ObjectID string
+	Version  int64
+	IsPublic *bool
+	FileName *string
+	// Metadata replaces the whole metadata when set.
+	Metadata *ObjectMetadata
+}
+
+func (m *UpdateObjectPayload) Validate() error {
+	if m.Version <= 0 {
+		return ErrInvalidObjectUpdate
+	}
+	if m.IsPublic == nil && m.FileName == nil && m.Metadata == nil {
+		return ErrInvalidObjectUpdate
+	}
+	if m.FileName != nil && SanitizeFileName(*m.FileName) == "" {
+		return ErrInvalidObjectUpdate
+	}
+	if m.Metadata != nil {
+		return m.Metadata.Validate()
+	}
+	return nil
+}
+
+// Apply sets the payload fields on the object and returns the changed columns.
+func (m *UpdateObjectPayload) Apply(object *Object) map[string]any {
+	changes := make(map[string]any)
+	if m.IsPublic != nil && *m.IsPublic != object.IsPublic {
+		object.IsPublic = *m.IsPublic
+		changes["is_public"] = object.IsPublic
+	}
+	if m.FileName != nil {
+		object.Rename(*m.FileName)
+		changes["file_name"] = object.FileName
+		changes["original_file_name"] = object.OriginalFileName
+	}
+	if m.Metadata != nil {
+		object.Metadata = *m.Metadata
+		changes["metadata"] = object.Metadata
+	}
+	return changes
+}
+
+// Rename keeps the stored extension, it was derived from the content at upload.
+func (m *Object) Rename(fileName string) *Object {
+	ext := filepath.Ext(m.FileName)
+	m.OriginalFileName = SanitizeFileName(fileName)
+	m.FileName = m.OriginalFileName
+	if ext != "" {
+		m.FileName = WithExtension(m.FileName, []string{ext})
+	}
+	return m
+}
+
+type JSUpdateObjectPayload struct {
+	TenantID  string   `json:"tenantID"`
+	ObjectID  string   `json:"objectID"`
+	UpdatedBy string   `json:"updatedBy"`
+	Version   int64    `json:"version"`
+	Changes   []string `json:"changes"`
+}
+
+type HTTPUpdateObjectRequest struct {
+	ObjectID string          `param:"id"`
+	Version  int64           `json:"version"`
+	IsPublic *bool           `json:"isPublic"`
+	FileName *string         `json:"fileName"`
+	Metadata *ObjectMetadata `json:"metadata"`
+}
+
+func (m *HTTPUpdateObjectRequest) ToPayload() *UpdateObjectPayload {
+	return &UpdateObjectPayload{
+		ObjectID: m.ObjectID,
+		Version:  m.Version,
+		IsPublic: m.IsPublic,
+		FileName: m.FileName,
+		Metadata: m.Metadata,
+	}
+}
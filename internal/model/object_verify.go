@@ -0,0 +1,43 @@
+//go:generate mockgen -destination=mock/mock_object_verify_usecase.go -package=mock github.com/krobus00/storage-service/internal/model ObjectVerifyUsecase
+
+package model
+
+import (
+	"context"
+)
+
+const (
+	VerifyStatusMissing   = "missing"
+	VerifyStatusCorrupted = "corrupted"
+	VerifyStatusError     = "error"
+)
+
+// ObjectVerifyFailure is an object whose stored content does not match its row.
+type ObjectVerifyFailure struct {
+	Object *Object `json:"object"`
+	Status string  `json:"status"`
+	Detail string  `json:"detail"`
+}
+
+// ObjectVerifyReport summarizes a verify run, objects stored before checksums
+// are only checked for their size.
+type ObjectVerifyReport struct {
+	Checked  int                    `json:"checked"`
+	Passed   int                    `json:"passed"`
+	SizeOnly int                    `json:"sizeOnly"`
+	Failures []*ObjectVerifyFailure `json:"failures"`
+}
+
+func NewObjectVerifyReport() *ObjectVerifyReport {
+	return &ObjectVerifyReport{
+		Failures: make([]*ObjectVerifyFailure, 0),
+	}
+}
+
+type ObjectVerifyUsecase interface {
+	// Verify re-reads the content of every object and reports the missing and corrupted ones.
+	Verify(ctx context.Context) (*ObjectVerifyReport, error)
+
+	// DI
+	InjectObjectRepo(repo ObjectRepository) error
+}
@@ -6,11 +6,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 )
 
 type ObjectWhitelistType struct {
+	TenantID  string
 	TypeID    string
 	Extension string
 }
@@ -19,14 +19,32 @@ func (ObjectWhitelistType) TableName() string {
 	return "object_whitelist_types"
 }
 
-func NewObjectWhitelistTypeCacheKey(typeID string) string {
-	return fmt.Sprintf("object-whitelist-types:typeID:%s:extension", typeID)
+func NewObjectWhitelistTypeCacheKey(tenantID string, typeID string) string {
+	return fmt.Sprintf("tenants:%s:object-whitelist-types:typeID:%s:extension", tenantID, typeID)
 }
 
-func GetObjectWhitelistTypCacheKeys(typeID string) []string {
+func NewObjectWhitelistTypeCacheTag(tenantID string, typeID string) string {
+	return fmt.Sprintf("tags:tenants:%s:object-whitelist-types:typeID:%s", tenantID, typeID)
+}
+
+// GetObjectWhitelistTypeCacheTags return tags for whitelist cache entries, the entries are
+// also tagged with their type since deleting a type cascades to its whitelist.
+func GetObjectWhitelistTypeCacheTags(tenantID string, typeID string) []string {
 	return []string{
-		NewObjectWhitelistTypeCacheKey(typeID),
-		"object-whitelist-types:typeID:*",
+		NewObjectWhitelistTypeCacheTag(tenantID, typeID),
+		NewObjectTypeCacheTag(tenantID, typeID),
+	}
+}
+
+type HTTPObjectWhitelistTypeResponse struct {
+	TypeID    string `json:"typeID"`
+	Extension string `json:"extension"`
+}
+
+func (m *ObjectWhitelistType) ToHTTPResponse() *HTTPObjectWhitelistTypeResponse {
+	return &HTTPObjectWhitelistTypeResponse{
+		TypeID:    m.TypeID,
+		Extension: m.Extension,
 	}
 }
 
@@ -37,5 +55,5 @@ type ObjectWhitelistTypeRepository interface {
 
 	// DI
 	InjectDB(db *gorm.DB) error
-	InjectRedisClient(client *redis.Client) error
+	InjectCache(cache Cache) error
 }
@@ -0,0 +1,38 @@
+//go:generate mockgen -destination=mock/mock_pending_deletion_repository.go -package=mock github.com/krobus00/storage-service/internal/model PendingDeletionRepository
+
+package model
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PendingDeletion is a blob left behind by a failed write whose cleanup failed too,
+// the gc retries the delete.
+type PendingDeletion struct {
+	ID        string
+	Bucket    string
+	Key       string
+	Reason    string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (PendingDeletion) TableName() string {
+	return "pending_deletions"
+}
+
+type PendingDeletionRepository interface {
+	Create(ctx context.Context, deletion *PendingDeletion) error
+	// FindAll is a system query and spans every tenant, the least recently tried come first.
+	FindAll(ctx context.Context, limit int) ([]*PendingDeletion, error)
+	MarkFailed(ctx context.Context, id string, cause error) error
+	DeleteByID(ctx context.Context, id string) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
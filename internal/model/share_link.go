@@ -0,0 +1,170 @@
+//go:generate mockgen -destination=mock/mock_share_link_repository.go -package=mock github.com/krobus00/storage-service/internal/model ShareLinkRepository
+
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidShareLink       = errors.New("invalid share link")
+	ErrShareLinkNotFound      = errors.New("share link not found")
+	ErrShareLinkUnavailable   = errors.New("share link expired, revoked or used up")
+	ErrShareLinkWrongPassword = errors.New("share link password mismatch")
+	ErrShareLinkLocked        = errors.New("share link locked after too many wrong passwords")
+)
+
+// ShareLink lets anyone holding its token download an object until it expires,
+// is revoked or runs out of downloads. Only the token hash is stored.
+type ShareLink struct {
+	ID             string
+	TenantID       string
+	ObjectID       string
+	TokenHash      string
+	PasswordHash   *string
+	MaxDownloads   int
+	DownloadCount  int
+	FailedAttempts int
+	LockedUntil    *time.Time
+	CreatedBy      string
+	ExpiredAt      time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+}
+
+func (ShareLink) TableName() string {
+	return "share_links"
+}
+
+// HashShareToken returns the value stored in place of the token.
+func HashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (m *ShareLink) HasPassword() bool {
+	return m.PasswordHash != nil
+}
+
+// IsUsable reports whether the link may still be downloaded at the given time,
+// the download count is rechecked when the use is counted.
+func (m *ShareLink) IsUsable(now time.Time) bool {
+	if m.RevokedAt != nil || !m.ExpiredAt.After(now) {
+		return false
+	}
+	return m.MaxDownloads == 0 || m.DownloadCount < m.MaxDownloads
+}
+
+// IsLocked reports whether password attempts are refused at the given time.
+func (m *ShareLink) IsLocked(now time.Time) bool {
+	return m.LockedUntil != nil && m.LockedUntil.After(now)
+}
+
+type CreateShareLinkPayload struct {
+	ObjectID     string
+	ExpiredAt    *time.Time
+	MaxDownloads int
+	Password     string
+}
+
+type ResolveShareLinkPayload struct {
+	Token    string
+	Password string
+}
+
+// CreatedShareLink carries the token, which is only known at creation time.
+type CreatedShareLink struct {
+	*ShareLink
+	Token string
+}
+
+type HTTPCreateShareLinkRequest struct {
+	ObjectID     string     `param:"id"`
+	ExpiredAt    *time.Time `json:"expiredAt"`
+	MaxDownloads int        `json:"maxDownloads"`
+	Password     string     `json:"password"`
+}
+
+func (m *HTTPCreateShareLinkRequest) ToPayload() *CreateShareLinkPayload {
+	return &CreateShareLinkPayload{
+		ObjectID:     m.ObjectID,
+		ExpiredAt:    m.ExpiredAt,
+		MaxDownloads: m.MaxDownloads,
+		Password:     m.Password,
+	}
+}
+
+type HTTPListShareLinksRequest struct {
+	ObjectID string `param:"id"`
+}
+
+type HTTPRevokeShareLinkRequest struct {
+	ObjectID    string `param:"id"`
+	ShareLinkID string `param:"shareID"`
+}
+
+// HTTPResolveShareLinkRequest never reads the password from the query,
+// it comes from a header or a POST body so it stays out of urls and access logs.
+type HTTPResolveShareLinkRequest struct {
+	Token    string `param:"token"`
+	Password string `json:"password" form:"password"`
+}
+
+type HTTPShareLinkResponse struct {
+	ID            string `json:"id"`
+	ObjectID      string `json:"objectID"`
+	Token         string `json:"token,omitempty"`
+	Path          string `json:"path,omitempty"`
+	HasPassword   bool   `json:"hasPassword"`
+	MaxDownloads  int    `json:"maxDownloads"`
+	DownloadCount int    `json:"downloadCount"`
+	CreatedBy     string `json:"createdBy"`
+	ExpiredAt     string `json:"expiredAt"`
+	RevokedAt     string `json:"revokedAt,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+func (m *ShareLink) ToHTTPResponse() *HTTPShareLinkResponse {
+	res := &HTTPShareLinkResponse{
+		ID:            m.ID,
+		ObjectID:      m.ObjectID,
+		HasPassword:   m.HasPassword(),
+		MaxDownloads:  m.MaxDownloads,
+		DownloadCount: m.DownloadCount,
+		CreatedBy:     m.CreatedBy,
+		ExpiredAt:     m.ExpiredAt.UTC().Format(time.RFC3339Nano),
+		CreatedAt:     m.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if m.RevokedAt != nil {
+		res.RevokedAt = m.RevokedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return res
+}
+
+func (m *CreatedShareLink) ToHTTPResponse() *HTTPShareLinkResponse {
+	res := m.ShareLink.ToHTTPResponse()
+	res.Token = m.Token
+	res.Path = "/s/" + m.Token
+	return res
+}
+
+type ShareLinkRepository interface {
+	Create(ctx context.Context, shareLink *ShareLink) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*ShareLink, error)
+	FindByObjectID(ctx context.Context, objectID string) ([]*ShareLink, error)
+	// CountDownload records a use, it returns ErrShareLinkUnavailable when none is left.
+	CountDownload(ctx context.Context, id string) error
+	// CountFailedAttempt records a wrong password, the link is locked for lockout
+	// once maxAttempts is reached.
+	CountFailedAttempt(ctx context.Context, id string, maxAttempts int, lockout time.Duration) error
+	Revoke(ctx context.Context, objectID string, id string) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
@@ -0,0 +1,169 @@
+//go:generate mockgen -destination=mock/mock_storage_usage_repository.go -package=mock github.com/krobus00/storage-service/internal/model StorageUsageRepository
+//go:generate mockgen -destination=mock/mock_storage_usage_usecase.go -package=mock github.com/krobus00/storage-service/internal/model StorageUsageUsecase
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"gorm.io/gorm"
+)
+
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageUsage counts the stored bytes and objects of a user for one object type,
+// it is adjusted in the transaction creating or deleting an object.
+type StorageUsage struct {
+	TenantID  string
+	UserID    string
+	TypeID    string
+	Type      string `gorm:"-"`
+	Bytes     int64
+	Objects   int64
+	UpdatedAt time.Time
+}
+
+func (StorageUsage) TableName() string {
+	return "storage_usages"
+}
+
+// StorageQuota limits a user, a zero limit means unlimited.
+type StorageQuota struct {
+	MaxBytes   int64
+	MaxObjects int64
+}
+
+// Allows reports whether one more object of size bytes fits next to the usage.
+func (m StorageQuota) Allows(usage *UserStorageUsage, size int64) bool {
+	return m.Fits(usage.Bytes, usage.Objects, size)
+}
+
+// Fits reports whether one more object of size bytes fits next to the stored bytes and objects.
+func (m StorageQuota) Fits(bytes int64, objects int64, size int64) bool {
+	if m.MaxBytes > 0 && bytes+size > m.MaxBytes {
+		return false
+	}
+	if m.MaxObjects > 0 && objects+1 > m.MaxObjects {
+		return false
+	}
+	return true
+}
+
+func (m StorageQuota) IsUnlimited() bool {
+	return m.MaxBytes <= 0 && m.MaxObjects <= 0
+}
+
+// UploadQuota are the limits enforced in the transaction storing an upload,
+// User applies to the uploader and Tenant to the sum of every user of the tenant.
+type UploadQuota struct {
+	User   StorageQuota
+	Tenant StorageQuota
+}
+
+// UserStorageUsage is the usage of a user summed over its object types.
+type UserStorageUsage struct {
+	UserID  string
+	Bytes   int64
+	Objects int64
+	Quota   StorageQuota
+	Types   []*StorageUsage
+}
+
+func NewUserStorageUsage(userID string, quota StorageQuota, usages []*StorageUsage) *UserStorageUsage {
+	res := &UserStorageUsage{
+		UserID: userID,
+		Quota:  quota,
+		Types:  usages,
+	}
+	for _, usage := range usages {
+		res.Bytes += usage.Bytes
+		res.Objects += usage.Objects
+	}
+	return res
+}
+
+type HTTPGetUsageRequest struct {
+	// UserID defaults to the caller.
+	UserID string `query:"userID"`
+}
+
+type HTTPStorageUsageResponse struct {
+	TypeID  string `json:"typeID"`
+	Type    string `json:"type"`
+	Bytes   int64  `json:"bytes"`
+	Objects int64  `json:"objects"`
+}
+
+type HTTPUserStorageUsageResponse struct {
+	UserID     string                      `json:"userID"`
+	Bytes      int64                       `json:"bytes"`
+	Objects    int64                       `json:"objects"`
+	MaxBytes   int64                       `json:"maxBytes"`
+	MaxObjects int64                       `json:"maxObjects"`
+	Types      []*HTTPStorageUsageResponse `json:"types"`
+}
+
+func (m *UserStorageUsage) ToHTTPResponse() *HTTPUserStorageUsageResponse {
+	res := &HTTPUserStorageUsageResponse{
+		UserID:     m.UserID,
+		Bytes:      m.Bytes,
+		Objects:    m.Objects,
+		MaxBytes:   m.Quota.MaxBytes,
+		MaxObjects: m.Quota.MaxObjects,
+		Types:      make([]*HTTPStorageUsageResponse, 0, len(m.Types)),
+	}
+	for _, usage := range m.Types {
+		res.Types = append(res.Types, &HTTPStorageUsageResponse{
+			TypeID:  usage.TypeID,
+			Type:    usage.Type,
+			Bytes:   usage.Bytes,
+			Objects: usage.Objects,
+		})
+	}
+	return res
+}
+
+func (m *UserStorageUsage) ToGRPCResponse() *pb.StorageUsage {
+	res := &pb.StorageUsage{
+		UserId:     m.UserID,
+		Bytes:      m.Bytes,
+		Objects:    m.Objects,
+		MaxBytes:   m.Quota.MaxBytes,
+		MaxObjects: m.Quota.MaxObjects,
+		Types:      make([]*pb.TypeStorageUsage, 0, len(m.Types)),
+	}
+	for _, usage := range m.Types {
+		res.Types = append(res.Types, &pb.TypeStorageUsage{
+			TypeId:  usage.TypeID,
+			Type:    usage.Type,
+			Bytes:   usage.Bytes,
+			Objects: usage.Objects,
+		})
+	}
+	return res
+}
+
+type StorageUsageRepository interface {
+	FindByUserID(ctx context.Context, userID string) ([]*StorageUsage, error)
+	// Recompute rebuilds the usage from the objects table, the user is taken in the
+	// tenant of ctx and every user of every tenant is rebuilt when userID is empty.
+	Recompute(ctx context.Context, userID string) error
+
+	// DI
+	InjectDB(db *gorm.DB) error
+}
+
+type StorageUsageUsecase interface {
+	GetUsage(ctx context.Context, userID string) (*UserStorageUsage, error)
+	// CheckQuota returns ErrQuotaExceeded when an upload of size bytes does not fit.
+	CheckQuota(ctx context.Context, userID string, size int64) error
+
+	// DI
+	InjectStorageUsageRepo(repo StorageUsageRepository) error
+	InjectObjectTypeRepo(repo ObjectTypeRepository) error
+	InjectAuthClient(client authPB.AuthServiceClient) error
+}
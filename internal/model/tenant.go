@@ -0,0 +1,16 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+)
+
+var ErrInvalidTenant = errors.New("invalid tenant")
+
+// tenantIDPattern keeps tenant ids safe to embed in cache keys, config paths and s3 keys.
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// IsValidTenantID reports whether id can name a tenant, an empty id selects the default tenant.
+func IsValidTenantID(id string) bool {
+	return id == "" || tenantIDPattern.MatchString(id)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository() model.AuditLogRepository {
+	return new(auditLogRepository)
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"actorID":  log.ActorID,
+		"objectID": log.ObjectID,
+		"action":   log.Action,
+		"outcome":  log.Outcome,
+	})
+
+	// not bound to the caller transaction, a rolled back mutation is still audited
+	err := r.db.WithContext(ctx).Create(log).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) FindAll(ctx context.Context, filter *model.AuditLogFilter) ([]*model.AuditLog, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": filter.ObjectID,
+		"actorID":  filter.ActorID,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	})
+
+	logs := make([]*model.AuditLog, 0)
+	db := utils.GetTxFromContext(ctx, r.db).WithContext(ctx).
+		Where("tenant_id = ?", utils.GetTenantIDFromContext(ctx))
+
+	if filter.ObjectID != "" {
+		db = db.Where("object_id = ?", filter.ObjectID)
+	}
+	if filter.ActorID != "" {
+		db = db.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.From != nil {
+		db = db.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("created_at < ?", *filter.To)
+	}
+
+	err := db.
+		Order("created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&logs).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func newAuditLogRepoMock() (model.AuditLogRepository, sqlmock.Sqlmock) {
+	dbConn, dbMock := utils.NewDBMock()
+	auditLogRepo := NewAuditLogRepository()
+	err := auditLogRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+
+	return auditLogRepo, dbMock
+}
+
+func Test_auditLogRepository_Create(t *testing.T) {
+	auditLog := &model.AuditLog{
+		ID:        utils.GenerateUUID(),
+		TenantID:  constant.DefaultTenantID,
+		ActorID:   utils.GenerateUUID(),
+		ObjectID:  utils.GenerateUUID(),
+		Action:    model.AuditActionDelete,
+		Outcome:   model.AuditOutcomeSuccess,
+		ClientIP:  "127.0.0.1",
+		UserAgent: "curl/8.0",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		CreatedAt: time.Now(),
+	}
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "error create",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newAuditLogRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO \"audit_logs\"").
+				WithArgs(
+					auditLog.ID,
+					auditLog.TenantID,
+					auditLog.ActorID,
+					auditLog.ObjectID,
+					auditLog.Action,
+					auditLog.Outcome,
+					auditLog.ClientIP,
+					auditLog.UserAgent,
+					auditLog.TraceID,
+					auditLog.CreatedAt,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tt.mockErr)
+
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Create(ctx, auditLog); (err != nil) != tt.wantErr {
+				t.Errorf("auditLogRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_auditLogRepository_FindAll(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		actorID  = utils.GenerateUUID()
+		from     = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+		to       = time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC)
+	)
+	auditLog := &model.AuditLog{
+		ID:        utils.GenerateUUID(),
+		TenantID:  constant.DefaultTenantID,
+		ActorID:   actorID,
+		ObjectID:  objectID,
+		Action:    model.AuditActionPresign,
+		Outcome:   model.AuditOutcomeDenied,
+		CreatedAt: from.Add(time.Hour),
+	}
+	type mockSelect struct {
+		query     string
+		args      []driver.Value
+		auditLogs []*model.AuditLog
+		err       error
+	}
+	tests := []struct {
+		name       string
+		filter     *model.AuditLogFilter
+		mockSelect *mockSelect
+		want       []*model.AuditLog
+		wantErr    bool
+	}{
+		{
+			name: "success filter by object, actor and time range",
+			filter: &model.AuditLogFilter{
+				ObjectID: objectID,
+				ActorID:  actorID,
+				From:     &from,
+				To:       &to,
+				Limit:    model.DefaultListAuditLogsLimit,
+			},
+			mockSelect: &mockSelect{
+				query:     "^SELECT .+ FROM \"audit_logs\" WHERE tenant_id = .+ AND object_id = .+ AND actor_id = .+ AND created_at >= .+ AND created_at < .+ ORDER BY created_at DESC LIMIT 50",
+				args:      []driver.Value{constant.DefaultTenantID, objectID, actorID, from, to},
+				auditLogs: []*model.AuditLog{auditLog},
+			},
+			want:    []*model.AuditLog{auditLog},
+			wantErr: false,
+		},
+		{
+			name: "success without filter",
+			filter: &model.AuditLogFilter{
+				Limit: model.DefaultListAuditLogsLimit,
+			},
+			mockSelect: &mockSelect{
+				query:     "^SELECT .+ FROM \"audit_logs\" WHERE tenant_id = .+ ORDER BY created_at DESC LIMIT 50",
+				args:      []driver.Value{constant.DefaultTenantID},
+				auditLogs: []*model.AuditLog{},
+			},
+			want:    []*model.AuditLog{},
+			wantErr: false,
+		},
+		{
+			name: "error find audit logs",
+			filter: &model.AuditLogFilter{
+				ObjectID: objectID,
+				Limit:    model.DefaultListAuditLogsLimit,
+			},
+			mockSelect: &mockSelect{
+				query: "^SELECT .+ FROM \"audit_logs\" WHERE tenant_id = .+ AND object_id = ",
+				args:  []driver.Value{constant.DefaultTenantID, objectID},
+				err:   errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newAuditLogRepoMock()
+
+			row := sqlmock.NewRows([]string{"id", "tenant_id", "actor_id", "object_id", "action", "outcome", "client_ip", "user_agent", "trace_id", "created_at"})
+			for _, auditLog := range tt.mockSelect.auditLogs {
+				row.AddRow(
+					auditLog.ID,
+					auditLog.TenantID,
+					auditLog.ActorID,
+					auditLog.ObjectID,
+					auditLog.Action,
+					auditLog.Outcome,
+					auditLog.ClientIP,
+					auditLog.UserAgent,
+					auditLog.TraceID,
+					auditLog.CreatedAt,
+				)
+			}
+
+			dbMock.ExpectQuery(tt.mockSelect.query).
+				WithArgs(tt.mockSelect.args...).
+				WillReturnRows(row).
+				WillReturnError(tt.mockSelect.err)
+
+			got, err := r.FindAll(ctx, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auditLogRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditLogRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
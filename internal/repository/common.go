@@ -2,68 +2,114 @@ package repository
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/sirupsen/logrus"
-
-	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
 )
 
-func HSetWithExpiry(ctx context.Context, redisClient *redis.Client, bucketCacheKey string, field string, data any) error {
+func HSetWithExpiry(ctx context.Context, cache model.Cache, bucketCacheKey string, field string, data any, tags ...string) error {
 	cacheData, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	err = redisClient.HSet(ctx, bucketCacheKey, field, cacheData).Err()
+	return cache.HSet(ctx, bucketCacheKey, field, cacheData, config.RedisCacheTTL(), tags...)
+}
+
+func SetWithExpiry(ctx context.Context, cache model.Cache, cacheKey string, data any, tags ...string) error {
+	return SetWithTTL(ctx, cache, cacheKey, data, config.RedisCacheTTL(), tags...)
+}
+
+// SetWithTTL is SetWithExpiry for entries whose lifetime is bound to the data itself.
+func SetWithTTL(ctx context.Context, cache model.Cache, cacheKey string, data any, ttl time.Duration, tags ...string) error {
+	cacheData, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	err = redisClient.ExpireNX(ctx, bucketCacheKey, config.RedisCacheTTL()).Err()
+	return cache.Set(ctx, cacheKey, cacheData, ttl, tags...)
+}
+
+func Get(ctx context.Context, cache model.Cache, repository string, cacheKey string) ([]byte, error) {
+	cachedData, err := cache.Get(ctx, cacheKey)
 	if err != nil {
-		return err
+		logrus.WithField("cacheKey", cacheKey).Error(err.Error())
+		return nil, err
 	}
-	return nil
+	metrics.ObserveRepositoryCacheLookup(repository, cachedData != nil)
+	return cachedData, nil
 }
 
-func SetWithExpiry(ctx context.Context, redisClient *redis.Client, cacheKey string, data any) error {
-	cacheData, err := json.Marshal(data)
+func DeleteByKeys(ctx context.Context, cache model.Cache, cacheKeys []string) error {
+	err := cache.Delete(ctx, cacheKeys...)
 	if err != nil {
+		logrus.WithField("cacheKeys", cacheKeys).Error(err.Error())
 		return err
 	}
+	return nil
+}
 
-	err = redisClient.Set(ctx, cacheKey, cacheData, config.RedisCacheTTL()).Err()
+// InvalidateTags deletes every cache key recorded under the given tags.
+func InvalidateTags(ctx context.Context, cache model.Cache, tags []string) error {
+	err := cache.InvalidateTags(ctx, tags...)
 	if err != nil {
+		logrus.WithField("cacheTags", tags).Error(err.Error())
 		return err
 	}
 	return nil
 }
 
-func Get(ctx context.Context, redisClient *redis.Client, cacheKey string) ([]byte, error) {
-	cachedData, err := redisClient.Get(ctx, cacheKey).Bytes()
-	if err != nil && !errors.Is(err, redis.Nil) {
-		logrus.WithField("cacheKey", cacheKey).Error(err.Error())
+func HGet(ctx context.Context, cache model.Cache, repository string, bucketCacheKey string, field string) ([]byte, error) {
+	cachedData, err := cache.HGet(ctx, bucketCacheKey, field)
+	if err != nil {
 		return nil, err
 	}
+	metrics.ObserveRepositoryCacheLookup(repository, cachedData != nil)
 	return cachedData, nil
 }
 
-func DeleteByKeys(ctx context.Context, redisClient *redis.Client, cacheKeys []string) error {
-	for _, cacheKey := range cacheKeys {
-		err := redisClient.Del(ctx, cacheKey).Err()
-		if err != nil && !errors.Is(err, redis.Nil) {
-			logrus.WithField("cacheKey", cacheKey).Error(err.Error())
-			return err
-		}
+// sharedLoadTimeout bounds a load shared by concurrent cache misses.
+const sharedLoadTimeout = 10 * time.Second
+
+// loadOnce collapses concurrent cache misses on the same key into a single load,
+// so a hot entry expiring does not stampede the database. The shared load runs
+// detached from the caller that started it, so cancelling one request does not
+// fail the others, and callers inside a transaction load on their own. Callers
+// sharing a load get their own copy since the result is usually mutated afterwards.
+func loadOnce[T any](ctx context.Context, group *singleflight.Group, cacheKey string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if utils.HasTxInContext(ctx) {
+		return load(ctx)
 	}
-	return nil
+
+	resCh := group.DoChan(cacheKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(utils.NewDetachedContext(ctx), sharedLoadTimeout)
+		defer cancel()
+		return load(loadCtx)
+	})
+
+	var res singleflight.Result
+	select {
+	case res = <-resCh:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	data, _ := res.Val.(*T)
+	if !res.Shared || data == nil {
+		return data, res.Err
+	}
+	return cloneLoaded(data), res.Err
 }
 
-func HGet(ctx context.Context, redisClient *redis.Client, bucketCacheKey string, field string) ([]byte, error) {
-	cachedData, err := redisClient.HGet(ctx, bucketCacheKey, field).Bytes()
-	if err != nil {
-		return nil, err
+// cloneLoaded deep copies types that know how to, other types are copied shallowly.
+func cloneLoaded[T any](data *T) *T {
+	if cloner, ok := any(data).(interface{ Clone() *T }); ok {
+		return cloner.Clone()
 	}
-	return cachedData, nil
+	clone := *data
+	return &clone
 }
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/krobus00/storage-service/internal/infrastructure"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/singleflight"
+)
+
+func TestInvalidateTags(t *testing.T) {
+	const (
+		tagA = "tags:a"
+		tagB = "tags:b"
+	)
+	type cacheEntry struct {
+		key  string
+		tags []string
+	}
+	tests := []struct {
+		name        string
+		entries     []cacheEntry
+		invalidate  []string
+		wantDropped []string
+		wantKept    []string
+	}{
+		{
+			name: "drop every key of the tag",
+			entries: []cacheEntry{
+				{key: "key:1", tags: []string{tagA}},
+				{key: "key:2", tags: []string{tagA}},
+				{key: "key:3", tags: []string{tagB}},
+				{key: "key:4"},
+			},
+			invalidate:  []string{tagA},
+			wantDropped: []string{"key:1", "key:2", tagA},
+			wantKept:    []string{"key:3", "key:4", tagB},
+		},
+		{
+			name: "key with many tags dropped by any of them",
+			entries: []cacheEntry{
+				{key: "key:1", tags: []string{tagA, tagB}},
+				{key: "key:2", tags: []string{tagB}},
+			},
+			invalidate:  []string{tagA},
+			wantDropped: []string{"key:1", tagA},
+			wantKept:    []string{"key:2", tagB},
+		},
+		{
+			name: "unknown tag",
+			entries: []cacheEntry{
+				{key: "key:1", tags: []string{tagA}},
+			},
+			invalidate:  []string{tagB},
+			wantDropped: []string{},
+			wantKept:    []string{"key:1", tagA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			redisMock := miniredis.RunT(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			for _, entry := range tt.entries {
+				utils.ContinueOrFatal(SetWithExpiry(ctx, cache, entry.key, "data", entry.tags...))
+			}
+
+			if err := InvalidateTags(ctx, cache, tt.invalidate); err != nil {
+				t.Errorf("InvalidateTags() error = %v", err)
+			}
+
+			for _, key := range tt.wantDropped {
+				if redisMock.Exists(key) {
+					t.Errorf("InvalidateTags() key %s still exists", key)
+				}
+			}
+			for _, key := range tt.wantKept {
+				if !redisMock.Exists(key) {
+					t.Errorf("InvalidateTags() key %s was dropped", key)
+				}
+			}
+		})
+	}
+}
+
+func TestHSetWithExpiry_tagged(t *testing.T) {
+	ctx := context.TODO()
+	redisMock := miniredis.RunT(t)
+	cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+	utils.ContinueOrFatal(HSetWithExpiry(ctx, cache, "bucket:1", "field", "data", "tags:a"))
+	utils.ContinueOrFatal(HSetWithExpiry(ctx, cache, "bucket:2", "field", "data", "tags:a"))
+
+	if ttl := redisMock.TTL("tags:a"); ttl <= 0 {
+		t.Errorf("HSetWithExpiry() tag ttl = %v, want positive", ttl)
+	}
+
+	utils.ContinueOrFatal(InvalidateTags(ctx, cache, []string{"tags:a"}))
+
+	for _, key := range []string{"bucket:1", "bucket:2", "tags:a"} {
+		if redisMock.Exists(key) {
+			t.Errorf("InvalidateTags() key %s still exists", key)
+		}
+	}
+}
+
+func TestLoadOnce(t *testing.T) {
+	const cacheKey = "objects:1"
+
+	t.Run("leader cancellation does not fail the waiters", func(t *testing.T) {
+		group := new(singleflight.Group)
+		started := make(chan struct{})
+		startOnce := sync.Once{}
+		release := make(chan struct{})
+		load := func(ctx context.Context) (*model.Object, error) {
+			startOnce.Do(func() { close(started) })
+			<-release
+			return &model.Object{ID: "1", Metadata: model.ObjectMetadata{"k": "v"}}, ctx.Err()
+		}
+
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := loadOnce(leaderCtx, group, cacheKey, load)
+			leaderErr <- err
+		}()
+		<-started
+
+		waiter := make(chan *model.Object, 1)
+		go func() {
+			object, err := loadOnce(context.Background(), group, cacheKey, load)
+			assert.NoError(t, err)
+			waiter <- object
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+		close(release)
+
+		object := <-waiter
+		assert.Equal(t, "1", object.ID)
+	})
+
+	t.Run("shared results do not share metadata", func(t *testing.T) {
+		group := new(singleflight.Group)
+		shared := &model.Object{ID: "1", Metadata: model.ObjectMetadata{"k": "v"}}
+		release := make(chan struct{})
+		load := func(ctx context.Context) (*model.Object, error) {
+			<-release
+			return shared, nil
+		}
+
+		results := make(chan *model.Object, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				object, _ := loadOnce(context.Background(), group, cacheKey, load)
+				results <- object
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+
+		first, second := <-results, <-results
+		first.Metadata["k"] = "changed"
+		assert.Equal(t, "v", second.Metadata["k"])
+		assert.Equal(t, "v", shared.Metadata["k"])
+	})
+
+	t.Run("transactions load on their own", func(t *testing.T) {
+		group := new(singleflight.Group)
+		dbConn, _ := utils.NewDBMock()
+		txCtx := utils.NewTxContext(context.Background(), dbConn)
+
+		release := make(chan struct{})
+		go func() {
+			_, _ = loadOnce(context.Background(), group, cacheKey, func(ctx context.Context) (*model.Object, error) {
+				<-release
+				return &model.Object{ID: "outside"}, nil
+			})
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		object, err := loadOnce(txCtx, group, cacheKey, func(ctx context.Context) (*model.Object, error) {
+			return &model.Object{ID: "inside"}, nil
+		})
+		close(release)
+		assert.NoError(t, err)
+		assert.Equal(t, "inside", object.ID)
+	})
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type objectGrantRepository struct {
+	db        *gorm.DB
+	cache     model.Cache
+	loadGroup singleflight.Group
+}
+
+func NewObjectGrantRepository() model.ObjectGrantRepository {
+	return new(objectGrantRepository)
+}
+
+// Create grants access, granting again to the same grantee only moves the expiry.
+func (r *objectGrantRepository) Create(ctx context.Context, grant *model.ObjectGrant) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":    grant.ObjectID,
+		"granteeType": grant.GranteeType,
+		"granteeID":   grant.GranteeID,
+		"permission":  grant.Permission,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	err := db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "object_id"},
+				{Name: "grantee_type"},
+				{Name: "grantee_id"},
+				{Name: "permission"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"granted_by", "expired_at"}),
+		},
+		clause.Returning{},
+	).Create(grant).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	_ = DeleteByKeys(ctx, r.cache, []string{model.NewObjectGrantCacheKey(utils.GetTenantIDFromContext(ctx), grant.ObjectID)})
+
+	return nil
+}
+
+func (r *objectGrantRepository) FindByObjectID(ctx context.Context, objectID string) ([]*model.ObjectGrant, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
+	grants := make([]*model.ObjectGrant, 0)
+	cacheKey := model.NewObjectGrantCacheKey(tenantID, objectID)
+
+	cachedData, err := Get(ctx, r.cache, metrics.RepositoryObjectGrant, cacheKey)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	err = json.Unmarshal(cachedData, &grants)
+	if err == nil {
+		return grants, nil
+	}
+
+	res, err := loadOnce(ctx, &r.loadGroup, cacheKey, func(ctx context.Context) (*[]*model.ObjectGrant, error) {
+		grants := make([]*model.ObjectGrant, 0)
+
+		err := db.WithContext(ctx).
+			Where("object_id = ?", objectID).
+			Order("created_at ASC").
+			Find(&grants).Error
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+
+		// tagged with the object so deleting it drops the grants too
+		err = SetWithExpiry(ctx, r.cache, cacheKey, grants, model.NewObjectCacheTag(tenantID, objectID))
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		return &grants, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+func (r *objectGrantRepository) DeleteByID(ctx context.Context, objectID string, id string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+		"id":       id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	res := db.WithContext(ctx).
+		Where("object_id = ? AND id = ?", objectID, id).
+		Delete(new(model.ObjectGrant))
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrObjectGrantNotFound
+	}
+
+	_ = DeleteByKeys(ctx, r.cache, []string{model.NewObjectGrantCacheKey(utils.GetTenantIDFromContext(ctx), objectID)})
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"gorm.io/gorm"
+)
+
+func (r *objectGrantRepository) InjectDB(db *gorm.DB) error {
+	if db == nil {
+		return errors.New("invalid db")
+	}
+	r.db = db
+	return nil
+}
+
+func (r *objectGrantRepository) InjectCache(cache model.Cache) error {
+	if cache == nil {
+		return errors.New("invalid cache")
+	}
+	r.cache = cache
+	return nil
+}
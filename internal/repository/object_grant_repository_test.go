@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/infrastructure"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func newObjectGrantRepoMock(t *testing.T) (model.ObjectGrantRepository, sqlmock.Sqlmock, *miniredis.Miniredis) {
+	dbConn, dbMock := utils.NewDBMock()
+	miniRedis := miniredis.RunT(t)
+	viper.Set("redis.cache_host", fmt.Sprintf("redis://%s", miniRedis.Addr()))
+	redisClient, err := infrastructure.NewRedisClient()
+	utils.ContinueOrFatal(err)
+	objectGrantRepo := NewObjectGrantRepository()
+	err = objectGrantRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+	err = objectGrantRepo.InjectCache(infrastructure.NewRedisCache(redisClient))
+	utils.ContinueOrFatal(err)
+
+	return objectGrantRepo, dbMock, miniRedis
+}
+
+func Test_objectGrantRepository_Create(t *testing.T) {
+	var (
+		grantID  = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		userID   = utils.GenerateUUID()
+	)
+	type args struct {
+		grant *model.ObjectGrant
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				grant: &model.ObjectGrant{
+					ID:          grantID,
+					ObjectID:    objectID,
+					GranteeType: model.GranteeTypeUser,
+					GranteeID:   userID,
+					Permission:  model.GrantPermissionRead,
+					GrantedBy:   "owner",
+				},
+			},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name: "error create",
+			args: args{
+				grant: &model.ObjectGrant{
+					ID:          grantID,
+					ObjectID:    objectID,
+					GranteeType: model.GranteeTypeUser,
+					GranteeID:   userID,
+					Permission:  model.GrantPermissionRead,
+					GrantedBy:   "owner",
+				},
+			},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			r, dbMock, miniRedis := newObjectGrantRepoMock(t)
+
+			cacheKey := model.NewObjectGrantCacheKey(constant.DefaultTenantID, tt.args.grant.ObjectID)
+			err := miniRedis.Set(cacheKey, "[]")
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO \"object_grants\" .+ ON CONFLICT").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tt.args.grant.ID)).
+				WillReturnError(tt.mockErr)
+
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Create(ctx, tt.args.grant); (err != nil) != tt.wantErr {
+				t.Errorf("objectGrantRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && miniRedis.Exists(cacheKey) {
+				t.Errorf("objectGrantRepository.Create() cache key %s was not invalidated", cacheKey)
+			}
+		})
+	}
+}
+
+func Test_objectGrantRepository_FindByObjectID(t *testing.T) {
+	var (
+		grantID   = utils.GenerateUUID()
+		objectID  = utils.GenerateUUID()
+		userID    = utils.GenerateUUID()
+		createdAt = time.Now().UTC().Truncate(time.Second)
+	)
+	grant := &model.ObjectGrant{
+		ID:          grantID,
+		ObjectID:    objectID,
+		GranteeType: model.GranteeTypeUser,
+		GranteeID:   userID,
+		Permission:  model.GrantPermissionRead,
+		GrantedBy:   "owner",
+		CreatedAt:   createdAt,
+	}
+	type mockSelect struct {
+		grants []*model.ObjectGrant
+		err    error
+	}
+	type mockCache struct {
+		grants []*model.ObjectGrant
+	}
+	tests := []struct {
+		name       string
+		mockSelect *mockSelect
+		mockCache  *mockCache
+		want       []*model.ObjectGrant
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			mockSelect: &mockSelect{
+				grants: []*model.ObjectGrant{grant},
+			},
+			want:    []*model.ObjectGrant{grant},
+			wantErr: false,
+		},
+		{
+			name: "success from cache",
+			mockCache: &mockCache{
+				grants: []*model.ObjectGrant{grant},
+			},
+			want:    []*model.ObjectGrant{grant},
+			wantErr: false,
+		},
+		{
+			name: "success empty",
+			mockSelect: &mockSelect{
+				grants: []*model.ObjectGrant{},
+			},
+			want:    []*model.ObjectGrant{},
+			wantErr: false,
+		},
+		{
+			name: "error find grants",
+			mockSelect: &mockSelect{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, miniRedis := newObjectGrantRepoMock(t)
+
+			if tt.mockCache != nil {
+				cachedData, err := json.Marshal(tt.mockCache.grants)
+				utils.ContinueOrFatal(err)
+				err = miniRedis.Set(model.NewObjectGrantCacheKey(constant.DefaultTenantID, objectID), string(cachedData))
+				utils.ContinueOrFatal(err)
+			}
+
+			if tt.mockSelect != nil {
+				row := sqlmock.NewRows([]string{"id", "object_id", "grantee_type", "grantee_id", "permission", "granted_by", "expired_at", "created_at"})
+				for _, grant := range tt.mockSelect.grants {
+					row.AddRow(grant.ID, grant.ObjectID, grant.GranteeType, grant.GranteeID, grant.Permission, grant.GrantedBy, grant.ExpiredAt, grant.CreatedAt)
+				}
+
+				dbMock.ExpectQuery("^SELECT .+ FROM \"object_grants\" WHERE object_id = .+ ORDER BY created_at ASC").
+					WithArgs(objectID).
+					WillReturnRows(row).
+					WillReturnError(tt.mockSelect.err)
+			}
+
+			got, err := r.FindByObjectID(ctx, objectID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectGrantRepository.FindByObjectID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectGrantRepository.FindByObjectID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_objectGrantRepository_DeleteByID(t *testing.T) {
+	var (
+		grantID  = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+	)
+	type mockDelete struct {
+		rowsAffected int64
+		err          error
+	}
+	tests := []struct {
+		name       string
+		mockDelete *mockDelete
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mockDelete: &mockDelete{
+				rowsAffected: 1,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error grant not found",
+			mockDelete: &mockDelete{
+				rowsAffected: 0,
+			},
+			wantErr: model.ErrObjectGrantNotFound,
+		},
+		{
+			name: "error delete grant",
+			mockDelete: &mockDelete{
+				err: errors.New("db error"),
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, miniRedis := newObjectGrantRepoMock(t)
+
+			cacheKey := model.NewObjectGrantCacheKey(constant.DefaultTenantID, objectID)
+			err := miniRedis.Set(cacheKey, "[]")
+			utils.ContinueOrFatal(err)
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("DELETE FROM \"object_grants\" WHERE").
+				WithArgs(objectID, grantID).
+				WillReturnResult(sqlmock.NewResult(0, tt.mockDelete.rowsAffected)).
+				WillReturnError(tt.mockDelete.err)
+			if tt.mockDelete.err != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err = r.DeleteByID(ctx, objectID, grantID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectGrantRepository.DeleteByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && miniRedis.Exists(cacheKey) {
+				t.Errorf("objectGrantRepository.DeleteByID() cache key %s was not invalidated", cacheKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type objectReferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewObjectReferenceRepository() model.ObjectReferenceRepository {
+	return new(objectReferenceRepository)
+}
+
+// Create adds the reference, adding an existing one again is a no-op.
+func (r *objectReferenceRepository) Create(ctx context.Context, reference *model.ObjectReference) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":     reference.ObjectID,
+		"ownerService": reference.OwnerService,
+		"entityType":   reference.EntityType,
+		"entityID":     reference.EntityID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "object_id"},
+			{Name: "owner_service"},
+			{Name: "entity_type"},
+			{Name: "entity_id"},
+		},
+		DoNothing: true,
+	}).Create(reference).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *objectReferenceRepository) Delete(ctx context.Context, payload *model.ObjectReferencePayload) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":     payload.ObjectID,
+		"ownerService": payload.OwnerService,
+		"entityType":   payload.EntityType,
+		"entityID":     payload.EntityID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	res := db.WithContext(ctx).
+		Where("object_id = ? AND owner_service = ? AND entity_type = ? AND entity_id = ?",
+			payload.ObjectID, payload.OwnerService, payload.EntityType, payload.EntityID).
+		Delete(new(model.ObjectReference))
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrObjectReferenceNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func newObjectReferenceRepoMock() (model.ObjectReferenceRepository, sqlmock.Sqlmock) {
+	dbConn, dbMock := utils.NewDBMock()
+	objectReferenceRepo := NewObjectReferenceRepository()
+	err := objectReferenceRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+
+	return objectReferenceRepo, dbMock
+}
+
+func Test_objectReferenceRepository_Create(t *testing.T) {
+	var (
+		referenceID = utils.GenerateUUID()
+		objectID    = utils.GenerateUUID()
+		userID      = utils.GenerateUUID()
+	)
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "error create object reference",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newObjectReferenceRepoMock()
+
+			reference := &model.ObjectReference{
+				ID:           referenceID,
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+				EntityType:   "product",
+				EntityID:     "1",
+				CreatedBy:    userID,
+			}
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO \"object_references\" .+ ON CONFLICT \\(\"object_id\",\"owner_service\",\"entity_type\",\"entity_id\"\\) DO NOTHING").
+				WithArgs(referenceID, objectID, "product-service", "product", "1", userID, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Create(ctx, reference); (err != nil) != tt.wantErr {
+				t.Errorf("objectReferenceRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_objectReferenceRepository_Delete(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+	)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		mockErr      error
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "error object reference not found",
+			rowsAffected: 0,
+			wantErr:      model.ErrObjectReferenceNotFound,
+		},
+		{
+			name:    "error delete object reference",
+			mockErr: errors.New("db error"),
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newObjectReferenceRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("DELETE FROM \"object_references\" WHERE object_id = .+ AND owner_service = .+ AND entity_type = .+ AND entity_id = ").
+				WithArgs(objectID, "product-service", "product", "1").
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.mockErr)
+			if tt.mockErr != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err := r.Delete(ctx, &model.ObjectReferencePayload{
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+				EntityType:   "product",
+				EntityID:     "1",
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectReferenceRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
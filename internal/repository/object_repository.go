@@ -3,8 +3,9 @@ package repository
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
-	"fmt"
+	"io"
 	"mime"
 	"net/http"
 	"time"
@@ -12,27 +13,43 @@ import (
 	"github.com/goccy/go-json"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
-	"github.com/go-redis/redis/v8"
 	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// compensationTimeout bounds the cleanup of a failed upload.
+const compensationTimeout = 10 * time.Second
+
 type objectRepository struct {
-	s3          model.S3Client
-	db          *gorm.DB
-	redisClient *redis.Client
+	s3        model.S3Client
+	db        *gorm.DB
+	cache     model.Cache
+	keyring   model.Keyring
+	loadGroup singleflight.Group
 }
 
 func NewObjectRepository() model.ObjectRepository {
 	return new(objectRepository)
 }
 
+// bucketOf is where the object content lives, legacy objects stored before the bucket
+// was recorded fall back to the tenant bucket.
+func bucketOf(object *model.Object) string {
+	if object.Bucket != "" {
+		return object.Bucket
+	}
+	return config.TenantS3BucketName(object.TenantID)
+}
+
 func (r *objectRepository) uploadToS3(ctx context.Context, data *model.ObjectPayload) error {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -43,8 +60,6 @@ func (r *objectRepository) uploadToS3(ctx context.Context, data *model.ObjectPay
 		"key": data.Object.Key,
 	})
 
-	buf := bytes.NewBuffer(data.Src)
-
 	contentType := http.DetectContentType(data.Src)
 	exts, err := mime.ExtensionsByType(contentType)
 	if err != nil {
@@ -52,18 +67,61 @@ func (r *objectRepository) uploadToS3(ctx context.Context, data *model.ObjectPay
 		return err
 	}
 
-	data.Object.FileName = fmt.Sprintf("%s%s", data.Object.FileName, exts[0])
-	data.Object.Key = fmt.Sprintf("%s%s", data.Object.Key, exts[0])
+	data.Object.FileName = model.WithExtension(data.Object.FileName, exts)
+
+	objectType := data.ObjectType
+	if objectType == nil {
+		objectType = &model.ObjectType{Name: data.Object.Type}
+	}
+	keyVars := model.ObjectKeyVars{
+		Tenant: data.Object.TenantID,
+		Type:   objectType.Name,
+		User:   data.Object.UploadedBy,
+		ID:     data.Object.ID,
+		At:     time.Now().UTC(),
+	}
+	if len(exts) > 0 {
+		keyVars.Ext = exts[0]
+	}
+	data.Object.Key = config.TenantS3KeyPrefix(data.Object.TenantID) + model.RenderObjectKey(objectType.KeyTemplate, keyVars)
+	data.Object.Bucket = objectType.Bucket
+	if data.Object.Bucket == "" {
+		// persisted with the row, a later tenant bucket change must not move existing objects
+		data.Object.Bucket = config.TenantS3BucketName(data.Object.TenantID)
+	}
+
+	body := data.Src
+	checksum := model.SHA256HexToBase64(data.Object.ChecksumSHA256)
+	if objectType.Encrypted {
+		body, err = r.encrypt(data.Object, data.Src)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+		checksum = model.SHA256HexToBase64(model.SHA256Hex(body))
+	}
+	buf := bytes.NewBuffer(body)
 
-	bucketName := config.GetS3BucketName()
-	_, err = r.s3.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:        &bucketName,
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(bucketOf(data.Object)),
 		Key:           &data.Object.Key,
 		ACL:           types.ObjectCannedACLPrivate,
 		ContentLength: int64(buf.Len()),
 		Body:          buf,
 		ContentType:   aws.String(contentType),
-	})
+	}
+	if objectType.StorageClass != "" {
+		input.StorageClass = types.StorageClass(objectType.StorageClass)
+	}
+	if objectType.CacheControl != "" {
+		input.CacheControl = aws.String(objectType.CacheControl)
+	}
+	// s3 rejects the write when the received bytes do not match
+	if checksum != "" {
+		input.ChecksumSHA256 = aws.String(checksum)
+	}
+
+	_, err = r.s3.PutObject(ctx, input)
 
 	if err != nil {
 		logger.Error(err.Error())
@@ -73,6 +131,48 @@ func (r *objectRepository) uploadToS3(ctx context.Context, data *model.ObjectPay
 	return nil
 }
 
+// encrypt seals src with a new data key and stores the wrapped data key on the object.
+func (r *objectRepository) encrypt(object *model.Object, src []byte) ([]byte, error) {
+	if r.keyring == nil {
+		return nil, model.ErrEncryptionKeyNotFound
+	}
+	dataKey, err := utils.NewDataKey()
+	if err != nil {
+		return nil, err
+	}
+	keyID, wrapped, err := r.keyring.WrapKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := utils.SealAESGCM(dataKey, src)
+	if err != nil {
+		return nil, err
+	}
+	object.EncryptionKeyID = keyID
+	object.WrappedDataKey = base64.StdEncoding.EncodeToString(wrapped)
+	return sealed, nil
+}
+
+// decrypt opens content stored by encrypt.
+func (r *objectRepository) decrypt(object *model.Object, sealed []byte) ([]byte, error) {
+	if r.keyring == nil {
+		return nil, model.ErrEncryptionKeyNotFound
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(object.WrappedDataKey)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := r.keyring.UnwrapKey(object.EncryptionKeyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := utils.OpenAESGCM(dataKey, sealed)
+	if err != nil {
+		return nil, model.ErrContentTampered
+	}
+	return plaintext, nil
+}
+
 func (r *objectRepository) Create(ctx context.Context, data *model.ObjectPayload) error {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -84,23 +184,112 @@ func (r *objectRepository) Create(ctx context.Context, data *model.ObjectPayload
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	data.Object.TenantID = utils.GetTenantIDFromContext(ctx)
+
+	data.Object.IdempotencyKey = data.IdempotencyKey
 
 	err := r.uploadToS3(ctx, data)
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
-	err = db.WithContext(ctx).Create(data.Object).Error
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := enforceStorageQuota(tx, data.Object, data.Quota)
+		if err != nil {
+			return err
+		}
+		if data.Object.IdempotencyKey != "" {
+			// a concurrent upload with the key inserts nothing instead of failing the tx
+			tx = tx.Clauses(clause.OnConflict{
+				Columns:     []clause.Column{{Name: "tenant_id"}, {Name: "uploaded_by"}, {Name: "idempotency_key"}},
+				TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "idempotency_key <> ''"}}},
+				DoNothing:   true,
+			})
+		}
+		res := tx.Create(data.Object)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return model.ErrIdempotencyKeyTaken
+		}
+		return adjustStorageUsage(tx, data.Object, 1)
+	})
 	if err != nil {
 		logger.Error(err.Error())
+		r.compensateUpload(ctx, data.Object, err)
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectCacheKeys(data.Object.ID))
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectCacheKeys(data.Object.TenantID, data.Object.ID))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectCacheTag(data.Object.TenantID, data.Object.ID)})
 
 	return nil
 }
 
+// compensateUpload deletes the content of an object whose row was not written,
+// a failed delete is recorded for the gc to retry.
+func (r *objectRepository) compensateUpload(ctx context.Context, object *model.Object, cause error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	// the request may be gone already, the cleanup still has to run
+	ctx, cancel := context.WithTimeout(utils.NewDetachedContext(ctx), compensationTimeout)
+	defer cancel()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":     object.ID,
+		"bucket": bucketOf(object),
+		"key":    object.Key,
+	})
+
+	err := r.DeleteContent(ctx, object)
+	if err == nil {
+		return
+	}
+
+	// the tx of the caller, if any, is aborted, record outside of it
+	err = createPendingDeletion(r.db.WithContext(ctx), &model.PendingDeletion{
+		ID:        utils.GenerateUUID(),
+		Bucket:    bucketOf(object),
+		Key:       object.Key,
+		Reason:    cause.Error(),
+		LastError: err.Error(),
+	})
+	if err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func (r *objectRepository) FindByIdempotencyKey(ctx context.Context, userID string, idempotencyKey string) (*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID":         userID,
+		"idempotencyKey": idempotencyKey,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
+	object := new(model.Object)
+
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND uploaded_by = ? AND idempotency_key = ?", tenantID, userID, idempotencyKey).
+		Take(object).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return object, nil
+}
+
 func (r *objectRepository) FindByID(ctx context.Context, id string) (*model.Object, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -111,10 +300,11 @@ func (r *objectRepository) FindByID(ctx context.Context, id string) (*model.Obje
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	object := new(model.Object)
-	cacheKey := model.NewObjectCacheKey(id)
+	cacheKey := model.NewObjectCacheKey(tenantID, id)
 
-	cachedData, err := Get(ctx, r.redisClient, cacheKey)
+	cachedData, err := Get(ctx, r.cache, metrics.RepositoryObject, cacheKey)
 	if err != nil {
 		logger.Error(err.Error())
 	}
@@ -123,84 +313,369 @@ func (r *objectRepository) FindByID(ctx context.Context, id string) (*model.Obje
 		return object, nil
 	}
 
-	object = new(model.Object)
-
-	err = db.WithContext(ctx).First(object, "id = ?", id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = SetWithExpiry(ctx, r.redisClient, cacheKey, nil)
-			if err != nil {
-				logger.Error(err.Error())
+	return loadOnce(ctx, &r.loadGroup, cacheKey, func(ctx context.Context) (*model.Object, error) {
+		object := new(model.Object)
+
+		err := db.WithContext(ctx).First(object, "id = ? AND tenant_id = ?", id, tenantID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = SetWithExpiry(ctx, r.cache, cacheKey, nil, model.NewObjectCacheTag(tenantID, id))
+				if err != nil {
+					logger.Error(err.Error())
+				}
+				return nil, nil
 			}
-			return nil, nil
+			logger.Error(err.Error())
+			return nil, err
 		}
+
+		err = SetWithExpiry(ctx, r.cache, cacheKey, object, model.GetObjectCacheTags(tenantID, object.ID, object.TypeID)...)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		return object, nil
+	})
+}
+
+// accessibleObjectQuery matches objects owned by the user or granted for reading to the user or its groups.
+const accessibleObjectQuery = `objects.uploaded_by = @userID OR EXISTS (
+	SELECT 1 FROM object_grants
+	WHERE object_grants.object_id = objects.id
+	AND object_grants.permission = @permission
+	AND (object_grants.expired_at IS NULL OR object_grants.expired_at > @now)
+	AND (
+		(object_grants.grantee_type = @userGrantee AND object_grants.grantee_id = @userID)
+		OR (object_grants.grantee_type = @groupGrantee AND object_grants.grantee_id IN @groupIDs)
+	)
+)`
+
+func (r *objectRepository) FindAll(ctx context.Context, filter *model.ObjectFilter) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID": filter.UserID,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objects := make([]*model.Object, 0)
+
+	query := db.WithContext(ctx).
+		Where("tenant_id = ?", utils.GetTenantIDFromContext(ctx)).
+		Where(accessibleObjectQuery, map[string]any{
+			"userID":       filter.UserID,
+			"groupIDs":     filter.GroupIDs,
+			"permission":   model.GrantPermissionRead,
+			"now":          time.Now(),
+			"userGrantee":  model.GranteeTypeUser,
+			"groupGrantee": model.GranteeTypeGroup,
+		})
+	if len(filter.Metadata) > 0 {
+		// containment is served by the gin index on metadata
+		query = query.Where("metadata @> ?::jsonb", filter.Metadata)
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&objects).Error
+	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
 	}
 
-	err = SetWithExpiry(ctx, r.redisClient, cacheKey, object)
+	return objects, nil
+}
+
+func (r *objectRepository) FindByTypeID(ctx context.Context, typeID string, afterID string, limit int) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"typeID":  typeID,
+		"afterID": afterID,
+		"limit":   limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objects := make([]*model.Object, 0)
+
+	err := db.WithContext(ctx).
+		Where("tenant_id = ?", utils.GetTenantIDFromContext(ctx)).
+		Where("type_id = ?", typeID).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&objects).Error
 	if err != nil {
 		logger.Error(err.Error())
+		return nil, err
 	}
 
-	return object, nil
+	return objects, nil
 }
 
-func (r *objectRepository) GeneratePresignedURL(ctx context.Context, object *model.Object) (*model.GetPresignedURLResponse, error) {
+func (r *objectRepository) GeneratePresignedURL(ctx context.Context, object *model.Object, minValidity time.Duration) (*model.GetPresignedURLResponse, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
 	logger := logrus.WithFields(logrus.Fields{
-		"id":   object.ID,
-		"key":  object.Key,
-		"type": object.Type,
+		"id":          object.ID,
+		"key":         object.Key,
+		"type":        object.Type,
+		"minValidity": minValidity,
 	})
 
+	// s3 would hand out the ciphertext
+	if object.IsEncrypted() {
+		return nil, model.ErrObjectEncrypted
+	}
+
+	signDuration := config.GetS3SignDuration()
+	safetyMargin := config.GetS3PresignSafetyMargin()
+	if minValidity < 0 || minValidity+safetyMargin > signDuration {
+		return nil, model.ErrInvalidMinValidity
+	}
+
 	data := new(model.GetPresignedURLResponse)
-	cacheKey := model.NewObjectPresignedURLCacheKey(object.ID)
+	cacheKey := model.NewObjectPresignedURLCacheKey(object.TenantID, object.ID)
 
-	cachedData, err := Get(ctx, r.redisClient, cacheKey)
+	cachedData, err := Get(ctx, r.cache, metrics.RepositoryObject, cacheKey)
 	if err != nil {
 		logger.Error(err.Error())
 	}
 	err = json.Unmarshal(cachedData, &data)
-	if err == nil {
+	// cached urls are shared, only hand one out if it outlives what the caller needs
+	usable := err == nil && data != nil && data.ValidFor(minValidity+safetyMargin)
+	if usable && data.ValidFor(config.GetS3PresignRefreshWindow()) {
 		return data, nil
 	}
 
-	data = new(model.GetPresignedURLResponse)
+	signed, err := r.signPresignedURL(ctx, object, cacheKey)
+	if err != nil {
+		// a url inside the refresh window still serves this caller
+		if usable {
+			logger.Warn(err.Error())
+			return data, nil
+		}
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// signPresignedURL signs a fresh url and caches it until it is too close to expiry
+// to be handed out, hits inside the refresh window re-sign it ahead of that.
+func (r *objectRepository) signPresignedURL(ctx context.Context, object *model.Object, cacheKey string) (*model.GetPresignedURLResponse, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":  object.ID,
+		"key": object.Key,
+	})
 
-	expiration := time.Now().Add(config.GetS3SignDuration())
-	bucketName := config.GetS3BucketName()
+	data, err := r.SignPresignedURL(ctx, object, config.GetS3SignDuration())
+	if err != nil {
+		return nil, err
+	}
+
+	cacheTTL := time.Until(data.ExpiredAt) - config.GetS3PresignSafetyMargin()
+	if cacheTTL <= 0 {
+		return data, nil
+	}
+	err = SetWithTTL(ctx, r.cache, cacheKey, data, cacheTTL, model.GetObjectCacheTags(object.TenantID, object.ID, object.TypeID)...)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return data, nil
+}
+
+// SignPresignedURL signs a url valid for ttl and never caches it, for callers that
+// must not share a url with anyone else.
+func (r *objectRepository) SignPresignedURL(ctx context.Context, object *model.Object, ttl time.Duration) (*model.GetPresignedURLResponse, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	// s3 would hand out the ciphertext
+	if object.IsEncrypted() {
+		return nil, model.ErrObjectEncrypted
+	}
+
+	expiration := time.Now().Add(ttl)
+	bucketName := bucketOf(object)
 	getObjectArgs := s3.GetObjectInput{
 		Bucket:          &bucketName,
 		ResponseExpires: &expiration,
 		Key:             &object.Key,
 	}
+	// signed into the url, so the browser saves the object under its uploaded name
+	if disposition := mime.FormatMediaType("inline", map[string]string{"filename": object.DownloadFileName()}); disposition != "" {
+		getObjectArgs.ResponseContentDisposition = aws.String(disposition)
+	}
 
-	res, err := r.s3.PresignGetObject(ctx, &getObjectArgs)
+	res, err := r.s3.PresignGetObject(ctx, &getObjectArgs, s3.WithPresignExpires(ttl))
 	if err != nil {
-		logger.Error(err.Error())
 		return nil, err
 	}
-	data = &model.GetPresignedURLResponse{
+
+	return &model.GetPresignedURLResponse{
 		ID:         object.ID,
-		Filename:   object.FileName,
+		Filename:   object.DownloadFileName(),
 		Type:       object.Type,
 		URL:        res.URL,
 		ExpiredAt:  expiration,
 		IsPublic:   object.IsPublic,
 		UploadedBy: object.UploadedBy,
+		Version:    object.Version,
+		Metadata:   object.Metadata,
 		CreatedAt:  object.CreatedAt,
+	}, nil
+}
+
+// GetContent streams the object from S3, the caller must close the body.
+func (r *objectRepository) GetContent(ctx context.Context, object *model.Object, payload *model.GetObjectContentPayload) (*model.ObjectContent, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":    object.ID,
+		"key":   object.Key,
+		"range": payload.Range,
+	})
+
+	bucketName := bucketOf(object)
+	input := &s3.GetObjectInput{
+		Bucket: &bucketName,
+		Key:    &object.Key,
+	}
+	// ranges of the ciphertext are useless, encrypted objects are always served whole
+	if payload.Range != "" && !object.IsEncrypted() {
+		input.Range = aws.String(payload.Range)
+	}
+	if payload.IfNoneMatch != "" {
+		input.IfNoneMatch = aws.String(payload.IfNoneMatch)
+	}
+
+	output, err := r.s3.GetObject(ctx, input)
+	var respErr *awshttp.ResponseError
+	switch {
+	case err == nil:
+	case errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotModified:
+		return &model.ObjectContent{
+			Object:      object,
+			ETag:        payload.IfNoneMatch,
+			NotModified: true,
+		}, nil
+	case errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusRequestedRangeNotSatisfiable:
+		return nil, model.ErrInvalidRange
+	case errors.As(err, new(*types.NoSuchKey)):
+		logger.Error(err.Error())
+		return nil, model.ErrContentNotFound
+	default:
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if object.IsEncrypted() {
+		return r.decryptContent(ctx, object, output)
 	}
 
-	err = SetWithExpiry(ctx, r.redisClient, cacheKey, data)
+	return &model.ObjectContent{
+		Object:        object,
+		Body:          output.Body,
+		ContentType:   aws.ToString(output.ContentType),
+		ContentLength: output.ContentLength,
+		ContentRange:  aws.ToString(output.ContentRange),
+		ETag:          aws.ToString(output.ETag),
+		CacheControl:  aws.ToString(output.CacheControl),
+		LastModified:  output.LastModified,
+	}, nil
+}
+
+// decryptContent buffers and decrypts the whole body, gcm can only authenticate it at the end.
+func (r *objectRepository) decryptContent(ctx context.Context, object *model.Object, output *s3.GetObjectOutput) (*model.ObjectContent, error) {
+	_, _, fn := utils.Trace()
+	_, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":    object.ID,
+		"keyID": object.EncryptionKeyID,
+	})
+
+	defer output.Body.Close()
+	sealed, err := io.ReadAll(output.Body)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	plaintext, err := r.decrypt(object, sealed)
 	if err != nil {
 		logger.Error(err.Error())
+		return nil, err
 	}
 
-	return data, nil
+	return &model.ObjectContent{
+		Object:        object,
+		Body:          io.NopCloser(bytes.NewReader(plaintext)),
+		ContentType:   aws.ToString(output.ContentType),
+		ContentLength: int64(len(plaintext)),
+		ETag:          aws.ToString(output.ETag),
+		CacheControl:  aws.ToString(output.CacheControl),
+		LastModified:  output.LastModified,
+	}, nil
+}
+
+// Update writes the changed columns when the stored version still matches
+// object.Version, which is then bumped.
+func (r *objectRepository) Update(ctx context.Context, object *model.Object, changes map[string]any) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":      object.ID,
+		"version": object.Version,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	columns := make(map[string]any, len(changes)+1)
+	for column, value := range changes {
+		columns[column] = value
+	}
+	columns["version"] = gorm.Expr("version + 1")
+
+	res := db.WithContext(ctx).
+		Model(new(model.Object)).
+		Where("id = ? AND tenant_id = ? AND version = ?", object.ID, object.TenantID, object.Version).
+		Updates(columns)
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrObjectVersionConflict
+	}
+	object.Version++
+
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectCacheKeys(object.TenantID, object.ID))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectCacheTag(object.TenantID, object.ID)})
+
+	return nil
 }
 
 func (r *objectRepository) DeleteByID(ctx context.Context, id string) error {
@@ -214,16 +689,228 @@ func (r *objectRepository) DeleteByID(ctx context.Context, id string) error {
 
 	db := utils.GetTxFromContext(ctx, r.db)
 
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	object := new(model.Object)
 
-	err := db.WithContext(ctx).Clauses(clause.Returning{}).
-		Where("id = ?", id).Delete(object).Error
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Returning{}).
+			Where("id = ? AND tenant_id = ?", id, tenantID).Delete(object)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return adjustStorageUsage(tx, object, -1)
+	})
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectCacheKeys(id))
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectCacheKeys(tenantID, id))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectCacheTag(tenantID, id)})
 
 	return nil
 }
+
+// unreferencedTemporaryQuery matches temporary objects past their deadline that nothing claims,
+// the gc is a system job so it is the one query not scoped to the context tenant.
+const unreferencedTemporaryQuery = `objects.temporary_until IS NOT NULL AND objects.temporary_until < ? AND NOT EXISTS (
+	SELECT 1 FROM object_references WHERE object_references.object_id = objects.id
+)`
+
+func (r *objectRepository) FindUnreferencedTemporary(ctx context.Context, before time.Time, limit int, offset int) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"before": before,
+		"limit":  limit,
+		"offset": offset,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objects := make([]*model.Object, 0)
+
+	err := db.WithContext(ctx).
+		Where(unreferencedTemporaryQuery, before).
+		Order("temporary_until ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&objects).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// DeleteUnreferencedTemporary deletes the object row only when it is still an
+// unreferenced expired temporary object, a reference added meanwhile wins.
+func (r *objectRepository) DeleteUnreferencedTemporary(ctx context.Context, id string, before time.Time) (bool, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	object := new(model.Object)
+	deleted := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Returning{}).
+			Where("objects.id = ?", id).
+			Where(unreferencedTemporaryQuery, before).
+			Delete(object)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		deleted = true
+		return adjustStorageUsage(tx, object, -1)
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return false, err
+	}
+
+	if !deleted {
+		return false, nil
+	}
+
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectCacheKeys(object.TenantID, id))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectCacheTag(object.TenantID, id)})
+
+	return true, nil
+}
+
+// DeleteContent removes the stored content, deleting a missing key is not an error.
+func (r *objectRepository) DeleteContent(ctx context.Context, object *model.Object) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":  object.ID,
+		"key": object.Key,
+	})
+
+	bucketName := bucketOf(object)
+	_, err := r.s3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &bucketName,
+		Key:    &object.Key,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// FindAllAfterID is a system query and spans every tenant.
+func (r *objectRepository) FindAllAfterID(ctx context.Context, afterID string, limit int) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"afterID": afterID,
+		"limit":   limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objects := make([]*model.Object, 0)
+
+	err := db.WithContext(ctx).
+		Select("id", "tenant_id", "bucket", "key", "uploaded_by", "size", "checksum_sha256", "encryption_key_id", "wrapped_data_key", "created_at").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&objects).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	for _, object := range objects {
+		object.Bucket = bucketOf(object)
+	}
+
+	return objects, nil
+}
+
+func (r *objectRepository) UpdateWrappedDataKey(ctx context.Context, object *model.Object, previousKeyID string) (bool, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":            object.ID,
+		"keyID":         object.EncryptionKeyID,
+		"previousKeyID": previousKeyID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	res := db.WithContext(ctx).
+		Model(new(model.Object)).
+		Where("id = ? AND encryption_key_id = ?", object.ID, previousKeyID).
+		Updates(map[string]any{
+			"encryption_key_id": object.EncryptionKeyID,
+			"wrapped_data_key":  object.WrappedDataKey,
+		})
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectCacheKeys(object.TenantID, object.ID))
+
+	return true, nil
+}
+
+// ListContent lists one page of the bucket, an empty continuation token starts from the beginning.
+func (r *objectRepository) ListContent(ctx context.Context, bucket string, continuationToken string) (*model.StoredContentPage, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"bucket": bucket,
+	})
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	if continuationToken != "" {
+		input.ContinuationToken = aws.String(continuationToken)
+	}
+
+	output, err := r.s3.ListObjectsV2(ctx, input)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	page := &model.StoredContentPage{
+		Contents: make([]*model.StoredContent, 0, len(output.Contents)),
+	}
+	for _, content := range output.Contents {
+		page.Contents = append(page.Contents, &model.StoredContent{
+			Bucket:       bucket,
+			Key:          aws.ToString(content.Key),
+			Size:         content.Size,
+			LastModified: aws.ToTime(content.LastModified),
+		})
+	}
+	if output.IsTruncated {
+		page.ContinuationToken = aws.ToString(output.NextContinuationToken)
+	}
+
+	return page, nil
+}
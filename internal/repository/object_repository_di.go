@@ -3,7 +3,6 @@ package repository
 import (
 	"errors"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/krobus00/storage-service/internal/model"
 	"gorm.io/gorm"
 )
@@ -16,11 +15,19 @@ func (r *objectRepository) InjectS3Client(client model.S3Client) error {
 	return nil
 }
 
-func (r *objectRepository) InjectRedisClient(client *redis.Client) error {
-	if client == nil {
-		return errors.New("invalid redis client")
+func (r *objectRepository) InjectKeyring(keyring model.Keyring) error {
+	if keyring == nil {
+		return errors.New("invalid keyring")
+	}
+	r.keyring = keyring
+	return nil
+}
+
+func (r *objectRepository) InjectCache(cache model.Cache) error {
+	if cache == nil {
+		return errors.New("invalid cache")
 	}
-	r.redisClient = client
+	r.cache = cache
 	return nil
 }
 
@@ -6,16 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"reflect"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis/v2"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
 	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
 	"github.com/krobus00/storage-service/internal/infrastructure"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/model/mock"
@@ -34,7 +45,7 @@ func newObjectRepoMock(t *testing.T) (model.ObjectRepository, sqlmock.Sqlmock, *
 	objectRepo := NewObjectRepository()
 	err = objectRepo.InjectDB(dbConn)
 	utils.ContinueOrFatal(err)
-	err = objectRepo.InjectRedisClient(redisClient)
+	err = objectRepo.InjectCache(infrastructure.NewRedisCache(redisClient))
 	utils.ContinueOrFatal(err)
 
 	return objectRepo, dbMock, miniRedis
@@ -53,12 +64,20 @@ func Test_objectRepository_Create(t *testing.T) {
 		userID string
 		data   *model.ObjectPayload
 	}
+	type mockCompensation struct {
+		deleteErr error
+	}
 	tests := []struct {
-		name          string
-		args          args
-		mockPutObject *mockPutObject
-		mockErr       error
-		wantErr       bool
+		name             string
+		args             args
+		mockPutObject    *mockPutObject
+		mockErr          error
+		mockRowsAffected int64
+		mockCompensation *mockCompensation
+		wantFileName     string
+		wantKey          string
+		wantBucket       string
+		wantErr          bool
 	}{
 		{
 			name: "success",
@@ -79,8 +98,67 @@ func Test_objectRepository_Create(t *testing.T) {
 				res: &s3.PutObjectOutput{},
 				err: nil,
 			},
-			mockErr: nil,
-			wantErr: false,
+			mockErr:      nil,
+			wantFileName: "test.png",
+			wantKey:      fmt.Sprintf(`^%s/%s\.png$`, userID, objectID),
+			wantBucket:   config.GetS3BucketName(),
+			wantErr:      false,
+		},
+		{
+			name: "success routed by object type",
+			args: args{
+				userID: userID,
+				data: &model.ObjectPayload{
+					Object: &model.Object{
+						ID:             objectID,
+						FileName:       "test",
+						UploadedBy:     userID,
+						IsPublic:       true,
+						Type:           "image",
+						ChecksumSHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					},
+					ObjectType: &model.ObjectType{
+						Name:         "image",
+						Bucket:       "media",
+						KeyTemplate:  "{type}/{yyyy}/{id}{ext}",
+						StorageClass: "STANDARD_IA",
+						CacheControl: "public, max-age=60",
+					},
+				},
+			},
+			mockPutObject: &mockPutObject{
+				res: &s3.PutObjectOutput{},
+				err: nil,
+			},
+			mockErr:      nil,
+			wantFileName: "test.png",
+			wantKey:      fmt.Sprintf(`^image/\d{4}/%s\.png$`, objectID),
+			wantBucket:   "media",
+			wantErr:      false,
+		},
+		{
+			name: "success keep matching extension",
+			args: args{
+				userID: userID,
+				data: &model.ObjectPayload{
+					Object: (&model.Object{
+						ID:         objectID,
+						Key:        "/object/test",
+						UploadedBy: userID,
+						IsPublic:   false,
+						Type:       "image",
+					}).SetFileName("../report.v2.final.PNG"),
+				},
+			},
+			mockPutObject: &mockPutObject{
+				res: &s3.PutObjectOutput{},
+				err: nil,
+			},
+			mockErr:      nil,
+			wantFileName: "report.v2.final.PNG",
+			wantKey:      fmt.Sprintf(`^%s/%s\.png$`, userID, objectID),
+			wantBucket:   config.GetS3BucketName(),
+			wantErr:      false,
 		},
 		{
 			name: "error create object",
@@ -101,8 +179,55 @@ func Test_objectRepository_Create(t *testing.T) {
 				res: &s3.PutObjectOutput{},
 				err: nil,
 			},
-			mockErr: errors.New("db error"),
-			wantErr: true,
+			mockErr:          errors.New("db error"),
+			mockCompensation: &mockCompensation{},
+			wantFileName:     "test.png",
+			wantErr:          true,
+		},
+		{
+			name: "error create object record pending deletion",
+			args: args{
+				userID: userID,
+				data: &model.ObjectPayload{
+					Object: &model.Object{
+						ID:         objectID,
+						FileName:   "test",
+						UploadedBy: userID,
+						Type:       "image",
+					},
+				},
+			},
+			mockPutObject: &mockPutObject{
+				res: &s3.PutObjectOutput{},
+				err: nil,
+			},
+			mockErr:          errors.New("db error"),
+			mockCompensation: &mockCompensation{deleteErr: errors.New("s3 error")},
+			wantFileName:     "test.png",
+			wantErr:          true,
+		},
+		{
+			name: "error idempotency key taken",
+			args: args{
+				userID: userID,
+				data: &model.ObjectPayload{
+					Object: &model.Object{
+						ID:         objectID,
+						FileName:   "test",
+						UploadedBy: userID,
+						Type:       "image",
+					},
+					IdempotencyKey: "retry-1",
+				},
+			},
+			mockPutObject: &mockPutObject{
+				res: &s3.PutObjectOutput{},
+				err: nil,
+			},
+			mockRowsAffected: 0,
+			mockCompensation: &mockCompensation{},
+			wantFileName:     "test.png",
+			wantErr:          true,
 		},
 		{
 			name: "error put object",
@@ -123,8 +248,9 @@ func Test_objectRepository_Create(t *testing.T) {
 				res: &s3.PutObjectOutput{},
 				err: errors.New("s3 error"),
 			},
-			mockErr: nil,
-			wantErr: true,
+			mockErr:      nil,
+			wantFileName: "test.png",
+			wantErr:      true,
 		},
 	}
 	for _, tt := range tests {
@@ -149,33 +275,262 @@ func Test_objectRepository_Create(t *testing.T) {
 
 			tt.args.data.Src = buf.Bytes()
 
+			var putInput *s3.PutObjectInput
 			if tt.mockPutObject != nil {
 				s3Client.EXPECT().
-					PutObject(ctx, gomock.Any()).
-					Times(1).Return(tt.mockPutObject.res, tt.mockPutObject.err)
+					PutObject(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+						putInput = input
+						return tt.mockPutObject.res, tt.mockPutObject.err
+					})
 			}
 
 			object := tt.args.data.Object
+			rowsAffected := int64(1)
+			if tt.args.data.IdempotencyKey != "" {
+				rowsAffected = tt.mockRowsAffected
+			}
 
 			dbMock.ExpectBegin()
 			dbMock.ExpectExec("INSERT INTO \"objects\"").
-				WithArgs(object.ID, fmt.Sprintf("%s.png", object.FileName), fmt.Sprintf("%s.png", object.Key), object.UploadedBy, object.IsPublic, object.TypeID, sqlmock.AnyArg()).
-				WillReturnResult(sqlmock.NewResult(1, 1)).
+				WithArgs(object.ID, constant.DefaultTenantID, tt.wantFileName, object.OriginalFileName, sqlmock.AnyArg(), sqlmock.AnyArg(), object.UploadedBy, object.IsPublic, object.TypeID, object.Size, object.ChecksumSHA256, "", "", object.Version, "{}", nil, tt.args.data.IdempotencyKey, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, rowsAffected)).
 				WillReturnError(tt.mockErr)
+			if tt.mockErr == nil && rowsAffected > 0 {
+				dbMock.ExpectExec("INSERT INTO storage_usages").
+					WithArgs(constant.DefaultTenantID, object.UploadedBy, object.TypeID, object.Size, 1, object.Size, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
 
 			if tt.wantErr {
 				dbMock.ExpectRollback()
 			} else {
 				dbMock.ExpectCommit()
 			}
+			if tt.mockCompensation != nil {
+				s3Client.EXPECT().
+					DeleteObject(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&s3.DeleteObjectOutput{}, tt.mockCompensation.deleteErr)
+				if tt.mockCompensation.deleteErr != nil {
+					dbMock.ExpectBegin()
+					dbMock.ExpectExec("INSERT INTO \"pending_deletions\"").
+						WithArgs(sqlmock.AnyArg(), config.GetS3BucketName(), sqlmock.AnyArg(), "db error", 0, "s3 error", sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					dbMock.ExpectCommit()
+				}
+			}
 
 			if err := r.Create(ctx, tt.args.data); (err != nil) != tt.wantErr {
 				t.Errorf("objectRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if err := dbMock.ExpectationsWereMet(); tt.mockPutObject.err == nil && err != nil {
+				t.Errorf("objectRepository.Create() %v", err)
+			}
+			if tt.wantKey == "" {
+				return
+			}
+			if !regexp.MustCompile(tt.wantKey).MatchString(object.Key) {
+				t.Errorf("objectRepository.Create() key = %v, want %v", object.Key, tt.wantKey)
+			}
+			if aws.ToString(putInput.Bucket) != tt.wantBucket {
+				t.Errorf("objectRepository.Create() bucket = %v, want %v", aws.ToString(putInput.Bucket), tt.wantBucket)
+			}
+			if tt.args.data.ObjectType != nil {
+				if string(putInput.StorageClass) != tt.args.data.ObjectType.StorageClass || aws.ToString(putInput.CacheControl) != tt.args.data.ObjectType.CacheControl {
+					t.Errorf("objectRepository.Create() storageClass = %v, cacheControl = %v", putInput.StorageClass, aws.ToString(putInput.CacheControl))
+				}
+				if aws.ToString(putInput.ChecksumSHA256) != "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=" {
+					t.Errorf("objectRepository.Create() checksum = %v", aws.ToString(putInput.ChecksumSHA256))
+				}
+			}
+			if object.Bucket != tt.wantBucket {
+				t.Errorf("objectRepository.Create() object bucket = %v, want %v", object.Bucket, tt.wantBucket)
+			}
+		})
+	}
+}
+
+func Test_objectRepository_Create_quota(t *testing.T) {
+	userID := utils.GenerateUUID()
+	type mockTotals struct {
+		bytes   int64
+		objects int64
+	}
+	tests := []struct {
+		name       string
+		quota      model.UploadQuota
+		mockTenant *mockTotals
+		mockUser   *mockTotals
+		wantErr    error
+	}{
+		{
+			name:    "success unlimited",
+			quota:   model.UploadQuota{},
+			wantErr: nil,
+		},
+		{
+			name:       "success within quota",
+			quota:      model.UploadQuota{User: model.StorageQuota{MaxBytes: 100}, Tenant: model.StorageQuota{MaxObjects: 10}},
+			mockTenant: &mockTotals{bytes: 1000, objects: 9},
+			mockUser:   &mockTotals{bytes: 90, objects: 1},
+			wantErr:    nil,
+		},
+		{
+			name:     "error user quota exceeded",
+			quota:    model.UploadQuota{User: model.StorageQuota{MaxBytes: 100}},
+			mockUser: &mockTotals{bytes: 95, objects: 1},
+			wantErr:  model.ErrQuotaExceeded,
+		},
+		{
+			name:       "error tenant quota exceeded",
+			quota:      model.UploadQuota{User: model.StorageQuota{MaxBytes: 100}, Tenant: model.StorageQuota{MaxObjects: 10}},
+			mockTenant: &mockTotals{bytes: 1000, objects: 10},
+			wantErr:    model.ErrQuotaExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			r, dbMock, _ := newObjectRepoMock(t)
+			s3Client := mock.NewMockS3Client(ctrl)
+			_ = r.InjectS3Client(s3Client)
+
+			s3Client.EXPECT().
+				PutObject(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(&s3.PutObjectOutput{}, nil)
+
+			dbMock.ExpectBegin()
+			expectTotals := func(lockKey string, totals *mockTotals) {
+				dbMock.ExpectExec("SELECT pg_advisory_xact_lock").
+					WithArgs(lockKey).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				dbMock.ExpectQuery("SELECT COALESCE\\(SUM\\(bytes\\), 0\\) AS bytes, COALESCE\\(SUM\\(objects\\), 0\\) AS objects FROM \"storage_usages\"").
+					WillReturnRows(sqlmock.NewRows([]string{"bytes", "objects"}).AddRow(totals.bytes, totals.objects))
+			}
+			if tt.mockTenant != nil {
+				expectTotals("storage_usages:"+constant.DefaultTenantID, tt.mockTenant)
+			}
+			if tt.mockUser != nil {
+				expectTotals("storage_usages:"+constant.DefaultTenantID+":"+userID, tt.mockUser)
+			}
+			if tt.wantErr == nil {
+				dbMock.ExpectExec("INSERT INTO \"objects\"").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				dbMock.ExpectExec("INSERT INTO storage_usages").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				dbMock.ExpectCommit()
+			} else {
+				dbMock.ExpectRollback()
+				s3Client.EXPECT().
+					DeleteObject(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(&s3.DeleteObjectOutput{}, nil)
+			}
+
+			err := r.Create(ctx, &model.ObjectPayload{
+				Object: &model.Object{
+					ID:         utils.GenerateUUID(),
+					FileName:   "test",
+					UploadedBy: userID,
+					Type:       "image",
+					Size:       10,
+				},
+				Quota: tt.quota,
+			})
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, dbMock.ExpectationsWereMet())
 		})
 	}
 }
 
+func Test_objectRepository_encryptedRoundTrip(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		userID   = utils.GenerateUUID()
+		src      = []byte("\x89PNG\r\n\x1a\nsecret")
+	)
+	viper.Set("encryption.active_key", "k1")
+	viper.Set("encryption.keys", map[string]string{"k1": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="})
+	defer viper.Set("encryption", nil)
+	keyring, err := infrastructure.NewLocalKeyring()
+	assert.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r, dbMock, _ := newObjectRepoMock(t)
+	s3Client := mock.NewMockS3Client(ctrl)
+	assert.NoError(t, r.InjectS3Client(s3Client))
+	assert.NoError(t, r.InjectKeyring(keyring))
+	ctx := utils.NewTenantContext(context.Background(), constant.DefaultTenantID)
+
+	object := &model.Object{
+		ID:             objectID,
+		FileName:       "test",
+		UploadedBy:     userID,
+		Type:           "secret",
+		Size:           int64(len(src)),
+		ChecksumSHA256: model.SHA256Hex(src),
+	}
+	var stored []byte
+	s3Client.EXPECT().
+		PutObject(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			stored, _ = io.ReadAll(input.Body)
+			assert.Equal(t, "image/png", aws.ToString(input.ContentType))
+			assert.Equal(t, model.SHA256HexToBase64(model.SHA256Hex(stored)), aws.ToString(input.ChecksumSHA256))
+			return &s3.PutObjectOutput{}, nil
+		})
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("INSERT INTO \"objects\"").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectExec("INSERT INTO storage_usages").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err = r.Create(ctx, &model.ObjectPayload{
+		Object:     object,
+		ObjectType: &model.ObjectType{Name: "secret", Encrypted: true},
+		Src:        src,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "k1", object.EncryptionKeyID)
+	assert.NotEmpty(t, object.WrappedDataKey)
+	assert.False(t, bytes.Contains(stored, []byte("secret")))
+
+	_, err = r.GeneratePresignedURL(ctx, object, 0)
+	assert.Equal(t, model.ErrObjectEncrypted, err)
+
+	s3Client.EXPECT().
+		GetObject(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+			assert.Nil(t, input.Range)
+			return &s3.GetObjectOutput{
+				Body:          io.NopCloser(bytes.NewReader(stored)),
+				ContentType:   aws.String("image/png"),
+				ContentLength: int64(len(stored)),
+			}, nil
+		})
+	content, err := r.GetContent(ctx, object, &model.GetObjectContentPayload{ObjectID: objectID, Range: "bytes=0-1"})
+	assert.NoError(t, err)
+	plaintext, _ := io.ReadAll(content.Body)
+	assert.Equal(t, src, plaintext)
+	assert.Equal(t, int64(len(src)), content.ContentLength)
+	assert.False(t, content.IsPartial())
+
+	stored[len(stored)-1] ^= 0xff
+	_, err = r.GetContent(ctx, object, &model.GetObjectContentPayload{ObjectID: objectID})
+	assert.Equal(t, model.ErrContentTampered, err)
+}
+
 func Test_objectRepository_FindByID(t *testing.T) {
 	var (
 		objectID = utils.GenerateUUID()
@@ -285,7 +640,7 @@ func Test_objectRepository_FindByID(t *testing.T) {
 			ctx := context.TODO()
 			r, dbMock, redisMock := newObjectRepoMock(t)
 
-			cacheKey := model.NewObjectCacheKey(tt.args.id)
+			cacheKey := model.NewObjectCacheKey(constant.DefaultTenantID, tt.args.id)
 			if tt.mockSelect != nil {
 				row := sqlmock.NewRows([]string{"id", "file_name", "key", "uploaded_by", "is_public", "type_id", "created_at"})
 				if tt.mockSelect.object != nil {
@@ -302,7 +657,7 @@ func Test_objectRepository_FindByID(t *testing.T) {
 				}
 
 				dbMock.ExpectQuery("^SELECT .+ FROM \"objects\"").
-					WithArgs(tt.args.id).
+					WithArgs(tt.args.id, constant.DefaultTenantID).
 					WillReturnRows(row).
 					WillReturnError(tt.mockSelect.err)
 			}
@@ -326,6 +681,149 @@ func Test_objectRepository_FindByID(t *testing.T) {
 	}
 }
 
+func Test_objectRepository_FindAll(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		userID   = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	type args struct {
+		filter *model.ObjectFilter
+	}
+	type mockSelect struct {
+		objects []*model.Object
+		err     error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mockSelect *mockSelect
+		wantQuery  string
+		want       []*model.Object
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			args: args{
+				filter: &model.ObjectFilter{
+					UserID:   userID,
+					GroupIDs: []string{"group"},
+					Limit:    model.DefaultListObjectsLimit,
+				},
+			},
+			mockSelect: &mockSelect{
+				objects: []*model.Object{
+					{
+						ID:         objectID,
+						FileName:   "test.jpg",
+						Key:        "/object/test.jpg",
+						UploadedBy: userID,
+						TypeID:     typeID,
+					},
+				},
+			},
+			want: []*model.Object{
+				{
+					ID:         objectID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					TypeID:     typeID,
+					Metadata:   model.ObjectMetadata{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success filter by metadata",
+			args: args{
+				filter: &model.ObjectFilter{
+					UserID:   userID,
+					Metadata: model.ObjectMetadata{"sku": "123"},
+					Limit:    model.DefaultListObjectsLimit,
+				},
+			},
+			mockSelect: &mockSelect{
+				objects: []*model.Object{
+					{
+						ID:         objectID,
+						FileName:   "test.jpg",
+						Key:        "/object/test.jpg",
+						UploadedBy: userID,
+						TypeID:     typeID,
+						Metadata:   model.ObjectMetadata{"sku": "123", "source": "import"},
+					},
+				},
+			},
+			wantQuery: "^SELECT .+ FROM \"objects\" WHERE .+object_grants.+ AND metadata @> \\$\\d+::jsonb ORDER BY created_at DESC LIMIT",
+			want: []*model.Object{
+				{
+					ID:         objectID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					TypeID:     typeID,
+					Metadata:   model.ObjectMetadata{"sku": "123", "source": "import"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error find objects",
+			args: args{
+				filter: &model.ObjectFilter{
+					UserID: userID,
+					Limit:  model.DefaultListObjectsLimit,
+				},
+			},
+			mockSelect: &mockSelect{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, _ := newObjectRepoMock(t)
+
+			row := sqlmock.NewRows([]string{"id", "file_name", "key", "uploaded_by", "is_public", "type_id", "metadata", "created_at"})
+			for _, object := range tt.mockSelect.objects {
+				metadata, err := object.Metadata.Value()
+				utils.ContinueOrFatal(err)
+				row.AddRow(
+					object.ID,
+					object.FileName,
+					object.Key,
+					object.UploadedBy,
+					object.IsPublic,
+					object.TypeID,
+					[]byte(metadata.(string)),
+					object.CreatedAt,
+				)
+			}
+
+			wantQuery := tt.wantQuery
+			if wantQuery == "" {
+				wantQuery = "^SELECT .+ FROM \"objects\" WHERE .+object_grants.+ ORDER BY created_at DESC LIMIT"
+			}
+			dbMock.ExpectQuery(wantQuery).
+				WillReturnRows(row).
+				WillReturnError(tt.mockSelect.err)
+
+			got, err := r.FindAll(ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 	var (
 		objectID = utils.GenerateUUID()
@@ -340,7 +838,8 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 		res *model.GetPresignedURLResponse
 	}
 	type args struct {
-		object *model.Object
+		object      *model.Object
+		minValidity time.Duration
 	}
 	tests := []struct {
 		name                 string
@@ -348,6 +847,7 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 		mockPresignGetObject *mockPresignGetObject
 		mockCache            *mockCache
 		want                 *model.GetPresignedURLResponse
+		wantCached           bool
 		wantErr              bool
 	}{
 		{
@@ -355,6 +855,7 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 			args: args{
 				object: &model.Object{
 					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
 					FileName:   "test.jpg",
 					Key:        "/object/test.jpg",
 					UploadedBy: userID,
@@ -377,13 +878,15 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 				IsPublic:   false,
 				UploadedBy: userID,
 			},
-			wantErr: false,
+			wantCached: true,
+			wantErr:    false,
 		},
 		{
 			name: "success found in cache",
 			args: args{
 				object: &model.Object{
 					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
 					FileName:   "test.jpg",
 					Key:        "/object/test.jpg",
 					UploadedBy: userID,
@@ -398,6 +901,7 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 					Filename:   "test.jpg",
 					Type:       "image",
 					URL:        "https://s3.bucket/test.jpg",
+					ExpiredAt:  time.Now().Add(30 * time.Minute),
 					IsPublic:   false,
 					UploadedBy: userID,
 				},
@@ -413,42 +917,192 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "error find object",
+			name: "success cached url does not satisfy min validity",
 			args: args{
 				object: &model.Object{
 					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
 					FileName:   "test.jpg",
 					Key:        "/object/test.jpg",
 					UploadedBy: userID,
 					IsPublic:   false,
 					TypeID:     typeID,
 				},
+				minValidity: 45 * time.Minute,
 			},
 			mockPresignGetObject: &mockPresignGetObject{
-				res: nil,
-				err: errors.New("s3 error"),
+				res: &v4.PresignedHTTPRequest{
+					URL: "https://s3.bucket/test.jpg?fresh",
+				},
+				err: nil,
 			},
-			mockCache: nil,
-			want:      nil,
-			wantErr:   true,
+			mockCache: &mockCache{
+				res: &model.GetPresignedURLResponse{
+					ID:        objectID,
+					URL:       "https://s3.bucket/test.jpg?stale",
+					ExpiredAt: time.Now().Add(30 * time.Minute),
+				},
+			},
+			want: &model.GetPresignedURLResponse{
+				ID:  objectID,
+				URL: "https://s3.bucket/test.jpg?fresh",
+			},
+			wantCached: true,
+			wantErr:    false,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			ctx := context.TODO()
-			cacheKey := model.NewObjectPresignedURLCacheKey(tt.args.object.ID)
-
-			r, _, redisMock := newObjectRepoMock(t)
-			s3Client := mock.NewMockS3Client(ctrl)
-			err := r.InjectS3Client(s3Client)
-			utils.ContinueOrFatal(err)
-
+		{
+			name: "success cached url about to expire",
+			args: args{
+				object: &model.Object{
+					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					IsPublic:   false,
+					TypeID:     typeID,
+				},
+			},
+			mockPresignGetObject: &mockPresignGetObject{
+				res: &v4.PresignedHTTPRequest{
+					URL: "https://s3.bucket/test.jpg?fresh",
+				},
+				err: nil,
+			},
+			mockCache: &mockCache{
+				res: &model.GetPresignedURLResponse{
+					ID:        objectID,
+					URL:       "https://s3.bucket/test.jpg?stale",
+					ExpiredAt: time.Now().Add(30 * time.Second),
+				},
+			},
+			want: &model.GetPresignedURLResponse{
+				ID:  objectID,
+				URL: "https://s3.bucket/test.jpg?fresh",
+			},
+			wantCached: true,
+			wantErr:    false,
+		},
+		{
+			name: "success cached url inside refresh window is re-signed",
+			args: args{
+				object: &model.Object{
+					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					IsPublic:   false,
+					TypeID:     typeID,
+				},
+			},
+			mockPresignGetObject: &mockPresignGetObject{
+				res: &v4.PresignedHTTPRequest{
+					URL: "https://s3.bucket/test.jpg?fresh",
+				},
+				err: nil,
+			},
+			mockCache: &mockCache{
+				res: &model.GetPresignedURLResponse{
+					ID:        objectID,
+					URL:       "https://s3.bucket/test.jpg?stale",
+					ExpiredAt: time.Now().Add(10 * time.Minute),
+				},
+			},
+			want: &model.GetPresignedURLResponse{
+				ID:  objectID,
+				URL: "https://s3.bucket/test.jpg?fresh",
+			},
+			wantCached: true,
+			wantErr:    false,
+		},
+		{
+			name: "success cached url kept when re-sign fails",
+			args: args{
+				object: &model.Object{
+					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					IsPublic:   false,
+					TypeID:     typeID,
+				},
+			},
+			mockPresignGetObject: &mockPresignGetObject{
+				res: nil,
+				err: errors.New("s3 error"),
+			},
+			mockCache: &mockCache{
+				res: &model.GetPresignedURLResponse{
+					ID:        objectID,
+					URL:       "https://s3.bucket/test.jpg?stale",
+					ExpiredAt: time.Now().Add(10 * time.Minute),
+				},
+			},
+			want: &model.GetPresignedURLResponse{
+				ID:  objectID,
+				URL: "https://s3.bucket/test.jpg?stale",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error min validity exceeds sign duration",
+			args: args{
+				object: &model.Object{
+					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					IsPublic:   false,
+					TypeID:     typeID,
+				},
+				minValidity: 2 * time.Hour,
+			},
+			mockPresignGetObject: nil,
+			mockCache:            nil,
+			want:                 nil,
+			wantErr:              true,
+		},
+		{
+			name: "error find object",
+			args: args{
+				object: &model.Object{
+					ID:         objectID,
+					TenantID:   constant.DefaultTenantID,
+					FileName:   "test.jpg",
+					Key:        "/object/test.jpg",
+					UploadedBy: userID,
+					IsPublic:   false,
+					TypeID:     typeID,
+				},
+			},
+			mockPresignGetObject: &mockPresignGetObject{
+				res: nil,
+				err: errors.New("s3 error"),
+			},
+			mockCache: nil,
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			cacheKey := model.NewObjectPresignedURLCacheKey(constant.DefaultTenantID, tt.args.object.ID)
+
+			r, _, redisMock := newObjectRepoMock(t)
+			s3Client := mock.NewMockS3Client(ctrl)
+			err := r.InjectS3Client(s3Client)
+			utils.ContinueOrFatal(err)
+
 			if tt.mockPresignGetObject != nil {
 				s3Client.EXPECT().
-					PresignGetObject(ctx, gomock.Any()).
+					PresignGetObject(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(tt.mockPresignGetObject.res, tt.mockPresignGetObject.err)
 			}
@@ -461,7 +1115,7 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 				_ = redisMock.Set(cacheKey, string(cacheData))
 			}
 
-			got, err := r.GeneratePresignedURL(ctx, tt.args.object)
+			got, err := r.GeneratePresignedURL(ctx, tt.args.object, tt.args.minValidity)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("objectRepository.GeneratePresignedURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -471,6 +1125,608 @@ func Test_objectRepository_GeneratePresignedURL(t *testing.T) {
 					t.Errorf("objectRepository.GeneratePresignedURL() = %v, want %v", got, tt.want)
 				}
 			}
+			if tt.wantCached {
+				// the entry must leave the cache before the url is too close to expiry to hand out
+				ttl := redisMock.TTL(cacheKey)
+				assert.Greater(t, ttl, config.GetS3SignDuration()-config.GetS3PresignRefreshWindow())
+				assert.LessOrEqual(t, ttl, config.GetS3SignDuration()-config.GetS3PresignSafetyMargin())
+			}
+		})
+	}
+}
+
+func Test_objectRepository_SignPresignedURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	object := &model.Object{
+		ID:       utils.GenerateUUID(),
+		TenantID: constant.DefaultTenantID,
+		FileName: "test.jpg",
+		Key:      "/object/test.jpg",
+	}
+	cacheKey := model.NewObjectPresignedURLCacheKey(object.TenantID, object.ID)
+
+	r, _, redisMock := newObjectRepoMock(t)
+	s3Client := mock.NewMockS3Client(ctrl)
+	err := r.InjectS3Client(s3Client)
+	utils.ContinueOrFatal(err)
+
+	cached, err := json.Marshal(&model.GetPresignedURLResponse{
+		ID:        object.ID,
+		URL:       "https://s3.bucket/test.jpg?cached",
+		ExpiredAt: time.Now().Add(50 * time.Minute),
+	})
+	utils.ContinueOrFatal(err)
+	_ = redisMock.Set(cacheKey, string(cached))
+
+	signed := 0
+	s3Client.EXPECT().
+		PresignGetObject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, _ *s3.GetObjectInput, _ ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+			signed++
+			return &v4.PresignedHTTPRequest{URL: fmt.Sprintf("https://s3.bucket/test.jpg?sig=%d", signed)}, nil
+		})
+
+	first, err := r.SignPresignedURL(context.TODO(), object, time.Minute)
+	assert.NoError(t, err)
+	second, err := r.SignPresignedURL(context.TODO(), object, time.Minute)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://s3.bucket/test.jpg?sig=1", first.URL)
+	assert.Equal(t, "https://s3.bucket/test.jpg?sig=2", second.URL)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), second.ExpiredAt, 5*time.Second)
+
+	// the shared entry is neither handed out nor replaced
+	stored, err := redisMock.Get(cacheKey)
+	assert.NoError(t, err)
+	assert.Equal(t, string(cached), stored)
+}
+
+func Test_objectRepository_GetContent(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+	)
+	object := &model.Object{
+		ID:       objectID,
+		FileName: "test.jpg",
+		Key:      "/object/test.jpg",
+	}
+	newResponseError := func(statusCode int) error {
+		return &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{StatusCode: statusCode}},
+				Err:      errors.New(http.StatusText(statusCode)),
+			},
+		}
+	}
+	body := io.NopCloser(bytes.NewBufferString("test"))
+	type mockGetObject struct {
+		res *s3.GetObjectOutput
+		err error
+	}
+	tests := []struct {
+		name          string
+		payload       *model.GetObjectContentPayload
+		mockGetObject *mockGetObject
+		want          *model.ObjectContent
+		wantErr       error
+	}{
+		{
+			name:    "success",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID},
+			mockGetObject: &mockGetObject{
+				res: &s3.GetObjectOutput{
+					Body:          body,
+					ContentType:   aws.String("image/jpeg"),
+					ContentLength: 4,
+					ETag:          aws.String(`"etag"`),
+				},
+			},
+			want: &model.ObjectContent{
+				Object:        object,
+				Body:          body,
+				ContentType:   "image/jpeg",
+				ContentLength: 4,
+				ETag:          `"etag"`,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "success partial content",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, Range: "bytes=0-1"},
+			mockGetObject: &mockGetObject{
+				res: &s3.GetObjectOutput{
+					Body:          body,
+					ContentType:   aws.String("image/jpeg"),
+					ContentLength: 2,
+					ContentRange:  aws.String("bytes 0-1/4"),
+					ETag:          aws.String(`"etag"`),
+				},
+			},
+			want: &model.ObjectContent{
+				Object:        object,
+				Body:          body,
+				ContentType:   "image/jpeg",
+				ContentLength: 2,
+				ContentRange:  "bytes 0-1/4",
+				ETag:          `"etag"`,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "success not modified",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, IfNoneMatch: `"etag"`},
+			mockGetObject: &mockGetObject{
+				err: newResponseError(http.StatusNotModified),
+			},
+			want: &model.ObjectContent{
+				Object:      object,
+				ETag:        `"etag"`,
+				NotModified: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "error invalid range",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, Range: "bytes=10-20"},
+			mockGetObject: &mockGetObject{
+				err: newResponseError(http.StatusRequestedRangeNotSatisfiable),
+			},
+			want:    nil,
+			wantErr: model.ErrInvalidRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+
+			r, _, _ := newObjectRepoMock(t)
+			s3Client := mock.NewMockS3Client(ctrl)
+			err := r.InjectS3Client(s3Client)
+			utils.ContinueOrFatal(err)
+
+			s3Client.EXPECT().
+				GetObject(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, params *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+					assert.Equal(t, object.Key, aws.ToString(params.Key))
+					assert.Equal(t, tt.payload.Range, aws.ToString(params.Range))
+					assert.Equal(t, tt.payload.IfNoneMatch, aws.ToString(params.IfNoneMatch))
+					return tt.mockGetObject.res, tt.mockGetObject.err
+				})
+
+			got, err := r.GetContent(ctx, object, tt.payload)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectRepository.GetContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectRepository.GetContent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_objectRepository_Update(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	type args struct {
+		object  *model.Object
+		changes map[string]any
+	}
+	tests := []struct {
+		name         string
+		args         args
+		mockAffected int64
+		mockErr      error
+		wantVersion  int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			args: args{
+				object:  &model.Object{ID: objectID, TenantID: constant.DefaultTenantID, Version: 1},
+				changes: map[string]any{"is_public": true},
+			},
+			mockAffected: 1,
+			wantVersion:  2,
+		},
+		{
+			name: "error version conflict",
+			args: args{
+				object:  &model.Object{ID: objectID, TenantID: constant.DefaultTenantID, Version: 1},
+				changes: map[string]any{"is_public": true},
+			},
+			mockAffected: 0,
+			wantVersion:  1,
+			wantErr:      model.ErrObjectVersionConflict,
+		},
+		{
+			name: "error update object",
+			args: args{
+				object:  &model.Object{ID: objectID, TenantID: constant.DefaultTenantID, Version: 1},
+				changes: map[string]any{"is_public": true},
+			},
+			mockErr:     errors.New("db error"),
+			wantVersion: 1,
+			wantErr:     errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjectRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			cacheKeys := model.GetObjectCacheKeys(constant.DefaultTenantID, objectID)
+			for _, cacheKey := range cacheKeys {
+				utils.ContinueOrFatal(SetWithExpiry(ctx, cache, cacheKey, model.Object{ID: objectID}, model.GetObjectCacheTags(constant.DefaultTenantID, objectID, typeID)...))
+			}
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"objects\" SET \"is_public\"=\\$1,\"version\"=version \\+ 1 WHERE id = \\$2 AND tenant_id = \\$3 AND version = \\$4").
+				WithArgs(true, objectID, constant.DefaultTenantID, int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, tt.mockAffected)).
+				WillReturnError(tt.mockErr)
+
+			if tt.mockErr != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err := r.Update(ctx, tt.args.object, tt.args.changes)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.args.object.Version != tt.wantVersion {
+				t.Errorf("objectRepository.Update() version = %v, want %v", tt.args.object.Version, tt.wantVersion)
+			}
+
+			for _, cacheKey := range cacheKeys {
+				if redisMock.Exists(cacheKey) != (tt.wantErr != nil) {
+					t.Errorf("objectRepository.Update() cache %s exists = %v, want %v", cacheKey, tt.wantErr == nil, tt.wantErr != nil)
+				}
+			}
+		})
+	}
+}
+
+func Test_objectRepository_DeleteByID(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+		userID   = utils.GenerateUUID()
+	)
+	type args struct {
+		id string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				id: objectID,
+			},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name: "error delete object",
+			args: args{
+				id: objectID,
+			},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjectRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			cacheKeys := model.GetObjectCacheKeys(constant.DefaultTenantID, tt.args.id)
+			for _, cacheKey := range cacheKeys {
+				utils.ContinueOrFatal(SetWithExpiry(ctx, cache, cacheKey, model.Object{ID: tt.args.id}, model.GetObjectCacheTags(constant.DefaultTenantID, tt.args.id, typeID)...))
+			}
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("DELETE FROM \"objects\"").
+				WithArgs(tt.args.id, constant.DefaultTenantID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "uploaded_by", "type_id", "size"}).AddRow(tt.args.id, constant.DefaultTenantID, userID, typeID, 100)).
+				WillReturnError(tt.mockErr)
+			if tt.mockErr == nil {
+				dbMock.ExpectExec("INSERT INTO storage_usages").
+					WithArgs(constant.DefaultTenantID, userID, typeID, -100, -1, -100, -1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.DeleteByID(ctx, tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("objectRepository.DeleteByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, cacheKey := range cacheKeys {
+				if redisMock.Exists(cacheKey) != tt.wantErr {
+					t.Errorf("objectRepository.DeleteByID() cache %s exists = %v, want %v", cacheKey, !tt.wantErr, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_objectRepository_FindByID_collapseConcurrentMiss(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	const callers = 5
+
+	ctx := context.TODO()
+	r, dbMock, _ := newObjectRepoMock(t)
+
+	row := sqlmock.NewRows([]string{"id", "file_name", "key", "uploaded_by", "is_public", "type_id", "created_at"}).
+		AddRow(objectID, "test.jpg", "/object/test.jpg", utils.GenerateUUID(), false, typeID, time.Time{})
+
+	// only one query is expected, a second one would fail the expectation
+	dbMock.ExpectQuery("^SELECT .+ FROM \"objects\"").
+		WithArgs(objectID, constant.DefaultTenantID).
+		WillDelayFor(50 * time.Millisecond).
+		WillReturnRows(row)
+
+	wg := sync.WaitGroup{}
+	results := make([]*model.Object, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			object, err := r.FindByID(ctx, objectID)
+			assert.NoError(t, err)
+			results[i] = object
+		}(i)
+	}
+	wg.Wait()
+
+	for i, object := range results {
+		assert.Equal(t, objectID, object.ID)
+		for _, other := range results[i+1:] {
+			assert.NotSame(t, object, other)
+		}
+	}
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func Test_objectRepository_DeleteUnreferencedTemporary(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+		userID   = utils.GenerateUUID()
+		before   = time.Now()
+	)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		mockErr      error
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			rowsAffected: 1,
+			want:         true,
+			wantErr:      false,
+		},
+		{
+			name:         "success referenced meanwhile",
+			rowsAffected: 0,
+			want:         false,
+			wantErr:      false,
+		},
+		{
+			name:    "error delete object",
+			mockErr: errors.New("db error"),
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjectRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			cacheKey := model.NewObjectCacheKey(constant.DefaultTenantID, objectID)
+			utils.ContinueOrFatal(SetWithExpiry(ctx, cache, cacheKey, model.Object{ID: objectID}, model.GetObjectCacheTags(constant.DefaultTenantID, objectID, typeID)...))
+
+			rows := sqlmock.NewRows([]string{"id", "tenant_id", "uploaded_by", "type_id", "size"})
+			if tt.rowsAffected > 0 {
+				rows.AddRow(objectID, constant.DefaultTenantID, userID, typeID, 100)
+			}
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("DELETE FROM \"objects\" WHERE objects.id = .+ AND \\(objects.temporary_until IS NOT NULL AND objects.temporary_until < .+ AND NOT EXISTS \\(\\s+SELECT 1 FROM object_references").
+				WithArgs(objectID, before).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+			if tt.rowsAffected > 0 {
+				dbMock.ExpectExec("INSERT INTO storage_usages").
+					WithArgs(constant.DefaultTenantID, userID, typeID, -100, -1, -100, -1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			got, err := r.DeleteUnreferencedTemporary(ctx, objectID, before)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectRepository.DeleteUnreferencedTemporary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("objectRepository.DeleteUnreferencedTemporary() = %v, want %v", got, tt.want)
+			}
+			// an object that was not deleted keeps its cache entry
+			if redisMock.Exists(cacheKey) == tt.want {
+				t.Errorf("objectRepository.DeleteUnreferencedTemporary() cache %s exists = %v, want %v", cacheKey, tt.want, !tt.want)
+			}
+		})
+	}
+}
+
+func Test_objectRepository_FindByID_tenantIsolation(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+
+	r, dbMock, redisMock := newObjectRepoMock(t)
+	cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+	// cached for another tenant, must not be served to the default tenant
+	acmeCtx := utils.NewTenantContext(context.TODO(), "acme")
+	utils.ContinueOrFatal(SetWithExpiry(acmeCtx, cache, model.NewObjectCacheKey("acme", objectID), model.Object{ID: objectID, TenantID: "acme"}, model.GetObjectCacheTags("acme", objectID, typeID)...))
+
+	dbMock.ExpectQuery("^SELECT .+ FROM \"objects\" WHERE id = .+ AND tenant_id = .+").
+		WithArgs(objectID, constant.DefaultTenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	object, err := r.FindByID(context.TODO(), objectID)
+	assert.NoError(t, err)
+	assert.Nil(t, object)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+
+	object, err = r.FindByID(acmeCtx, objectID)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", object.TenantID)
+}
+
+func Test_objectRepository_FindAllAfterID(t *testing.T) {
+	viper.Set("s3.bucket", "default-bucket")
+	viper.Set("tenants.acme.bucket", "acme-bucket")
+	defer viper.Set("s3.bucket", nil)
+	defer viper.Set("tenants", nil)
+
+	r, dbMock, _ := newObjectRepoMock(t)
+
+	rows := sqlmock.NewRows([]string{"id", "tenant_id", "bucket", "key"}).
+		AddRow("1", constant.DefaultTenantID, "", "user/1.png").
+		AddRow("2", "acme", "", "acme/user/2.png").
+		AddRow("3", "acme", "media", "image/3.png")
+	dbMock.ExpectQuery("^SELECT .+ FROM \"objects\" WHERE id > .+ ORDER BY id ASC LIMIT 3").
+		WithArgs("").
+		WillReturnRows(rows)
+
+	objects, err := r.FindAllAfterID(context.TODO(), "", 3)
+	assert.NoError(t, err)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+	assert.Equal(t, []string{"default-bucket", "acme-bucket", "media"}, []string{objects[0].Bucket, objects[1].Bucket, objects[2].Bucket})
+}
+
+func Test_objectRepository_FindByTypeID(t *testing.T) {
+	typeID := utils.GenerateUUID()
+	r, dbMock, _ := newObjectRepoMock(t)
+
+	rows := sqlmock.NewRows([]string{"id", "type_id"}).
+		AddRow("2", typeID).
+		AddRow("3", typeID)
+	dbMock.ExpectQuery("^SELECT .+ FROM \"objects\" WHERE tenant_id = .+ AND type_id = .+ AND id > .+ ORDER BY id ASC LIMIT 2").
+		WithArgs(constant.DefaultTenantID, typeID, "1").
+		WillReturnRows(rows)
+
+	objects, err := r.FindByTypeID(context.TODO(), typeID, "1", 2)
+	assert.NoError(t, err)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+	assert.Len(t, objects, 2)
+}
+
+func Test_objectRepository_ListContent(t *testing.T) {
+	lastModified := time.Now().UTC()
+	tests := []struct {
+		name    string
+		token   string
+		mockRes *s3.ListObjectsV2Output
+		mockErr error
+		want    *model.StoredContentPage
+		wantErr bool
+	}{
+		{
+			name:  "success truncated",
+			token: "",
+			mockRes: &s3.ListObjectsV2Output{
+				Contents:              []s3types.Object{{Key: aws.String("a.png"), Size: 10, LastModified: &lastModified}},
+				IsTruncated:           true,
+				NextContinuationToken: aws.String("next"),
+			},
+			want: &model.StoredContentPage{
+				Contents:          []*model.StoredContent{{Bucket: "media", Key: "a.png", Size: 10, LastModified: lastModified}},
+				ContinuationToken: "next",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "success last page",
+			token: "next",
+			mockRes: &s3.ListObjectsV2Output{
+				Contents:              []s3types.Object{},
+				NextContinuationToken: aws.String("ignored"),
+			},
+			want: &model.StoredContentPage{
+				Contents: []*model.StoredContent{},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error list objects",
+			mockErr: errors.New("s3 error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, _, _ := newObjectRepoMock(t)
+			s3Client := mock.NewMockS3Client(ctrl)
+			utils.ContinueOrFatal(r.InjectS3Client(s3Client))
+
+			s3Client.EXPECT().
+				ListObjectsV2(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+					assert.Equal(t, "media", aws.ToString(input.Bucket))
+					assert.Equal(t, tt.token, aws.ToString(input.ContinuationToken))
+					return tt.mockRes, tt.mockErr
+				})
+
+			got, err := r.ListContent(context.TODO(), "media", tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectRepository.ListContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectRepository.ListContent() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -4,18 +4,20 @@ import (
 	"context"
 	"errors"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type objectTypeRepository struct {
-	db          *gorm.DB
-	redisClient *redis.Client
+	db        *gorm.DB
+	cache     model.Cache
+	loadGroup singleflight.Group
 }
 
 func NewObjectTypeRepository() model.ObjectTypeRepository {
@@ -33,6 +35,7 @@ func (r *objectTypeRepository) Create(ctx context.Context, objectType *model.Obj
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	objectType.TenantID = utils.GetTenantIDFromContext(ctx)
 
 	err := db.WithContext(ctx).Create(objectType).Error
 	if err != nil {
@@ -40,11 +43,32 @@ func (r *objectTypeRepository) Create(ctx context.Context, objectType *model.Obj
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectTypeCacheKeys(objectType.ID, objectType.Name))
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectTypeCacheKeys(objectType.TenantID, objectType.ID, objectType.Name))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectTypeCacheTag(objectType.TenantID, objectType.ID)})
 
 	return nil
 }
 
+func (r *objectTypeRepository) FindAll(ctx context.Context) ([]*model.ObjectType, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objectTypes := make([]*model.ObjectType, 0)
+
+	err := db.WithContext(ctx).
+		Where("tenant_id = ?", utils.GetTenantIDFromContext(ctx)).
+		Order("name ASC").
+		Find(&objectTypes).Error
+	if err != nil {
+		logrus.Error(err.Error())
+		return nil, err
+	}
+
+	return objectTypes, nil
+}
+
 func (r *objectTypeRepository) FindByID(ctx context.Context, id string) (*model.ObjectType, error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
@@ -55,10 +79,11 @@ func (r *objectTypeRepository) FindByID(ctx context.Context, id string) (*model.
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	objectType := new(model.ObjectType)
-	cacheKey := model.NewObjectTypeCacheKeyByID(id)
+	cacheKey := model.NewObjectTypeCacheKeyByID(tenantID, id)
 
-	cachedData, err := Get(ctx, r.redisClient, cacheKey)
+	cachedData, err := Get(ctx, r.cache, metrics.RepositoryObjectType, cacheKey)
 	if err != nil {
 		logger.Error(err.Error())
 	}
@@ -67,26 +92,28 @@ func (r *objectTypeRepository) FindByID(ctx context.Context, id string) (*model.
 		return objectType, nil
 	}
 
-	objectType = new(model.ObjectType)
-
-	err = db.WithContext(ctx).First(objectType, "id = ?", id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = SetWithExpiry(ctx, r.redisClient, cacheKey, nil)
-			if err != nil {
-				logger.Error(err.Error())
+	return loadOnce(ctx, &r.loadGroup, cacheKey, func(ctx context.Context) (*model.ObjectType, error) {
+		objectType := new(model.ObjectType)
+
+		err := db.WithContext(ctx).First(objectType, "id = ? AND tenant_id = ?", id, tenantID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = SetWithExpiry(ctx, r.cache, cacheKey, nil, model.NewObjectTypeCacheTag(tenantID, id))
+				if err != nil {
+					logger.Error(err.Error())
+				}
+				return nil, nil
 			}
-			return nil, nil
+			return nil, err
 		}
-		return nil, err
-	}
 
-	err = SetWithExpiry(ctx, r.redisClient, cacheKey, objectType)
-	if err != nil {
-		logger.Error(err.Error())
-	}
+		err = SetWithExpiry(ctx, r.cache, cacheKey, objectType, model.NewObjectTypeCacheTag(tenantID, objectType.ID))
+		if err != nil {
+			logger.Error(err.Error())
+		}
 
-	return objectType, nil
+		return objectType, nil
+	})
 }
 
 func (r *objectTypeRepository) FindByName(ctx context.Context, name string) (*model.ObjectType, error) {
@@ -99,10 +126,11 @@ func (r *objectTypeRepository) FindByName(ctx context.Context, name string) (*mo
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	objectType := new(model.ObjectType)
-	cacheKey := model.NewObjectTypeCacheKeyByName(name)
+	cacheKey := model.NewObjectTypeCacheKeyByName(tenantID, name)
 
-	cachedData, err := Get(ctx, r.redisClient, cacheKey)
+	cachedData, err := Get(ctx, r.cache, metrics.RepositoryObjectType, cacheKey)
 	if err != nil {
 		logger.Error(err.Error())
 	}
@@ -111,26 +139,65 @@ func (r *objectTypeRepository) FindByName(ctx context.Context, name string) (*mo
 		return objectType, nil
 	}
 
-	objectType = new(model.ObjectType)
-
-	err = db.WithContext(ctx).First(objectType, "name = ?", name).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = SetWithExpiry(ctx, r.redisClient, cacheKey, nil)
-			if err != nil {
-				logger.Error(err.Error())
+	return loadOnce(ctx, &r.loadGroup, cacheKey, func(ctx context.Context) (*model.ObjectType, error) {
+		objectType := new(model.ObjectType)
+
+		err := db.WithContext(ctx).First(objectType, "name = ? AND tenant_id = ?", name, tenantID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = SetWithExpiry(ctx, r.cache, cacheKey, nil)
+				if err != nil {
+					logger.Error(err.Error())
+				}
+				return nil, nil
 			}
-			return nil, nil
+			return nil, err
 		}
-		return nil, err
-	}
 
-	err = SetWithExpiry(ctx, r.redisClient, cacheKey, objectType)
-	if err != nil {
-		logger.Error(err.Error())
+		err = SetWithExpiry(ctx, r.cache, cacheKey, objectType, model.NewObjectTypeCacheTag(tenantID, objectType.ID))
+		if err != nil {
+			logger.Error(err.Error())
+		}
+
+		return objectType, nil
+	})
+}
+
+func (r *objectTypeRepository) Update(ctx context.Context, objectType *model.ObjectType) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id":   objectType.ID,
+		"name": objectType.Name,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	objectType.TenantID = utils.GetTenantIDFromContext(ctx)
+
+	res := db.WithContext(ctx).Model(&model.ObjectType{}).
+		Where("id = ? AND tenant_id = ?", objectType.ID, objectType.TenantID).
+		Updates(map[string]any{
+			"bucket":        objectType.Bucket,
+			"key_template":  objectType.KeyTemplate,
+			"storage_class": objectType.StorageClass,
+			"cache_control": objectType.CacheControl,
+			"encrypted":     objectType.Encrypted,
+		})
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrObjectTypeNotFound
 	}
 
-	return objectType, nil
+	// cached objects of the type carry its routing, they go with the type tag
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectTypeCacheKeys(objectType.TenantID, objectType.ID, objectType.Name))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectTypeCacheTag(objectType.TenantID, objectType.ID)})
+
+	return nil
 }
 
 func (r *objectTypeRepository) DeleteByID(ctx context.Context, id string) error {
@@ -143,17 +210,42 @@ func (r *objectTypeRepository) DeleteByID(ctx context.Context, id string) error
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	objectType := new(model.ObjectType)
 
 	err := db.WithContext(ctx).Clauses(clause.Returning{}).
-		Where("id = ?", id).
+		Where("id = ? AND tenant_id = ?", id, tenantID).
 		Delete(objectType).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectTypeCacheKeys(objectType.ID, objectType.Name))
+	// dropping the type tag also drops the whitelist and objects cascaded by the delete
+	_ = DeleteByKeys(ctx, r.cache, model.GetObjectTypeCacheKeys(tenantID, id, objectType.Name))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectTypeCacheTag(tenantID, id)})
 
 	return nil
 }
+
+// FindBuckets is a system query and spans every tenant.
+func (r *objectTypeRepository) FindBuckets(ctx context.Context) ([]string, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	buckets := make([]string, 0)
+
+	err := db.WithContext(ctx).Model(&model.ObjectType{}).
+		Where("bucket <> ''").
+		Distinct().
+		Order("bucket").
+		Pluck("bucket", &buckets).Error
+	if err != nil {
+		logrus.Error(err.Error())
+		return nil, err
+	}
+
+	return buckets, nil
+}
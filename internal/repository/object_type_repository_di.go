@@ -3,7 +3,7 @@ package repository
 import (
 	"errors"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/krobus00/storage-service/internal/model"
 	"gorm.io/gorm"
 )
 
@@ -15,10 +15,10 @@ func (r *objectTypeRepository) InjectDB(db *gorm.DB) error {
 	return nil
 }
 
-func (r *objectTypeRepository) InjectRedisClient(client *redis.Client) error {
-	if client == nil {
-		return errors.New("invalid redis client")
+func (r *objectTypeRepository) InjectCache(cache model.Cache) error {
+	if cache == nil {
+		return errors.New("invalid cache")
 	}
-	r.redisClient = client
+	r.cache = cache
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/constant"
 	"github.com/krobus00/storage-service/internal/infrastructure"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
@@ -25,7 +27,7 @@ func newObjectTypeRepoMock(t *testing.T) (model.ObjectTypeRepository, sqlmock.Sq
 	objectTypeRepo := NewObjectTypeRepository()
 	err = objectTypeRepo.InjectDB(dbConn)
 	utils.ContinueOrFatal(err)
-	err = objectTypeRepo.InjectRedisClient(redisClient)
+	err = objectTypeRepo.InjectCache(infrastructure.NewRedisCache(redisClient))
 	utils.ContinueOrFatal(err)
 
 	return objectTypeRepo, dbMock, miniRedis
@@ -75,7 +77,7 @@ func Test_objectTypeRepository_Create(t *testing.T) {
 
 			dbMock.ExpectBegin()
 			dbMock.ExpectExec("INSERT INTO \"object_types\"").
-				WithArgs(tt.args.objectType.ID, tt.args.objectType.Name).
+				WithArgs(tt.args.objectType.ID, constant.DefaultTenantID, tt.args.objectType.Name, tt.args.objectType.Bucket, tt.args.objectType.KeyTemplate, tt.args.objectType.StorageClass, tt.args.objectType.CacheControl, tt.args.objectType.Encrypted).
 				WillReturnResult(sqlmock.NewResult(1, 1)).
 				WillReturnError(tt.mockErr)
 
@@ -92,6 +94,79 @@ func Test_objectTypeRepository_Create(t *testing.T) {
 	}
 }
 
+func Test_objectTypeRepository_FindAll(t *testing.T) {
+	var (
+		imageTypeID    = utils.GenerateUUID()
+		documentTypeID = utils.GenerateUUID()
+	)
+	type mockSelect struct {
+		objectTypes []*model.ObjectType
+		err         error
+	}
+	tests := []struct {
+		name       string
+		mockSelect *mockSelect
+		want       []*model.ObjectType
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			mockSelect: &mockSelect{
+				objectTypes: []*model.ObjectType{
+					{ID: documentTypeID, Name: "document"},
+					{ID: imageTypeID, Name: "image"},
+				},
+			},
+			want: []*model.ObjectType{
+				{ID: documentTypeID, Name: "document"},
+				{ID: imageTypeID, Name: "image"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success empty",
+			mockSelect: &mockSelect{
+				objectTypes: []*model.ObjectType{},
+			},
+			want:    []*model.ObjectType{},
+			wantErr: false,
+		},
+		{
+			name: "error find object types",
+			mockSelect: &mockSelect{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, _ := newObjectTypeRepoMock(t)
+
+			row := sqlmock.NewRows([]string{"id", "name"})
+			for _, objectType := range tt.mockSelect.objectTypes {
+				row.AddRow(objectType.ID, objectType.Name)
+			}
+
+			dbMock.ExpectQuery("^SELECT .+ FROM \"object_types\" WHERE tenant_id = .+ ORDER BY name ASC").
+				WithArgs(constant.DefaultTenantID).
+				WillReturnRows(row).
+				WillReturnError(tt.mockSelect.err)
+
+			got, err := r.FindAll(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectTypeRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectTypeRepository.FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_objectTypeRepository_FindByID(t *testing.T) {
 	var (
 		objectTypeID = utils.GenerateUUID()
@@ -181,7 +256,7 @@ func Test_objectTypeRepository_FindByID(t *testing.T) {
 			ctx := context.TODO()
 			r, dbMock, redisMock := newObjectTypeRepoMock(t)
 
-			cacheKey := model.NewObjectTypeCacheKeyByID(tt.args.id)
+			cacheKey := model.NewObjectTypeCacheKeyByID(constant.DefaultTenantID, tt.args.id)
 			if tt.mockSelect != nil {
 				row := sqlmock.NewRows([]string{"id", "name"})
 				if tt.mockSelect.objectType != nil {
@@ -193,7 +268,7 @@ func Test_objectTypeRepository_FindByID(t *testing.T) {
 				}
 
 				dbMock.ExpectQuery("^SELECT .+ FROM \"object_types\"").
-					WithArgs(tt.args.id).
+					WithArgs(tt.args.id, constant.DefaultTenantID).
 					WillReturnRows(row).
 					WillReturnError(tt.mockSelect.err)
 			}
@@ -306,7 +381,7 @@ func Test_objectTypeRepository_FindByName(t *testing.T) {
 			ctx := context.TODO()
 			r, dbMock, redisMock := newObjectTypeRepoMock(t)
 
-			cacheKey := model.NewObjectTypeCacheKeyByName(tt.args.name)
+			cacheKey := model.NewObjectTypeCacheKeyByName(constant.DefaultTenantID, tt.args.name)
 			if tt.mockSelect != nil {
 				row := sqlmock.NewRows([]string{"id", "name"})
 				if tt.mockSelect.objectType != nil {
@@ -318,7 +393,7 @@ func Test_objectTypeRepository_FindByName(t *testing.T) {
 				}
 
 				dbMock.ExpectQuery("^SELECT .+ FROM \"object_types\"").
-					WithArgs(tt.args.name).
+					WithArgs(tt.args.name, constant.DefaultTenantID).
 					WillReturnRows(row).
 					WillReturnError(tt.mockSelect.err)
 			}
@@ -342,6 +417,98 @@ func Test_objectTypeRepository_FindByName(t *testing.T) {
 	}
 }
 
+func Test_objectTypeRepository_Update(t *testing.T) {
+	var (
+		objectTypeID = utils.GenerateUUID()
+	)
+	type args struct {
+		objectType *model.ObjectType
+	}
+	tests := []struct {
+		name         string
+		args         args
+		rowsAffected int64
+		mockErr      error
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{
+				objectType: &model.ObjectType{
+					ID:           objectTypeID,
+					Name:         "image",
+					Bucket:       "media",
+					KeyTemplate:  "{type}/{yyyy}/{mm}/{uuid}{ext}",
+					StorageClass: "STANDARD_IA",
+					CacheControl: "public, max-age=3600",
+					Encrypted:    true,
+				},
+			},
+			rowsAffected: 1,
+			mockErr:      nil,
+			wantErr:      false,
+		},
+		{
+			name: "error object type not found",
+			args: args{
+				objectType: &model.ObjectType{
+					ID:   objectTypeID,
+					Name: "image",
+				},
+			},
+			rowsAffected: 0,
+			mockErr:      nil,
+			wantErr:      true,
+		},
+		{
+			name: "error update object type",
+			args: args{
+				objectType: &model.ObjectType{
+					ID:   objectTypeID,
+					Name: "image",
+				},
+			},
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjectTypeRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			objectID := utils.GenerateUUID()
+			typeCacheKey := model.NewObjectTypeCacheKeyByName(constant.DefaultTenantID, tt.args.objectType.Name)
+			objectCacheKey := model.NewObjectCacheKey(constant.DefaultTenantID, objectID)
+			utils.ContinueOrFatal(SetWithExpiry(ctx, cache, typeCacheKey, model.ObjectType{ID: tt.args.objectType.ID}, model.NewObjectTypeCacheTag(constant.DefaultTenantID, tt.args.objectType.ID)))
+			utils.ContinueOrFatal(SetWithExpiry(ctx, cache, objectCacheKey, model.Object{ID: objectID}, model.GetObjectCacheTags(constant.DefaultTenantID, objectID, tt.args.objectType.ID)...))
+
+			objectType := tt.args.objectType
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"object_types\"").
+				WithArgs(objectType.Bucket, objectType.CacheControl, objectType.Encrypted, objectType.KeyTemplate, objectType.StorageClass, objectType.ID, constant.DefaultTenantID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.mockErr)
+			if tt.mockErr != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Update(ctx, objectType); (err != nil) != tt.wantErr {
+				t.Errorf("objectTypeRepository.Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if redisMock.Exists(typeCacheKey) != tt.wantErr {
+				t.Errorf("objectTypeRepository.Update() cache exists = %v, want %v", !tt.wantErr, tt.wantErr)
+			}
+			if redisMock.Exists(objectCacheKey) != tt.wantErr {
+				t.Errorf("objectTypeRepository.Update() object cache exists = %v, want %v", !tt.wantErr, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_objectTypeRepository_DeleteByID(t *testing.T) {
 	var (
 		objectTypeID = utils.GenerateUUID()
@@ -374,7 +541,18 @@ func Test_objectTypeRepository_DeleteByID(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r, dbMock, _ := newObjectTypeRepoMock(t)
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjectTypeRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			// seed entries that depend on the type, deleting the type cascades to them
+			objectID := utils.GenerateUUID()
+			typeCacheKey := model.NewObjectTypeCacheKeyByID(constant.DefaultTenantID, tt.args.id)
+			objectCacheKey := model.NewObjectCacheKey(constant.DefaultTenantID, objectID)
+			whitelistCacheKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(constant.DefaultTenantID, tt.args.id), ".jpg")
+			utils.ContinueOrFatal(SetWithExpiry(ctx, cache, typeCacheKey, model.ObjectType{ID: tt.args.id}, model.NewObjectTypeCacheTag(constant.DefaultTenantID, tt.args.id)))
+			utils.ContinueOrFatal(SetWithExpiry(ctx, cache, objectCacheKey, model.Object{ID: objectID}, model.GetObjectCacheTags(constant.DefaultTenantID, objectID, tt.args.id)...))
+			utils.ContinueOrFatal(HSetWithExpiry(ctx, cache, whitelistCacheKey, ".jpg", nil, model.GetObjectWhitelistTypeCacheTags(constant.DefaultTenantID, tt.args.id)...))
 
 			dbMock.ExpectBegin()
 			row := sqlmock.NewRows([]string{"id", "name"})
@@ -382,7 +560,7 @@ func Test_objectTypeRepository_DeleteByID(t *testing.T) {
 			row.AddRow(tt.args.id, "image")
 
 			dbMock.ExpectQuery("DELETE FROM \"object_types\"").
-				WithArgs(tt.args.id).
+				WithArgs(tt.args.id, constant.DefaultTenantID).
 				WillReturnRows(row).
 				WillReturnError(tt.mockErr)
 
@@ -391,9 +569,34 @@ func Test_objectTypeRepository_DeleteByID(t *testing.T) {
 			} else {
 				dbMock.ExpectCommit()
 			}
-			if err := r.DeleteByID(context.TODO(), tt.args.id); (err != nil) != tt.wantErr {
+			if err := r.DeleteByID(ctx, tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("objectTypeRepository.DeleteByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			for _, cacheKey := range []string{typeCacheKey, objectCacheKey, whitelistCacheKey} {
+				if redisMock.Exists(cacheKey) != tt.wantErr {
+					t.Errorf("objectTypeRepository.DeleteByID() cache %s exists = %v, want %v", cacheKey, !tt.wantErr, tt.wantErr)
+				}
+			}
 		})
 	}
 }
+
+func Test_objectTypeRepository_FindBuckets(t *testing.T) {
+	r, dbMock, _ := newObjectTypeRepoMock(t)
+
+	dbMock.ExpectQuery("^SELECT DISTINCT \"bucket\" FROM \"object_types\" WHERE bucket <> ''").
+		WillReturnRows(sqlmock.NewRows([]string{"bucket"}).AddRow("archive").AddRow("media"))
+
+	buckets, err := r.FindBuckets(context.TODO())
+	if err != nil {
+		t.Errorf("objectTypeRepository.FindBuckets() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(buckets, []string{"archive", "media"}) {
+		t.Errorf("objectTypeRepository.FindBuckets() = %v", buckets)
+	}
+	if err := dbMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
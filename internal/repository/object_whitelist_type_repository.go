@@ -4,18 +4,20 @@ import (
 	"context"
 	"errors"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type objectWhitelistTypeRepository struct {
-	db          *gorm.DB
-	redisClient *redis.Client
+	db        *gorm.DB
+	cache     model.Cache
+	loadGroup singleflight.Group
 }
 
 func NewObjectWhitelistTypeRepository() model.ObjectWhitelistTypeRepository {
@@ -33,13 +35,15 @@ func (r *objectWhitelistTypeRepository) Create(ctx context.Context, objectWhitel
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	objectWhitelistType.TenantID = utils.GetTenantIDFromContext(ctx)
+
 	err := db.WithContext(ctx).Create(objectWhitelistType).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectWhitelistTypCacheKeys(objectWhitelistType.TypeID))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectWhitelistTypeCacheTag(objectWhitelistType.TenantID, objectWhitelistType.TypeID)})
 
 	return nil
 }
@@ -55,10 +59,11 @@ func (r *objectWhitelistTypeRepository) FindByTypeIDAndExt(ctx context.Context,
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	objectWhitelistType := new(model.ObjectWhitelistType)
-	cacheBucketKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(typeID), ext)
+	cacheBucketKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(tenantID, typeID), ext)
 
-	cachedData, err := HGet(ctx, r.redisClient, cacheBucketKey, ext)
+	cachedData, err := HGet(ctx, r.cache, metrics.RepositoryObjectWhitelistType, cacheBucketKey, ext)
 	if err != nil {
 		logger.Error(err.Error())
 	}
@@ -67,28 +72,30 @@ func (r *objectWhitelistTypeRepository) FindByTypeIDAndExt(ctx context.Context,
 		return objectWhitelistType, nil
 	}
 
-	objectWhitelistType = new(model.ObjectWhitelistType)
-
-	err = db.WithContext(ctx).
-		First(objectWhitelistType, "type_id = ? AND extension = ?", typeID, ext).
-		Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = HSetWithExpiry(ctx, r.redisClient, cacheBucketKey, ext, nil)
-			if err != nil {
-				logger.Error(err.Error())
+	return loadOnce(ctx, &r.loadGroup, cacheBucketKey+":"+ext, func(ctx context.Context) (*model.ObjectWhitelistType, error) {
+		objectWhitelistType := new(model.ObjectWhitelistType)
+
+		err := db.WithContext(ctx).
+			First(objectWhitelistType, "tenant_id = ? AND type_id = ? AND extension = ?", tenantID, typeID, ext).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = HSetWithExpiry(ctx, r.cache, cacheBucketKey, ext, nil, model.GetObjectWhitelistTypeCacheTags(tenantID, typeID)...)
+				if err != nil {
+					logger.Error(err.Error())
+				}
+				return nil, nil
 			}
-			return nil, nil
+			return nil, err
 		}
-		return nil, err
-	}
 
-	err = HSetWithExpiry(ctx, r.redisClient, cacheBucketKey, ext, objectWhitelistType)
-	if err != nil {
-		logger.Error(err.Error())
-	}
+		err = HSetWithExpiry(ctx, r.cache, cacheBucketKey, ext, objectWhitelistType, model.GetObjectWhitelistTypeCacheTags(tenantID, typeID)...)
+		if err != nil {
+			logger.Error(err.Error())
+		}
 
-	return objectWhitelistType, nil
+		return objectWhitelistType, nil
+	})
 }
 
 func (r *objectWhitelistTypeRepository) DeleteByTypeIDAndExt(ctx context.Context, typeID string, ext string) error {
@@ -102,17 +109,18 @@ func (r *objectWhitelistTypeRepository) DeleteByTypeIDAndExt(ctx context.Context
 	})
 
 	db := utils.GetTxFromContext(ctx, r.db)
+	tenantID := utils.GetTenantIDFromContext(ctx)
 	objectWhitelistType := new(model.ObjectWhitelistType)
 
 	err := db.WithContext(ctx).Clauses(clause.Returning{}).
-		Where("type_id = ? AND extension = ?", typeID, ext).
+		Where("tenant_id = ? AND type_id = ? AND extension = ?", tenantID, typeID, ext).
 		Delete(objectWhitelistType).Error
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
-	_ = DeleteByKeys(ctx, r.redisClient, model.GetObjectWhitelistTypCacheKeys(objectWhitelistType.TypeID))
+	_ = InvalidateTags(ctx, r.cache, []string{model.NewObjectWhitelistTypeCacheTag(tenantID, typeID)})
 
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
 	"github.com/goccy/go-json"
+	"github.com/krobus00/storage-service/internal/constant"
 	"github.com/krobus00/storage-service/internal/infrastructure"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
@@ -26,7 +28,7 @@ func newObjecteWhitelistTypeRepoMock(t *testing.T) (model.ObjectWhitelistTypeRep
 	objectWhitelistTypeRepo := NewObjectWhitelistTypeRepository()
 	err = objectWhitelistTypeRepo.InjectDB(dbConn)
 	utils.ContinueOrFatal(err)
-	err = objectWhitelistTypeRepo.InjectRedisClient(redisClient)
+	err = objectWhitelistTypeRepo.InjectCache(infrastructure.NewRedisCache(redisClient))
 	utils.ContinueOrFatal(err)
 
 	return objectWhitelistTypeRepo, dbMock, miniRedis
@@ -76,7 +78,7 @@ func Test_objectWhitelistTypeRepository_Create(t *testing.T) {
 
 			dbMock.ExpectBegin()
 			dbMock.ExpectExec("INSERT INTO \"object_whitelist_types\"").
-				WithArgs(tt.args.objectWhitelistType.TypeID, tt.args.objectWhitelistType.Extension).
+				WithArgs(constant.DefaultTenantID, tt.args.objectWhitelistType.TypeID, tt.args.objectWhitelistType.Extension).
 				WillReturnResult(sqlmock.NewResult(1, 1)).
 				WillReturnError(tt.mockErr)
 
@@ -182,7 +184,7 @@ func Test_objectWhitelistTypeRepository_FindByTypeIDAndExt(t *testing.T) {
 			ctx := context.TODO()
 			r, dbMock, redisMock := newObjecteWhitelistTypeRepoMock(t)
 
-			cacheBucketKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(tt.args.typeID), tt.args.ext)
+			cacheBucketKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(constant.DefaultTenantID, tt.args.typeID), tt.args.ext)
 
 			if tt.mockSelect != nil {
 				row := sqlmock.NewRows([]string{"type_id", "extension"})
@@ -195,7 +197,7 @@ func Test_objectWhitelistTypeRepository_FindByTypeIDAndExt(t *testing.T) {
 				}
 
 				dbMock.ExpectQuery("^SELECT .+ FROM \"object_whitelist_types\"").
-					WithArgs(tt.args.typeID, tt.args.ext).
+					WithArgs(constant.DefaultTenantID, tt.args.typeID, tt.args.ext).
 					WillReturnRows(row).
 					WillReturnError(tt.mockSelect.err)
 			}
@@ -254,7 +256,17 @@ func Test_objectWhitelistTypeRepository_DeleteByTypeIDAndExt(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r, dbMock, _ := newObjecteWhitelistTypeRepoMock(t)
+			ctx := context.TODO()
+			r, dbMock, redisMock := newObjecteWhitelistTypeRepoMock(t)
+			cache := infrastructure.NewRedisCache(redis.NewClient(&redis.Options{Addr: redisMock.Addr()}))
+
+			// one cached bucket per extension, all of them must be dropped
+			cacheBucketKeys := []string{}
+			for _, ext := range []string{tt.args.ext, ".png"} {
+				cacheBucketKey := utils.NewBucketKey(model.NewObjectWhitelistTypeCacheKey(constant.DefaultTenantID, tt.args.typeID), ext)
+				cacheBucketKeys = append(cacheBucketKeys, cacheBucketKey)
+				utils.ContinueOrFatal(HSetWithExpiry(ctx, cache, cacheBucketKey, ext, nil, model.GetObjectWhitelistTypeCacheTags(constant.DefaultTenantID, tt.args.typeID)...))
+			}
 
 			dbMock.ExpectBegin()
 			row := sqlmock.NewRows([]string{"type_id", "extension"})
@@ -262,7 +274,7 @@ func Test_objectWhitelistTypeRepository_DeleteByTypeIDAndExt(t *testing.T) {
 			row.AddRow(tt.args.typeID, tt.args.ext)
 
 			dbMock.ExpectQuery("DELETE FROM \"object_whitelist_types\"").
-				WithArgs(tt.args.typeID, tt.args.ext).
+				WithArgs(constant.DefaultTenantID, tt.args.typeID, tt.args.ext).
 				WillReturnRows(row).
 				WillReturnError(tt.mockErr)
 
@@ -271,9 +283,15 @@ func Test_objectWhitelistTypeRepository_DeleteByTypeIDAndExt(t *testing.T) {
 			} else {
 				dbMock.ExpectCommit()
 			}
-			if err := r.DeleteByTypeIDAndExt(context.TODO(), tt.args.typeID, tt.args.ext); (err != nil) != tt.wantErr {
+			if err := r.DeleteByTypeIDAndExt(ctx, tt.args.typeID, tt.args.ext); (err != nil) != tt.wantErr {
 				t.Errorf("objectWhitelistTypeRepository.DeleteByTypeIDAndExt() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			for _, cacheBucketKey := range cacheBucketKeys {
+				if redisMock.Exists(cacheBucketKey) != tt.wantErr {
+					t.Errorf("objectWhitelistTypeRepository.DeleteByTypeIDAndExt() cache %s exists = %v, want %v", cacheBucketKey, !tt.wantErr, tt.wantErr)
+				}
+			}
 		})
 	}
 }
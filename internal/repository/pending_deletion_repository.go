@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type pendingDeletionRepository struct {
+	db *gorm.DB
+}
+
+func NewPendingDeletionRepository() model.PendingDeletionRepository {
+	return new(pendingDeletionRepository)
+}
+
+// createPendingDeletion records a blob to delete later, it is used by the write
+// paths that fail after the content was stored.
+func createPendingDeletion(db *gorm.DB, deletion *model.PendingDeletion) error {
+	return db.Create(deletion).Error
+}
+
+func (r *pendingDeletionRepository) Create(ctx context.Context, deletion *model.PendingDeletion) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"bucket": deletion.Bucket,
+		"key":    deletion.Key,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := createPendingDeletion(db.WithContext(ctx), deletion)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *pendingDeletionRepository) FindAll(ctx context.Context, limit int) ([]*model.PendingDeletion, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"limit": limit,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	deletions := make([]*model.PendingDeletion, 0)
+
+	err := db.WithContext(ctx).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&deletions).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return deletions, nil
+}
+
+func (r *pendingDeletionRepository) MarkFailed(ctx context.Context, id string, cause error) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Model(&model.PendingDeletion{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause.Error(),
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *pendingDeletionRepository) DeleteByID(ctx context.Context, id string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+
+	err := db.WithContext(ctx).Where("id = ?", id).Delete(&model.PendingDeletion{}).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func newPendingDeletionRepoMock() (model.PendingDeletionRepository, sqlmock.Sqlmock) {
+	dbConn, dbMock := utils.NewDBMock()
+	pendingDeletionRepo := NewPendingDeletionRepository()
+	err := pendingDeletionRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+
+	return pendingDeletionRepo, dbMock
+}
+
+func Test_pendingDeletionRepository_FindAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockErr error
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "error find pending deletions",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newPendingDeletionRepoMock()
+
+			rows := sqlmock.NewRows([]string{"id", "bucket", "key"}).
+				AddRow(utils.GenerateUUID(), "media", "first").
+				AddRow(utils.GenerateUUID(), "media", "second")
+			dbMock.ExpectQuery("^SELECT \\* FROM \"pending_deletions\" ORDER BY updated_at ASC, id ASC LIMIT 10").
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindAll(context.TODO(), 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pendingDeletionRepository.FindAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("pendingDeletionRepository.FindAll() = %v, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_pendingDeletionRepository_MarkFailed(t *testing.T) {
+	id := utils.GenerateUUID()
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "error update pending deletion",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newPendingDeletionRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"pending_deletions\" SET \"attempts\"=attempts \\+ 1,\"last_error\"=\\$1,\"updated_at\"=NOW\\(\\) WHERE id = \\$2").
+				WithArgs("s3 error", id).
+				WillReturnResult(sqlmock.NewResult(0, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.MarkFailed(context.TODO(), id, errors.New("s3 error")); (err != nil) != tt.wantErr {
+				t.Errorf("pendingDeletionRepository.MarkFailed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_pendingDeletionRepository_Create(t *testing.T) {
+	deletion := &model.PendingDeletion{
+		ID:        utils.GenerateUUID(),
+		Bucket:    "bucket",
+		Key:       "key",
+		Reason:    "temporary object purged",
+		LastError: "s3 error",
+	}
+	tests := []struct {
+		name    string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "error insert pending deletion",
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, dbMock := newPendingDeletionRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO \"pending_deletions\"").
+				WithArgs(deletion.ID, deletion.Bucket, deletion.Key, deletion.Reason, sqlmock.AnyArg(), deletion.LastError, sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1)).
+				WillReturnError(tt.mockErr)
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Create(context.TODO(), deletion); (err != nil) != tt.wantErr {
+				t.Errorf("pendingDeletionRepository.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// shareLinkRepository is not cached, the download count changes on every use.
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository() model.ShareLinkRepository {
+	return new(shareLinkRepository)
+}
+
+func (r *shareLinkRepository) Create(ctx context.Context, shareLink *model.ShareLink) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": shareLink.ObjectID,
+		"id":       shareLink.ID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	shareLink.TenantID = utils.GetTenantIDFromContext(ctx)
+
+	err := db.WithContext(ctx).Create(shareLink).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *shareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.ShareLink, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	// the token is the credential, the link carries its tenant for the resolution
+	db := utils.GetTxFromContext(ctx, r.db)
+	shareLink := new(model.ShareLink)
+
+	err := db.WithContext(ctx).First(shareLink, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		logrus.Error(err.Error())
+		return nil, err
+	}
+
+	return shareLink, nil
+}
+
+func (r *shareLinkRepository) FindByObjectID(ctx context.Context, objectID string) ([]*model.ShareLink, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	shareLinks := make([]*model.ShareLink, 0)
+
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND object_id = ?", utils.GetTenantIDFromContext(ctx), objectID).
+		Order("created_at DESC").
+		Find(&shareLinks).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return shareLinks, nil
+}
+
+// CountDownload checks and counts the use in one statement so concurrent
+// downloads can never exceed the limit.
+func (r *shareLinkRepository) CountDownload(ctx context.Context, id string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	res := db.WithContext(ctx).
+		Model(new(model.ShareLink)).
+		Where("id = ? AND revoked_at IS NULL AND expired_at > ?", id, time.Now()).
+		Where("max_downloads = 0 OR download_count < max_downloads").
+		UpdateColumn("download_count", gorm.Expr("download_count + 1"))
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrShareLinkUnavailable
+	}
+
+	return nil
+}
+
+// CountFailedAttempt counts in one statement so concurrent guesses can not
+// slip past the limit, reaching it locks the link and starts a new count.
+func (r *shareLinkRepository) CountFailedAttempt(ctx context.Context, id string, maxAttempts int, lockout time.Duration) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"id": id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	err := db.WithContext(ctx).
+		Model(new(model.ShareLink)).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"failed_attempts": gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN 0 ELSE failed_attempts + 1 END", maxAttempts),
+			"locked_until":    gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ? ELSE locked_until END", maxAttempts, time.Now().Add(lockout)),
+		}).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *shareLinkRepository) Revoke(ctx context.Context, objectID string, id string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+		"id":       id,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	res := db.WithContext(ctx).
+		Model(new(model.ShareLink)).
+		Where("tenant_id = ? AND object_id = ? AND id = ? AND revoked_at IS NULL", utils.GetTenantIDFromContext(ctx), objectID, id).
+		UpdateColumn("revoked_at", time.Now())
+	if res.Error != nil {
+		logger.Error(res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrShareLinkNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (r *shareLinkRepository) InjectDB(db *gorm.DB) error {
+	if db == nil {
+		return errors.New("invalid db")
+	}
+	r.db = db
+	return nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func newShareLinkRepoMock() (model.ShareLinkRepository, sqlmock.Sqlmock) {
+	dbConn, dbMock := utils.NewDBMock()
+	shareLinkRepo := NewShareLinkRepository()
+	err := shareLinkRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+
+	return shareLinkRepo, dbMock
+}
+
+func Test_shareLinkRepository_FindByTokenHash(t *testing.T) {
+	var (
+		shareLinkID = utils.GenerateUUID()
+		objectID    = utils.GenerateUUID()
+		tokenHash   = model.HashShareToken("token")
+	)
+	type mockSelect struct {
+		shareLink *model.ShareLink
+		err       error
+	}
+	tests := []struct {
+		name       string
+		mockSelect *mockSelect
+		want       *model.ShareLink
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			mockSelect: &mockSelect{
+				shareLink: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, TokenHash: tokenHash},
+			},
+			want:    &model.ShareLink{ID: shareLinkID, ObjectID: objectID, TokenHash: tokenHash},
+			wantErr: false,
+		},
+		{
+			name:       "success not found",
+			mockSelect: &mockSelect{},
+			want:       nil,
+			wantErr:    false,
+		},
+		{
+			name: "error find share link",
+			mockSelect: &mockSelect{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newShareLinkRepoMock()
+
+			row := sqlmock.NewRows([]string{"id", "object_id", "token_hash"})
+			if tt.mockSelect.shareLink != nil {
+				row.AddRow(tt.mockSelect.shareLink.ID, tt.mockSelect.shareLink.ObjectID, tt.mockSelect.shareLink.TokenHash)
+			}
+
+			dbMock.ExpectQuery("^SELECT .+ FROM \"share_links\" WHERE token_hash = ").
+				WithArgs(tokenHash).
+				WillReturnRows(row).
+				WillReturnError(tt.mockSelect.err)
+
+			got, err := r.FindByTokenHash(ctx, tokenHash)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("shareLinkRepository.FindByTokenHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shareLinkRepository.FindByTokenHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shareLinkRepository_CountDownload(t *testing.T) {
+	var (
+		shareLinkID = utils.GenerateUUID()
+	)
+	type mockUpdate struct {
+		rowsAffected int64
+		err          error
+	}
+	tests := []struct {
+		name       string
+		mockUpdate *mockUpdate
+		wantErr    error
+	}{
+		{
+			name: "success",
+			mockUpdate: &mockUpdate{
+				rowsAffected: 1,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "error share link used up",
+			mockUpdate: &mockUpdate{
+				rowsAffected: 0,
+			},
+			wantErr: model.ErrShareLinkUnavailable,
+		},
+		{
+			name: "error update share link",
+			mockUpdate: &mockUpdate{
+				err: errors.New("db error"),
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newShareLinkRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"share_links\" SET \"download_count\"=download_count \\+ 1 WHERE \\(id = .+ AND revoked_at IS NULL AND expired_at > .+\\) AND \\(max_downloads = 0 OR download_count < max_downloads\\)").
+				WithArgs(shareLinkID, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, tt.mockUpdate.rowsAffected)).
+				WillReturnError(tt.mockUpdate.err)
+			if tt.mockUpdate.err != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err := r.CountDownload(ctx, shareLinkID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("shareLinkRepository.CountDownload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_shareLinkRepository_CountFailedAttempt(t *testing.T) {
+	var (
+		shareLinkID = utils.GenerateUUID()
+	)
+	tests := []struct {
+		name       string
+		mockUpdate error
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			mockUpdate: nil,
+			wantErr:    nil,
+		},
+		{
+			name:       "error update share link",
+			mockUpdate: errors.New("db error"),
+			wantErr:    errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newShareLinkRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"share_links\" SET \"failed_attempts\"=CASE WHEN failed_attempts \\+ 1 >= .+ THEN 0 ELSE failed_attempts \\+ 1 END,\"locked_until\"=CASE WHEN failed_attempts \\+ 1 >= .+ THEN .+ ELSE locked_until END WHERE id = .+").
+				WithArgs(5, 5, sqlmock.AnyArg(), shareLinkID).
+				WillReturnResult(sqlmock.NewResult(0, 1)).
+				WillReturnError(tt.mockUpdate)
+			if tt.mockUpdate != nil {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			err := r.CountFailedAttempt(ctx, shareLinkID, 5, time.Minute)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("shareLinkRepository.CountFailedAttempt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_shareLinkRepository_Revoke(t *testing.T) {
+	var (
+		shareLinkID = utils.GenerateUUID()
+		objectID    = utils.GenerateUUID()
+	)
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "error share link not found",
+			rowsAffected: 0,
+			wantErr:      model.ErrShareLinkNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newShareLinkRepoMock()
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("UPDATE \"share_links\" SET \"revoked_at\"=.+ WHERE tenant_id = .+ AND object_id = .+ AND id = .+ AND revoked_at IS NULL").
+				WithArgs(sqlmock.AnyArg(), constant.DefaultTenantID, objectID, shareLinkID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			dbMock.ExpectCommit()
+
+			err := r.Revoke(ctx, objectID, shareLinkID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("shareLinkRepository.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
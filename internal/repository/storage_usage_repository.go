@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type storageUsageRepository struct {
+	db *gorm.DB
+}
+
+func NewStorageUsageRepository() model.StorageUsageRepository {
+	return new(storageUsageRepository)
+}
+
+func (r *storageUsageRepository) FindByUserID(ctx context.Context, userID string) ([]*model.StorageUsage, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID": userID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	usages := make([]*model.StorageUsage, 0)
+
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND user_id = ?", utils.GetTenantIDFromContext(ctx), userID).
+		Order("type_id").
+		Find(&usages).Error
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+const recomputeStorageUsageQuery = `INSERT INTO storage_usages (tenant_id, user_id, type_id, bytes, objects, updated_at)
+SELECT tenant_id, uploaded_by, type_id, COALESCE(SUM(size), 0), COUNT(*), NOW() FROM objects`
+
+func (r *storageUsageRepository) Recompute(ctx context.Context, userID string) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"tenantID": utils.GetTenantIDFromContext(ctx),
+		"userID":   userID,
+	})
+
+	db := utils.GetTxFromContext(ctx, r.db)
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if userID == "" {
+			err := tx.Exec("DELETE FROM storage_usages").Error
+			if err != nil {
+				return err
+			}
+			return tx.Exec(recomputeStorageUsageQuery + " GROUP BY tenant_id, uploaded_by, type_id").Error
+		}
+
+		tenantID := utils.GetTenantIDFromContext(ctx)
+		err := tx.Exec("DELETE FROM storage_usages WHERE tenant_id = ? AND user_id = ?", tenantID, userID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(recomputeStorageUsageQuery+" WHERE tenant_id = ? AND uploaded_by = ? GROUP BY tenant_id, uploaded_by, type_id", tenantID, userID).Error
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// adjustStorageUsage moves the usage counters of the object owner by sign times the object,
+// it must run in the transaction writing the object.
+func adjustStorageUsage(tx *gorm.DB, object *model.Object, sign int64) error {
+	return tx.Exec(`INSERT INTO storage_usages (tenant_id, user_id, type_id, bytes, objects, updated_at)
+VALUES (?, ?, ?, GREATEST(?, 0), GREATEST(?, 0), NOW())
+ON CONFLICT (tenant_id, user_id, type_id) DO UPDATE SET
+	bytes = GREATEST(storage_usages.bytes + ?, 0),
+	objects = GREATEST(storage_usages.objects + ?, 0),
+	updated_at = NOW()`,
+		object.TenantID, object.UploadedBy, object.TypeID, sign*object.Size, sign, sign*object.Size, sign).Error
+}
+
+// enforceStorageQuota fails with model.ErrQuotaExceeded when the object does not fit the quota,
+// it must run in the transaction writing the object. The advisory locks serialize the uploads
+// of the tenant and of the user until the transaction ends, so concurrent uploads can not all
+// pass on the same usage.
+func enforceStorageQuota(tx *gorm.DB, object *model.Object, quota model.UploadQuota) error {
+	// the tenant lock is always taken first so two uploads can not deadlock
+	if !quota.Tenant.IsUnlimited() {
+		err := checkStorageQuota(tx, quota.Tenant, object.Size, "storage_usages:"+object.TenantID,
+			"tenant_id = ?", object.TenantID)
+		if err != nil {
+			return err
+		}
+	}
+	if !quota.User.IsUnlimited() {
+		err := checkStorageQuota(tx, quota.User, object.Size, "storage_usages:"+object.TenantID+":"+object.UploadedBy,
+			"tenant_id = ? AND user_id = ?", object.TenantID, object.UploadedBy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkStorageQuota(tx *gorm.DB, quota model.StorageQuota, size int64, lockKey string, query string, args ...any) error {
+	err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", lockKey).Error
+	if err != nil {
+		return err
+	}
+
+	var totals struct {
+		Bytes   int64
+		Objects int64
+	}
+	err = tx.Model(new(model.StorageUsage)).
+		Select("COALESCE(SUM(bytes), 0) AS bytes, COALESCE(SUM(objects), 0) AS objects").
+		Where(query, args...).
+		Scan(&totals).Error
+	if err != nil {
+		return err
+	}
+	if !quota.Fits(totals.Bytes, totals.Objects, size) {
+		return model.ErrQuotaExceeded
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func newStorageUsageRepoMock() (model.StorageUsageRepository, sqlmock.Sqlmock) {
+	dbConn, dbMock := utils.NewDBMock()
+	storageUsageRepo := NewStorageUsageRepository()
+	err := storageUsageRepo.InjectDB(dbConn)
+	utils.ContinueOrFatal(err)
+
+	return storageUsageRepo, dbMock
+}
+
+func Test_storageUsageRepository_FindByUserID(t *testing.T) {
+	var (
+		userID    = utils.GenerateUUID()
+		typeID    = utils.GenerateUUID()
+		updatedAt = time.Now()
+	)
+	tests := []struct {
+		name    string
+		mockErr error
+		want    []*model.StorageUsage
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			mockErr: nil,
+			want: []*model.StorageUsage{
+				{
+					TenantID:  constant.DefaultTenantID,
+					UserID:    userID,
+					TypeID:    typeID,
+					Bytes:     2048,
+					Objects:   2,
+					UpdatedAt: updatedAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error find storage usage",
+			mockErr: errors.New("db error"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newStorageUsageRepoMock()
+
+			rows := sqlmock.NewRows([]string{"tenant_id", "user_id", "type_id", "bytes", "objects", "updated_at"}).
+				AddRow(constant.DefaultTenantID, userID, typeID, 2048, 2, updatedAt)
+			dbMock.ExpectQuery("^SELECT \\* FROM \"storage_usages\" WHERE tenant_id = .+ AND user_id = .+ ORDER BY type_id").
+				WithArgs(constant.DefaultTenantID, userID).
+				WillReturnRows(rows).
+				WillReturnError(tt.mockErr)
+
+			got, err := r.FindByUserID(ctx, userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("storageUsageRepository.FindByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storageUsageRepository.FindByUserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_storageUsageRepository_Recompute(t *testing.T) {
+	userID := utils.GenerateUUID()
+	tests := []struct {
+		name    string
+		userID  string
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "success single user",
+			userID:  userID,
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "success every user",
+			userID:  "",
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "error insert storage usage",
+			userID:  userID,
+			mockErr: errors.New("db error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r, dbMock := newStorageUsageRepoMock()
+
+			dbMock.ExpectBegin()
+			if tt.userID == "" {
+				dbMock.ExpectExec("^DELETE FROM storage_usages$").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				dbMock.ExpectExec("INSERT INTO storage_usages .+ FROM objects GROUP BY tenant_id, uploaded_by, type_id").
+					WillReturnResult(sqlmock.NewResult(0, 3)).
+					WillReturnError(tt.mockErr)
+			} else {
+				dbMock.ExpectExec("^DELETE FROM storage_usages WHERE tenant_id = .+ AND user_id = ").
+					WithArgs(constant.DefaultTenantID, tt.userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				dbMock.ExpectExec("INSERT INTO storage_usages .+ FROM objects WHERE tenant_id = .+ AND uploaded_by = .+ GROUP BY tenant_id, uploaded_by, type_id").
+					WithArgs(constant.DefaultTenantID, tt.userID).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(tt.mockErr)
+			}
+			if tt.wantErr {
+				dbMock.ExpectRollback()
+			} else {
+				dbMock.ExpectCommit()
+			}
+
+			if err := r.Recompute(ctx, tt.userID); (err != nil) != tt.wantErr {
+				t.Errorf("storageUsageRepository.Recompute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := dbMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("storageUsageRepository.Recompute() expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (t *Delivery) ListAuditLogs(ctx context.Context, req *pb.ListAuditLogsRequest) (*pb.ListAuditLogsResponse, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	filter, err := model.NewAuditLogFilter(
+		req.GetObjectId(),
+		req.GetActorId(),
+		req.GetFrom(),
+		req.GetTo(),
+		int(req.GetLimit()),
+		int(req.GetOffset()),
+	)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	auditLogs, err := t.auditLogUC.ListAuditLogs(ctx, filter)
+
+	switch err {
+	case nil:
+	case model.ErrInvalidAuditLogFilter:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	res := &pb.ListAuditLogsResponse{
+		AuditLogs: make([]*pb.AuditLog, 0, len(auditLogs)),
+	}
+	for _, auditLog := range auditLogs {
+		res.AuditLogs = append(res.AuditLogs, auditLog.ToGRPCResponse())
+	}
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryTraceInterceptor continues the caller's trace from the incoming metadata.
+func UnaryTraceInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.MD{}
+		}
+		ctx = utils.ExtractTraceContext(ctx, utils.MetadataCarrier(md))
+
+		ctx, span := otel.Tracer("").Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		res, err := handler(ctx, req)
+		if err != nil {
+			span.SetStatus(codes.Error, status.Code(err).String())
+		}
+		return res, err
+	}
+}
+
+// UnaryRequestInfoInterceptor stores the caller address and user agent for the audit trail.
+func UnaryRequestInfoInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		clientIP := ""
+		if forwardedFor := md.Get("x-forwarded-for"); len(forwardedFor) > 0 {
+			clientIP = strings.TrimSpace(strings.Split(forwardedFor[0], ",")[0])
+		} else if p, ok := peer.FromContext(ctx); ok {
+			clientIP = p.Addr.String()
+			if host, _, err := net.SplitHostPort(clientIP); err == nil {
+				clientIP = host
+			}
+		}
+		ctx = context.WithValue(ctx, constant.KeyClientIPCtx, clientIP)
+
+		if userAgent := md.Get("user-agent"); len(userAgent) > 0 {
+			ctx = context.WithValue(ctx, constant.KeyUserAgentCtx, userAgent[0])
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// UnaryTenantInterceptor scopes the call to the tenant of the caller token in the
+// authorization metadata, calls without a token belong to the default tenant.
+func UnaryTenantInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		accessToken := ""
+		if values := md.Get("authorization"); len(values) > 0 {
+			accessToken = strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+		}
+		if accessToken == "" {
+			return handler(utils.NewTenantContext(ctx, ""), req)
+		}
+
+		token, _ := jwt.Parse(accessToken, nil)
+		if token == nil {
+			return nil, status.Error(grpcCodes.Unauthenticated, model.ErrTokenInvalid.Error())
+		}
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return nil, status.Error(grpcCodes.Unauthenticated, model.ErrTokenInvalid.Error())
+		}
+
+		// tokens without a tenant claim belong to the default tenant
+		tenantID, _ := claims["tenantID"].(string)
+		if !model.IsValidTenantID(tenantID) {
+			return nil, status.Error(grpcCodes.Unauthenticated, model.ErrTokenInvalid.Error())
+		}
+
+		return handler(utils.NewTenantContext(ctx, tenantID), req)
+	}
+}
@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (t *Delivery) GrantObjectAccess(ctx context.Context, req *pb.GrantObjectAccessRequest) (*pb.ObjectGrant, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	payload := &model.GrantObjectAccessPayload{
+		ObjectID:    req.GetObjectId(),
+		GranteeType: req.GetGranteeType(),
+		GranteeID:   req.GetGranteeId(),
+		Permission:  req.GetPermission(),
+	}
+	if req.GetExpiredAt() != "" {
+		expiredAt, err := time.Parse(time.RFC3339, req.GetExpiredAt())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, model.ErrInvalidObjectGrant.Error())
+		}
+		payload.ExpiredAt = &expiredAt
+	}
+
+	grant, err := t.objectUC.GrantAccess(ctx, payload)
+	if err != nil {
+		return nil, grantErrorStatus(err)
+	}
+
+	return grant.ToGRPCResponse(), nil
+}
+
+func (t *Delivery) RevokeObjectAccess(ctx context.Context, req *pb.RevokeObjectAccessRequest) (*emptypb.Empty, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err := t.objectUC.RevokeAccess(ctx, req.GetObjectId(), req.GetGrantId())
+	if err != nil {
+		return nil, grantErrorStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (t *Delivery) ListObjectGrants(ctx context.Context, req *pb.ListObjectGrantsRequest) (*pb.ListObjectGrantsResponse, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	grants, err := t.objectUC.ListGrants(ctx, req.GetObjectId())
+	if err != nil {
+		return nil, grantErrorStatus(err)
+	}
+
+	res := &pb.ListObjectGrantsResponse{
+		Grants: make([]*pb.ObjectGrant, 0, len(grants)),
+	}
+	for _, grant := range grants {
+		res.Grants = append(res.Grants, grant.ToGRPCResponse())
+	}
+	return res, nil
+}
+
+func grantErrorStatus(err error) error {
+	switch err {
+	case model.ErrInvalidObjectGrant:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrObjectNotFound, model.ErrObjectGrantNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, codes.Internal.String())
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/utils"
@@ -19,13 +20,22 @@ func (t *Delivery) GetObjectByID(ctx context.Context, req *pb.GetObjectByIDReque
 	defer span.End()
 
 	presignedObject, err := t.objectUC.GeneratePresignedURL(ctx, &model.GetPresignedURLPayload{
-		ObjectID: req.GetObjectId(),
+		ObjectID:    req.GetObjectId(),
+		MinValidity: time.Duration(req.GetMinValiditySeconds()) * time.Second,
 	})
 
 	switch err {
 	case nil:
 	case model.ErrObjectNotFound:
 		return nil, status.Error(codes.NotFound, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case model.ErrInvalidMinValidity:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrObjectEncrypted:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
 	default:
 		return nil, status.Error(codes.Internal, codes.Internal.String())
 	}
@@ -33,6 +43,49 @@ func (t *Delivery) GetObjectByID(ctx context.Context, req *pb.GetObjectByIDReque
 	return presignedObject.ToGRPCResponse(), nil
 }
 
+func (t *Delivery) UploadObject(ctx context.Context, req *pb.UploadObjectRequest) (*pb.Object, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	object, err := t.objectUC.Upload(ctx, &model.ObjectPayload{
+		Src: req.GetContent(),
+		Object: &model.Object{
+			FileName: req.GetFileName(),
+			Type:     req.GetType(),
+			IsPublic: req.GetIsPublic(),
+			Metadata: req.GetMetadata(),
+		},
+		Temporary:      req.GetTemporary(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+		ContentMD5:     req.GetContentMd5(),
+		ChecksumSHA256: req.GetChecksumSha256(),
+	})
+
+	switch err {
+	case nil:
+	case model.ErrExtensionNotAllowed, model.ErrObjectTypeNotFound, model.ErrInvalidObjectMetadata, model.ErrInvalidIdempotencyKey,
+		model.ErrInvalidChecksum, model.ErrChecksumMismatch:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrIdempotencyKeyReused:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case model.ErrIdempotencyKeyTaken:
+		return nil, status.Error(codes.Aborted, err.Error())
+	case model.ErrQuotaExceeded:
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	return object.ToGRPCResponse(), nil
+}
+
 func (t *Delivery) DeleteObjectByID(ctx context.Context, req *pb.DeleteObjectByIDRequest) (*emptypb.Empty, error) {
 	ctx = setUserIDCtx(ctx, req.GetUserId())
 
@@ -46,9 +99,86 @@ func (t *Delivery) DeleteObjectByID(ctx context.Context, req *pb.DeleteObjectByI
 	case nil:
 	case model.ErrObjectNotFound:
 		return nil, status.Error(codes.NotFound, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
 	default:
 		return nil, status.Error(codes.Internal, codes.Internal.String())
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func (t *Delivery) ListObjects(ctx context.Context, req *pb.ListObjectsRequest) (*pb.ListObjectsResponse, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	objects, err := t.objectUC.ListObjects(ctx, &model.ListObjectsPayload{
+		Metadata: req.GetMetadata(),
+		Limit:    int(req.GetLimit()),
+		Offset:   int(req.GetOffset()),
+	})
+
+	switch err {
+	case nil:
+	case model.ErrInvalidObjectMetadata:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	res := &pb.ListObjectsResponse{
+		Objects: make([]*pb.Object, 0, len(objects)),
+	}
+	for _, object := range objects {
+		res.Objects = append(res.Objects, object.ToGRPCResponse())
+	}
+	return res, nil
+}
+
+func (t *Delivery) UpdateObject(ctx context.Context, req *pb.UpdateObjectRequest) (*pb.Object, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	payload := &model.UpdateObjectPayload{
+		ObjectID: req.GetObjectId(),
+		Version:  req.GetVersion(),
+		IsPublic: req.IsPublic,
+		FileName: req.FileName,
+	}
+	if req.GetReplaceMetadata() {
+		metadata := model.ObjectMetadata(req.GetMetadata())
+		payload.Metadata = &metadata
+	}
+
+	object, err := t.objectUC.UpdateObject(ctx, payload)
+
+	switch err {
+	case nil:
+	case model.ErrInvalidObjectUpdate, model.ErrInvalidObjectMetadata:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrObjectNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case model.ErrObjectVersionConflict:
+		return nil, status.Error(codes.Aborted, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	return object.ToGRPCResponse(), nil
+}
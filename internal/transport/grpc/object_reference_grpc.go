@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (t *Delivery) AddReference(ctx context.Context, req *pb.ObjectReferenceRequest) (*pb.ObjectReference, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	reference, err := t.objectUC.AddReference(ctx, newObjectReferencePayload(req))
+	if err != nil {
+		return nil, referenceErrorStatus(err)
+	}
+
+	return reference.ToGRPCResponse(), nil
+}
+
+func (t *Delivery) RemoveReference(ctx context.Context, req *pb.ObjectReferenceRequest) (*emptypb.Empty, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err := t.objectUC.RemoveReference(ctx, newObjectReferencePayload(req))
+	if err != nil {
+		return nil, referenceErrorStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func newObjectReferencePayload(req *pb.ObjectReferenceRequest) *model.ObjectReferencePayload {
+	return &model.ObjectReferencePayload{
+		ObjectID:     req.GetObjectId(),
+		OwnerService: req.GetOwnerService(),
+		EntityType:   req.GetEntityType(),
+		EntityID:     req.GetEntityId(),
+	}
+}
+
+func referenceErrorStatus(err error) error {
+	switch err {
+	case model.ErrInvalidObjectReference:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case model.ErrObjectNotFound, model.ErrObjectReferenceNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case model.ErrUnauthorizeAccess:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, codes.Internal.String())
+	}
+}
@@ -6,7 +6,9 @@ import (
 )
 
 type Delivery struct {
-	objectUC model.ObjectUsecase
+	objectUC       model.ObjectUsecase
+	auditLogUC     model.AuditLogUsecase
+	storageUsageUC model.StorageUsageUsecase
 	pb.UnsafeStorageServiceServer
 }
 
@@ -13,3 +13,19 @@ func (t *Delivery) InjectObjectUsecase(uc model.ObjectUsecase) error {
 	t.objectUC = uc
 	return nil
 }
+
+func (t *Delivery) InjectAuditLogUsecase(uc model.AuditLogUsecase) error {
+	if uc == nil {
+		return errors.New("invalid audit log usecase")
+	}
+	t.auditLogUC = uc
+	return nil
+}
+
+func (t *Delivery) InjectStorageUsageUsecase(uc model.StorageUsageUsecase) error {
+	if uc == nil {
+		return errors.New("invalid storage usage usecase")
+	}
+	t.storageUsageUC = uc
+	return nil
+}
@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	pb "github.com/krobus00/storage-service/pb/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (t *Delivery) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.StorageUsage, error) {
+	ctx = setUserIDCtx(ctx, req.GetUserId())
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	usage, err := t.storageUsageUC.GetUsage(ctx, req.GetTargetUserId())
+
+	switch err {
+	case nil:
+	case model.ErrUnauthorizeAccess:
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case model.ErrAuthServiceUnavailable:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, codes.Internal.String())
+	}
+
+	return usage.ToGRPCResponse(), nil
+}
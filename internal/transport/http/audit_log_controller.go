@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditLogController struct {
+	auditLogUC model.AuditLogUsecase
+}
+
+func NewAuditLogController() *AuditLogController {
+	return new(AuditLogController)
+}
+
+func (t *AuditLogController) ListAuditLogs(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPListAuditLogsRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	filter, err := req.ToFilter()
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	}
+
+	auditLogs, err := t.auditLogUC.ListAuditLogs(ctx, filter)
+	switch err {
+	case nil:
+	case model.ErrInvalidAuditLogFilter:
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+
+	data := make([]*model.HTTPAuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		data = append(data, auditLog.ToHTTPResponse())
+	}
+	res.WithData(data)
+	return eCtx.JSON(http.StatusOK, res)
+}
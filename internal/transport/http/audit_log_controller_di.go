@@ -0,0 +1,15 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (t *AuditLogController) InjectAuditLogUsecase(uc model.AuditLogUsecase) error {
+	if uc == nil {
+		return errors.New("invalid audit log usecase")
+	}
+	t.auditLogUC = uc
+	return nil
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 
 	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/labstack/echo/v4"
 )
 
 func buildContext(eCtx echo.Context) context.Context {
 	userID := eCtx.Get(string(constant.KeyUserIDCtx))
 	ctx := context.WithValue(eCtx.Request().Context(), constant.KeyUserIDCtx, userID)
+	tenantID, _ := eCtx.Get(string(constant.KeyTenantIDCtx)).(string)
+	ctx = utils.NewTenantContext(ctx, tenantID)
+	ctx = context.WithValue(ctx, constant.KeyClientIPCtx, eCtx.RealIP())
+	ctx = context.WithValue(ctx, constant.KeyUserAgentCtx, eCtx.Request().UserAgent())
 	return ctx
 }
@@ -7,8 +7,10 @@ import (
 )
 
 type Delivery struct {
-	e                *echo.Echo
-	objectController *ObjectController
+	e                  *echo.Echo
+	objectController   *ObjectController
+	auditLogController *AuditLogController
+	usageController    *StorageUsageController
 }
 
 func NewDelivery() *Delivery {
@@ -31,10 +33,49 @@ func (t *Delivery) InjectObjectController(c *ObjectController) error {
 	return nil
 }
 
+func (t *Delivery) InjectAuditLogController(c *AuditLogController) error {
+	if c == nil {
+		return errors.New("invalid audit log controller")
+	}
+	t.auditLogController = c
+	return nil
+}
+
+func (t *Delivery) InjectStorageUsageController(c *StorageUsageController) error {
+	if c == nil {
+		return errors.New("invalid storage usage controller")
+	}
+	t.usageController = c
+	return nil
+}
+
 func (t *Delivery) InitRoutes() {
+	t.e.Use(Tracing(), RequestMetrics())
+
+	// share links are public, the token is the credential
+	t.e.GET("/s/:token", t.objectController.ResolveShareLink)
+	t.e.POST("/s/:token", t.objectController.ResolveShareLink)
+
 	api := t.e.Group("/api")
 
 	storage := api.Group("/storage")
 	storage.GET("/", t.objectController.GetPresignURL, DecodeJWTToken(true))
 	storage.POST("/upload", t.objectController.Upload, DecodeJWTToken(false))
+
+	// img src and plain links carry no token, guests may fetch public objects
+	storage.GET("/objects/:id/content", t.objectController.GetObjectContent, DecodeJWTToken(true))
+
+	objects := storage.Group("/objects", DecodeJWTToken(false))
+	objects.GET("", t.objectController.ListObjects)
+	objects.PATCH("/:id", t.objectController.UpdateObject)
+	objects.DELETE("/:id", t.objectController.DeleteObject)
+	objects.GET("/:id/grants", t.objectController.ListGrants)
+	objects.POST("/:id/grants", t.objectController.GrantAccess)
+	objects.DELETE("/:id/grants/:grantID", t.objectController.RevokeAccess)
+	objects.POST("/:id/share", t.objectController.CreateShareLink)
+	objects.GET("/:id/shares", t.objectController.ListShareLinks)
+	objects.DELETE("/:id/shares/:shareID", t.objectController.RevokeShareLink)
+
+	storage.GET("/audit-logs", t.auditLogController.ListAuditLogs, DecodeJWTToken(false))
+	storage.GET("/usage", t.usageController.GetUsage, DecodeJWTToken(false))
 }
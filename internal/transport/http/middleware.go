@@ -3,11 +3,20 @@ package http
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/krobus00/storage-service/internal/config"
 	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func DecodeJWTToken(allowGuest bool) echo.MiddlewareFunc {
@@ -16,11 +25,21 @@ func DecodeJWTToken(allowGuest bool) echo.MiddlewareFunc {
 			res := model.NewResponse().WithMessage(model.ErrTokenInvalid.Error())
 			accessToken := eCtx.Request().Header.Get("Authorization")
 			accessToken = strings.ReplaceAll(accessToken, "Bearer ", "")
-			if accessToken == "" && !allowGuest {
-				return eCtx.JSON(http.StatusUnauthorized, res)
+			if accessToken == "" {
+				if !allowGuest {
+					return eCtx.JSON(http.StatusUnauthorized, res)
+				}
+				// guests can not prove a tenant, the host they reach decides it
+				tenantID := config.TenantIDByHost(eCtx.Request().Host)
+				eCtx.Set(string(constant.KeyUserIDCtx), constant.GuestID)
+				eCtx.Set(string(constant.KeyTenantIDCtx), tenantID)
+				return next(eCtx)
 			}
 
 			token, _ := jwt.Parse(accessToken, nil)
+			if token == nil {
+				return eCtx.JSON(http.StatusUnauthorized, res)
+			}
 
 			claims, ok := token.Claims.(jwt.MapClaims)
 			if !ok {
@@ -31,8 +50,68 @@ func DecodeJWTToken(allowGuest bool) echo.MiddlewareFunc {
 				return eCtx.JSON(http.StatusUnauthorized, res)
 			}
 
+			// tokens without a tenant claim belong to the default tenant
+			tenantID, _ := claims["tenantID"].(string)
+			if !model.IsValidTenantID(tenantID) {
+				return eCtx.JSON(http.StatusUnauthorized, res)
+			}
+
 			eCtx.Set(string(constant.KeyUserIDCtx), userID)
+			eCtx.Set(string(constant.KeyTenantIDCtx), tenantID)
 			return next(eCtx)
 		}
 	}
 }
+
+// RequestMetrics records every request by its route template,
+// unmatched routes share one label to keep cardinality bounded.
+func RequestMetrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			start := time.Now()
+			err := next(eCtx)
+			if err != nil {
+				// let echo write the error response so the recorded status is the one sent
+				eCtx.Error(err)
+			}
+
+			route := eCtx.Path()
+			if route == "" {
+				route = metrics.UnknownLabel
+			}
+			metrics.ObserveHTTPRequest(eCtx.Request().Method, route, eCtx.Response().Status, start)
+			return nil
+		}
+	}
+}
+
+// Tracing continues the caller's trace from the w3c headers and
+// hands the span to the handlers through the request context.
+func Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+			ctx := utils.ExtractTraceContext(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := eCtx.Path()
+			if route == "" {
+				route = metrics.UnknownLabel
+			}
+			ctx, span := otel.Tracer("").Start(ctx, req.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+
+			eCtx.SetRequest(req.WithContext(ctx))
+			err := next(eCtx)
+			if err != nil {
+				eCtx.Error(err)
+			}
+
+			status := eCtx.Response().Status
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
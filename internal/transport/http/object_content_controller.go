@@ -0,0 +1,126 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// GetObjectContent serves the object either as a redirect to a presigned url
+// or streamed from S3, the mode query param overrides the configured one.
+// Encrypted objects can not be presigned and are always streamed.
+func (t *ObjectController) GetObjectContent(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPGetObjectContentRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	mode := t.downloadMode
+	if req.Mode != "" {
+		mode = req.Mode
+	}
+
+	if !isDownloadMode(mode) {
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(model.ErrInvalidDownloadMode.Error()))
+	}
+
+	content, err := t.objectUC.GetObjectContent(ctx, &model.GetObjectContentPayload{
+		ObjectID:    req.ObjectID,
+		Range:       eCtx.Request().Header.Get("Range"),
+		IfNoneMatch: eCtx.Request().Header.Get("If-None-Match"),
+		Redirect:    mode == model.DownloadModeRedirect,
+	})
+	if err != nil {
+		return contentErrorResponse(eCtx, res, err)
+	}
+
+	if content.RedirectURL != "" {
+		eCtx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return eCtx.Redirect(http.StatusFound, content.RedirectURL)
+	}
+	return streamContent(eCtx, content, req.Download)
+}
+
+func streamContent(eCtx echo.Context, content *model.ObjectContent, download bool) error {
+	header := eCtx.Response().Header()
+	// encrypted objects are decrypted whole, ranges are ignored
+	if content.Object.IsEncrypted() {
+		header.Set("Accept-Ranges", "none")
+	} else {
+		header.Set("Accept-Ranges", "bytes")
+	}
+	if content.ETag != "" {
+		header.Set("ETag", content.ETag)
+	}
+	if content.LastModified != nil {
+		header.Set(echo.HeaderLastModified, content.LastModified.UTC().Format(http.TimeFormat))
+	}
+	switch {
+	case !content.Object.IsPublic:
+		header.Set(echo.HeaderCacheControl, "private")
+	case content.CacheControl != "":
+		header.Set(echo.HeaderCacheControl, content.CacheControl)
+	}
+
+	if content.NotModified {
+		return eCtx.NoContent(http.StatusNotModified)
+	}
+	defer content.Body.Close()
+
+	disposition := "inline"
+	if download {
+		disposition = "attachment"
+	}
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{
+		"filename": content.Object.DownloadFileName(),
+	}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(content.ContentLength, 10))
+
+	status := http.StatusOK
+	if content.IsPartial() {
+		header.Set("Content-Range", content.ContentRange)
+		status = http.StatusPartialContent
+	}
+
+	contentType := content.ContentType
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	return eCtx.Stream(status, contentType, content.Body)
+}
+
+func contentErrorResponse(eCtx echo.Context, res *model.Response, err error) error {
+	switch err {
+	case model.ErrObjectNotFound, model.ErrContentNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrContentTampered:
+		return eCtx.JSON(http.StatusBadGateway, res.WithMessage(err.Error()))
+	case model.ErrInvalidRange:
+		return eCtx.JSON(http.StatusRequestedRangeNotSatisfiable, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+}
+
+func isDownloadMode(mode string) bool {
+	return mode == model.DownloadModeRedirect || mode == model.DownloadModeProxy
+}
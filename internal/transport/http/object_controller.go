@@ -10,8 +10,18 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// IdempotencyKeyHeader makes upload retries return the object of the first upload.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// ContentMD5Header and ChecksumSHA256Header carry digests of the uploaded file,
+	// not of the multipart body.
+	ContentMD5Header     = "Content-MD5"
+	ChecksumSHA256Header = "X-Checksum-SHA256"
+)
+
 type ObjectController struct {
-	objectUC model.ObjectUsecase
+	objectUC     model.ObjectUsecase
+	downloadMode string
 }
 
 func NewObjectController() *ObjectController {
@@ -52,22 +62,41 @@ func (t *ObjectController) Upload(eCtx echo.Context) (err error) {
 		return err
 	}
 
+	metadata, err := model.ParseObjectMetadata(req.Metadata)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	}
+
 	object, err := t.objectUC.Upload(ctx, &model.ObjectPayload{
 		Src: buf.Bytes(),
 		Object: &model.Object{
 			FileName: req.Filename,
 			Type:     req.Type,
 			IsPublic: req.IsPublic,
+			Metadata: metadata,
 		},
+		Temporary:      req.Temporary,
+		IdempotencyKey: eCtx.Request().Header.Get(IdempotencyKeyHeader),
+		ContentMD5:     eCtx.Request().Header.Get(ContentMD5Header),
+		ChecksumSHA256: eCtx.Request().Header.Get(ChecksumSHA256Header),
 	})
 	switch err {
 	case nil:
-	case model.ErrExtensionNotAllowed:
+	case model.ErrExtensionNotAllowed, model.ErrInvalidObjectMetadata, model.ErrInvalidIdempotencyKey,
+		model.ErrInvalidChecksum, model.ErrChecksumMismatch:
 		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrIdempotencyKeyReused:
+		return eCtx.JSON(http.StatusUnprocessableEntity, res.WithMessage(err.Error()))
+	case model.ErrIdempotencyKeyTaken:
+		return eCtx.JSON(http.StatusConflict, res.WithMessage(err.Error()))
 	case model.ErrObjectTypeNotFound:
 		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrQuotaExceeded:
+		return eCtx.JSON(http.StatusRequestEntityTooLarge, res.WithMessage(err.Error()))
 	case model.ErrUnauthorizeAccess:
 		return eCtx.JSON(http.StatusUnauthorized, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
 	default:
 		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
 	}
@@ -96,10 +125,14 @@ func (t *ObjectController) GetPresignURL(eCtx echo.Context) (err error) {
 	presignedObject, err := t.objectUC.GeneratePresignedURL(ctx, req.ToPayload())
 	switch err {
 	case nil:
-	case model.ErrObjectNotFound:
+	case model.ErrObjectNotFound, model.ErrInvalidMinValidity:
 		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrObjectEncrypted:
+		return eCtx.JSON(http.StatusConflict, res.WithMessage(err.Error()))
 	case model.ErrUnauthorizeAccess:
 		return eCtx.JSON(http.StatusUnauthorized, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
 	default:
 		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
 	}
@@ -107,3 +140,113 @@ func (t *ObjectController) GetPresignURL(eCtx echo.Context) (err error) {
 	res.WithData(presignedObject.ToHTTPResponse())
 	return eCtx.JSON(http.StatusOK, res)
 }
+
+func (t *ObjectController) UpdateObject(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPUpdateObjectRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	object, err := t.objectUC.UpdateObject(ctx, req.ToPayload())
+	switch err {
+	case nil:
+	case model.ErrInvalidObjectUpdate, model.ErrInvalidObjectMetadata:
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrObjectNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrObjectVersionConflict:
+		return eCtx.JSON(http.StatusConflict, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+
+	res.WithData(object.ToHTTPResponse())
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+func (t *ObjectController) DeleteObject(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPDeleteObjectRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	err = t.objectUC.DeleteObject(ctx, req.ObjectID)
+	switch err {
+	case nil:
+	case model.ErrObjectNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+
+	return eCtx.JSON(http.StatusOK, model.NewDefaultResponse())
+}
+
+func (t *ObjectController) ListObjects(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPListObjectsRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+	req.Metadata = model.NewMetadataFilter(eCtx.QueryParams())
+
+	objects, err := t.objectUC.ListObjects(ctx, req.ToPayload())
+	switch err {
+	case nil:
+	case model.ErrInvalidObjectMetadata:
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusUnauthorized, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+
+	data := make([]*model.HTTPUploadObjectResponse, 0, len(objects))
+	for _, object := range objects {
+		data = append(data, object.ToHTTPResponse())
+	}
+	res.WithData(data)
+	return eCtx.JSON(http.StatusOK, res)
+}
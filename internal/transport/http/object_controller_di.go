@@ -13,3 +13,11 @@ func (t *ObjectController) InjectObjectUsecase(uc model.ObjectUsecase) error {
 	t.objectUC = uc
 	return nil
 }
+
+func (t *ObjectController) InjectDownloadMode(mode string) error {
+	if !isDownloadMode(mode) {
+		return errors.New("invalid download mode")
+	}
+	t.downloadMode = mode
+	return nil
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+func (t *ObjectController) GrantAccess(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPGrantObjectAccessRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	grant, err := t.objectUC.GrantAccess(ctx, req.ToPayload())
+	if err != nil {
+		return grantErrorResponse(eCtx, res, err)
+	}
+
+	res.WithData(grant.ToHTTPResponse())
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+func (t *ObjectController) RevokeAccess(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPRevokeObjectAccessRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	err = t.objectUC.RevokeAccess(ctx, req.ObjectID, req.GrantID)
+	if err != nil {
+		return grantErrorResponse(eCtx, res, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, model.NewDefaultResponse())
+}
+
+func (t *ObjectController) ListGrants(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPListObjectGrantsRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	grants, err := t.objectUC.ListGrants(ctx, req.ObjectID)
+	if err != nil {
+		return grantErrorResponse(eCtx, res, err)
+	}
+
+	data := make([]*model.HTTPObjectGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		data = append(data, grant.ToHTTPResponse())
+	}
+	res.WithData(data)
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+func grantErrorResponse(eCtx echo.Context, res *model.Response, err error) error {
+	switch err {
+	case model.ErrInvalidObjectGrant:
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrObjectNotFound, model.ErrObjectGrantNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// headerSharePassword keeps the password out of urls and access logs,
+// forms without scripting POST it in the body instead.
+const headerSharePassword = "X-Share-Password"
+
+func (t *ObjectController) CreateShareLink(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPCreateShareLinkRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	shareLink, err := t.objectUC.CreateShareLink(ctx, req.ToPayload())
+	if err != nil {
+		return shareLinkErrorResponse(eCtx, res, err)
+	}
+
+	res.WithData(shareLink.ToHTTPResponse())
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+func (t *ObjectController) ListShareLinks(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPListShareLinksRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	shareLinks, err := t.objectUC.ListShareLinks(ctx, req.ObjectID)
+	if err != nil {
+		return shareLinkErrorResponse(eCtx, res, err)
+	}
+
+	data := make([]*model.HTTPShareLinkResponse, 0, len(shareLinks))
+	for _, shareLink := range shareLinks {
+		data = append(data, shareLink.ToHTTPResponse())
+	}
+	res.WithData(data)
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+func (t *ObjectController) RevokeShareLink(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPRevokeShareLinkRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	err = t.objectUC.RevokeShareLink(ctx, req.ObjectID, req.ShareLinkID)
+	if err != nil {
+		return shareLinkErrorResponse(eCtx, res, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, model.NewDefaultResponse())
+}
+
+func (t *ObjectController) ResolveShareLink(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPResolveShareLinkRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+	if password := eCtx.Request().Header.Get(headerSharePassword); password != "" {
+		req.Password = password
+	}
+
+	content, err := t.objectUC.ResolveShareLink(ctx, &model.ResolveShareLinkPayload{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		return shareLinkErrorResponse(eCtx, res, err)
+	}
+
+	if content.RedirectURL == "" {
+		return streamContent(eCtx, content, false)
+	}
+	// the signed url must not outlive the link in a shared cache
+	eCtx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return eCtx.Redirect(http.StatusFound, content.RedirectURL)
+}
+
+func shareLinkErrorResponse(eCtx echo.Context, res *model.Response, err error) error {
+	switch err {
+	case model.ErrInvalidShareLink:
+		return eCtx.JSON(http.StatusBadRequest, res.WithMessage(err.Error()))
+	case model.ErrObjectNotFound, model.ErrShareLinkNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrShareLinkUnavailable:
+		return eCtx.JSON(http.StatusGone, res.WithMessage(err.Error()))
+	case model.ErrShareLinkWrongPassword:
+		return eCtx.JSON(http.StatusUnauthorized, res.WithMessage(err.Error()))
+	case model.ErrShareLinkLocked:
+		return eCtx.JSON(http.StatusTooManyRequests, res.WithMessage(err.Error()))
+	case model.ErrContentNotFound:
+		return eCtx.JSON(http.StatusNotFound, res.WithMessage(err.Error()))
+	case model.ErrContentTampered:
+		return eCtx.JSON(http.StatusBadGateway, res.WithMessage(err.Error()))
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type StorageUsageController struct {
+	storageUsageUC model.StorageUsageUsecase
+}
+
+func NewStorageUsageController() *StorageUsageController {
+	return new(StorageUsageController)
+}
+
+func (t *StorageUsageController) GetUsage(eCtx echo.Context) (err error) {
+	var (
+		ctx = buildContext(eCtx)
+		res = model.NewResponse()
+		req = new(model.HTTPGetUsageRequest)
+	)
+
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	err = eCtx.Bind(req)
+	if err != nil {
+		res = model.WithBadRequestResponse(nil)
+		return eCtx.JSON(http.StatusBadRequest, res)
+	}
+
+	usage, err := t.storageUsageUC.GetUsage(ctx, req.UserID)
+	switch err {
+	case nil:
+	case model.ErrUnauthorizeAccess:
+		return eCtx.JSON(http.StatusForbidden, res.WithMessage(err.Error()))
+	case model.ErrAuthServiceUnavailable:
+		return eCtx.JSON(http.StatusServiceUnavailable, res.WithMessage(err.Error()))
+	default:
+		return eCtx.JSON(http.StatusInternalServerError, res.WithMessage("internal server error"))
+	}
+
+	res.WithData(usage.ToHTTPResponse())
+	return eCtx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (t *StorageUsageController) InjectStorageUsageUsecase(uc model.StorageUsageUsecase) error {
+	if uc == nil {
+		return errors.New("invalid storage usage usecase")
+	}
+	t.storageUsageUC = uc
+	return nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	auditWriteTimeout = 5 * time.Second
+	// auditObjectIDMaxLength is the size of the object_id column, ids from
+	// requests are not validated before they are audited.
+	auditObjectIDMaxLength = 36
+)
+
+type auditLogUsecase struct {
+	auditLogRepo model.AuditLogRepository
+	authClient   authPB.AuthServiceClient
+	jsClient     nats.JetStreamContext
+}
+
+func NewAuditLogUsecase() model.AuditLogUsecase {
+	return new(auditLogUsecase)
+}
+
+func (uc *auditLogUsecase) CreateStream() error {
+	if !config.AuditMirrorToJetstream() {
+		return nil
+	}
+
+	stream, _ := uc.jsClient.StreamInfo(model.AuditStreamName)
+	// stream not found, create it
+	if stream == nil {
+		logrus.Printf("Creating stream: %s\n", model.AuditStreamName)
+
+		_, err := uc.jsClient.AddStream(&nats.StreamConfig{
+			Name:     model.AuditStreamName,
+			Subjects: []string{model.AuditStreamSubjects},
+			MaxAge:   config.JetstreamMaxAge(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *auditLogUsecase) Record(ctx context.Context, action string, objectID string, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	// the trail is written even when the request was cancelled
+	ctx, cancel := context.WithTimeout(utils.NewDetachedContext(ctx), auditWriteTimeout)
+	defer cancel()
+
+	if len(objectID) > auditObjectIDMaxLength {
+		objectID = objectID[:auditObjectIDMaxLength]
+	}
+
+	auditLog := &model.AuditLog{
+		ID:        utils.GenerateUUID(),
+		TenantID:  utils.GetTenantIDFromContext(ctx),
+		ActorID:   getUserIDFromCtx(ctx),
+		ObjectID:  objectID,
+		Action:    action,
+		Outcome:   model.AuditOutcome(err),
+		ClientIP:  getStringFromCtx(ctx, constant.KeyClientIPCtx),
+		UserAgent: getStringFromCtx(ctx, constant.KeyUserAgentCtx),
+		CreatedAt: time.Now().UTC(),
+	}
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.HasTraceID() {
+		auditLog.TraceID = spanCtx.TraceID().String()
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"actorID":  auditLog.ActorID,
+		"objectID": auditLog.ObjectID,
+		"action":   auditLog.Action,
+		"outcome":  auditLog.Outcome,
+	})
+
+	writeErr := uc.auditLogRepo.Create(ctx, auditLog)
+	if writeErr != nil {
+		logger.Error(writeErr.Error())
+	}
+
+	if !config.AuditMirrorToJetstream() {
+		return
+	}
+	publishErr := publishJS(ctx, uc.jsClient, model.AuditLogSubject, auditLog)
+	if publishErr != nil {
+		logger.Error(publishErr.Error())
+	}
+}
+
+func (uc *auditLogUsecase) ListAuditLogs(ctx context.Context, filter *model.AuditLogFilter) ([]*model.AuditLog, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": filter.ObjectID,
+		"actorID":  filter.ActorID,
+	})
+
+	err := filter.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	err = hasAccess(ctx, uc.authClient, []string{
+		constant.PermissionFullAccess,
+		constant.PermissionAuditRead,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	auditLogs, err := uc.auditLogRepo.FindAll(ctx, filter)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"errors"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/nats-io/nats.go"
+)
+
+func (uc *auditLogUsecase) InjectAuditLogRepo(repo model.AuditLogRepository) error {
+	if repo == nil {
+		return errors.New("invalid audit log repository")
+	}
+	uc.auditLogRepo = repo
+	return nil
+}
+
+func (uc *auditLogUsecase) InjectAuthClient(client authPB.AuthServiceClient) error {
+	if client == nil {
+		return errors.New("invalid auth client")
+	}
+	uc.authClient = client
+	return nil
+}
+
+func (uc *auditLogUsecase) InjectJetstreamClient(client nats.JetStreamContext) error {
+	if client == nil {
+		return errors.New("invalid jetstream client")
+	}
+	uc.jsClient = client
+	return nil
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_auditLogUsecase_Record(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		traceID  = trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	)
+	tests := []struct {
+		name        string
+		action      string
+		err         error
+		wantOutcome string
+	}{
+		{
+			name:        "record success",
+			action:      model.AuditActionPresign,
+			err:         nil,
+			wantOutcome: model.AuditOutcomeSuccess,
+		},
+		{
+			name:        "record permission denial",
+			action:      model.AuditActionDelete,
+			err:         model.ErrUnauthorizeAccess,
+			wantOutcome: model.AuditOutcomeDenied,
+		},
+		{
+			name:        "record failure",
+			action:      model.AuditActionUpload,
+			err:         errors.New("db error"),
+			wantOutcome: model.AuditOutcomeError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := utils.NewTenantContext(context.TODO(), "acme")
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+			ctx = context.WithValue(ctx, constant.KeyClientIPCtx, "10.0.0.1")
+			ctx = context.WithValue(ctx, constant.KeyUserAgentCtx, "curl/8.0")
+			ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  trace.SpanID{1},
+			}))
+
+			auditLogRepo := mock.NewMockAuditLogRepository(ctrl)
+			auditLogRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, got *model.AuditLog) error {
+					want := &model.AuditLog{
+						ID:        got.ID,
+						TenantID:  "acme",
+						ActorID:   userID,
+						ObjectID:  objectID,
+						Action:    tt.action,
+						Outcome:   tt.wantOutcome,
+						ClientIP:  "10.0.0.1",
+						UserAgent: "curl/8.0",
+						TraceID:   traceID.String(),
+						CreatedAt: got.CreatedAt,
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("auditLogUsecase.Record() = %v, want %v", got, want)
+					}
+					return errors.New("db error")
+				})
+
+			uc := NewAuditLogUsecase()
+			err := uc.InjectAuditLogRepo(auditLogRepo)
+			utils.ContinueOrFatal(err)
+
+			// a failed write is only logged
+			uc.Record(ctx, tt.action, objectID, tt.err)
+		})
+	}
+}
+
+func Test_auditLogUsecase_Record_cancelledRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	auditLogRepo := mock.NewMockAuditLogRepository(ctrl)
+	auditLogRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, got *model.AuditLog) error {
+			if ctx.Err() != nil {
+				t.Errorf("auditLogUsecase.Record() wrote with a cancelled context")
+			}
+			if len(got.ObjectID) != 36 {
+				t.Errorf("auditLogUsecase.Record() object id = %v, want truncated to 36", got.ObjectID)
+			}
+			return nil
+		})
+
+	uc := NewAuditLogUsecase()
+	err := uc.InjectAuditLogRepo(auditLogRepo)
+	utils.ContinueOrFatal(err)
+
+	uc.Record(ctx, model.AuditActionDownload, strings.Repeat("a", 100), nil)
+}
+
+func Test_auditLogUsecase_ListAuditLogs(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		from     = time.Date(2023, 4, 2, 0, 0, 0, 0, time.UTC)
+		to       = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	)
+	auditLogs := []*model.AuditLog{
+		{ID: utils.GenerateUUID(), ObjectID: objectID, Action: model.AuditActionDelete},
+	}
+	type mockHasAccess struct {
+		hasAccess *wrapperspb.BoolValue
+		err       error
+	}
+	type mockFindAll struct {
+		res []*model.AuditLog
+		err error
+	}
+	tests := []struct {
+		name          string
+		filter        *model.AuditLogFilter
+		mockHasAccess *mockHasAccess
+		mockFindAll   *mockFindAll
+		want          []*model.AuditLog
+		wantErr       error
+	}{
+		{
+			name:   "success",
+			filter: &model.AuditLogFilter{ObjectID: objectID},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+			},
+			mockFindAll: &mockFindAll{
+				res: auditLogs,
+			},
+			want:    auditLogs,
+			wantErr: nil,
+		},
+		{
+			name:   "error unauthorized access",
+			filter: &model.AuditLogFilter{ObjectID: objectID},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(false),
+			},
+			want:    nil,
+			wantErr: model.ErrUnauthorizeAccess,
+		},
+		{
+			name:    "error inverted time range",
+			filter:  &model.AuditLogFilter{From: &from, To: &to},
+			want:    nil,
+			wantErr: model.ErrInvalidAuditLogFilter,
+		},
+		{
+			name:   "error find audit logs",
+			filter: &model.AuditLogFilter{ObjectID: objectID},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+			},
+			mockFindAll: &mockFindAll{
+				err: errors.New("db error"),
+			},
+			want:    nil,
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			auditLogRepo := mock.NewMockAuditLogRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+
+			if tt.mockHasAccess != nil {
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess.hasAccess, tt.mockHasAccess.err)
+			}
+
+			if tt.mockFindAll != nil {
+				auditLogRepo.EXPECT().
+					FindAll(gomock.Any(), tt.filter).
+					Times(1).
+					Return(tt.mockFindAll.res, tt.mockFindAll.err)
+			}
+
+			uc := NewAuditLogUsecase()
+			err := uc.InjectAuditLogRepo(auditLogRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.ListAuditLogs(ctx, tt.filter)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("auditLogUsecase.ListAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditLogUsecase.ListAuditLogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,18 +2,27 @@ package usecase
 
 import (
 	"context"
-
+	"errors"
 	"fmt"
 
 	"github.com/goccy/go-json"
 
 	authPB "github.com/krobus00/auth-service/pb/auth"
 	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
 )
 
+func getStringFromCtx(ctx context.Context, key any) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
 func getUserIDFromCtx(ctx context.Context) string {
 	ctxUserID := ctx.Value(constant.KeyUserIDCtx)
 
@@ -32,8 +41,20 @@ func hasAccess(ctx context.Context, authClient authPB.AuthServiceClient, permiss
 		Permissions: permissions,
 	})
 
-	if err != nil {
+	// the client already reports an unreachable service or an open breaker as unavailable
+	if errors.Is(err, model.ErrAuthServiceUnavailable) {
+		return err
+	}
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.PermissionDenied, codes.Unauthenticated:
 		return model.ErrUnauthorizeAccess
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		// no decision was made, do not report it as denied
+		return model.ErrAuthServiceUnavailable
+	default:
+		// the service answered with an error, it must not fail open
+		return err
 	}
 	if res == nil {
 		return model.ErrUnauthorizeAccess
@@ -45,19 +66,41 @@ func hasAccess(ctx context.Context, authClient authPB.AuthServiceClient, permiss
 	return model.ErrUnauthorizeAccess
 }
 
+// getUserGroupIDs returns the auth groups of the user, guests belong to none.
+func getUserGroupIDs(ctx context.Context, authClient authPB.AuthServiceClient, userID string) ([]string, error) {
+	if userID == constant.GuestID {
+		return nil, nil
+	}
+
+	res, err := authClient.FindAllUserGroups(ctx, &authPB.FindAllUserGroupsRequest{
+		SessionUserId: userID,
+		UserId:        userID,
+	})
+	if err != nil {
+		return nil, model.ErrAuthServiceUnavailable
+	}
+
+	groupIDs := make([]string, 0, len(res.GetUserGroups()))
+	for _, userGroup := range res.GetUserGroups() {
+		groupIDs = append(groupIDs, userGroup.GetGroupId())
+	}
+	return groupIDs, nil
+}
+
 func publishJS(ctx context.Context, jsClient nats.JetStreamContext, subjectName string, data any) error {
 	payload, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	_, err = jsClient.Publish(
-		subjectName,
-		payload,
-		nats.Context(ctx),
-	)
+	msg := nats.NewMsg(subjectName)
+	msg.Data = payload
+	utils.InjectTraceContext(ctx, utils.NATSHeaderCarrier(msg.Header))
+
+	_, err = jsClient.PublishMsg(msg, nats.Context(ctx))
 
 	if err != nil {
+		metrics.ObserveJetstreamPublishFailure(subjectName)
 		return err
 	}
 	return nil
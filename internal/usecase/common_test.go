@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_hasAccess(t *testing.T) {
+	internalErr := status.Error(codes.Internal, "boom")
+	tests := []struct {
+		name    string
+		mockRes *wrapperspb.BoolValue
+		mockErr error
+		wantErr error
+	}{
+		{
+			name:    "success",
+			mockRes: wrapperspb.Bool(true),
+			wantErr: nil,
+		},
+		{
+			name:    "error not allowed",
+			mockRes: wrapperspb.Bool(false),
+			wantErr: model.ErrUnauthorizeAccess,
+		},
+		{
+			name:    "error permission denied",
+			mockErr: status.Error(codes.PermissionDenied, "denied"),
+			wantErr: model.ErrUnauthorizeAccess,
+		},
+		{
+			name:    "error unavailable",
+			mockErr: status.Error(codes.Unavailable, "down"),
+			wantErr: model.ErrAuthServiceUnavailable,
+		},
+		{
+			name:    "error deadline exceeded",
+			mockErr: status.Error(codes.DeadlineExceeded, "slow"),
+			wantErr: model.ErrAuthServiceUnavailable,
+		},
+		{
+			name:    "error breaker open",
+			mockErr: model.ErrAuthServiceUnavailable,
+			wantErr: model.ErrAuthServiceUnavailable,
+		},
+		{
+			name:    "error internal is not unavailable",
+			mockErr: internalErr,
+			wantErr: internalErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, "user-1")
+
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			authClientMock.EXPECT().
+				HasAccess(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tt.mockRes, tt.mockErr)
+
+			err := hasAccess(ctx, authClientMock, []string{constant.PermissionFullAccess})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("hasAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// GetObjectContent streams the object through the service with the same access
+// rules as GeneratePresignedURL, a redirect is recorded as a single download.
+func (uc *objectUsecase) GetObjectContent(ctx context.Context, payload *model.GetObjectContentPayload) (content *model.ObjectContent, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionDownload, payload.ObjectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": payload.ObjectID,
+		"range":    payload.Range,
+	})
+
+	object, err := uc.objectRepo.FindByID(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if object == nil {
+		return nil, model.ErrObjectNotFound
+	}
+
+	err = uc.hasAccess(ctx, object)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	if payload.Redirect && !object.IsEncrypted() {
+		return uc.redirectContent(ctx, object)
+	}
+
+	content, err = uc.objectRepo.GetContent(ctx, object, payload)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// redirectContent presigns the object for a download that is already audited.
+func (uc *objectUsecase) redirectContent(ctx context.Context, object *model.Object) (content *model.ObjectContent, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	start := time.Now()
+	typeLabel := metrics.UnknownLabel
+	defer func() {
+		metrics.ObservePresign(typeLabel, start, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": object.ID,
+	})
+
+	objectType, err := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if objectType == nil {
+		return nil, model.ErrObjectNotFound
+	}
+	object.SetType(objectType.Name)
+	typeLabel = objectType.Name
+
+	presignedObject, err := uc.objectRepo.GeneratePresignedURL(ctx, object, 0)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &model.ObjectContent{Object: object, RedirectURL: presignedObject.URL}, nil
+}
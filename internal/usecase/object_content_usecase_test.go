@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_objectUsecase_GetObjectContent(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	privateObject := &model.Object{ID: objectID, UploadedBy: "other-user", IsPublic: false}
+	publicObject := &model.Object{ID: objectID, UploadedBy: "other-user", IsPublic: true}
+	encryptedObject := &model.Object{ID: objectID, TypeID: typeID, UploadedBy: "other-user", IsPublic: true, EncryptionKeyID: "k1"}
+	redirectObject := &model.Object{ID: objectID, TypeID: typeID, UploadedBy: "other-user", IsPublic: true}
+	type mockFindObjectByID struct {
+		res *model.Object
+		err error
+	}
+	tests := []struct {
+		name               string
+		payload            *model.GetObjectContentPayload
+		mockFindObjectByID *mockFindObjectByID
+		mockHasAccess      *wrapperspb.BoolValue
+		wantGetContent     bool
+		wantPresign        bool
+		want               *model.ObjectContent
+		wantErr            error
+	}{
+		{
+			name:    "success public object",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, Range: "bytes=0-1"},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: publicObject,
+			},
+			wantGetContent: true,
+			want:           &model.ObjectContent{Object: publicObject, ContentRange: "bytes 0-1/4"},
+			wantErr:        nil,
+		},
+		{
+			name:    "success private object with permission",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: privateObject,
+			},
+			mockHasAccess:  wrapperspb.Bool(true),
+			wantGetContent: true,
+			want:           &model.ObjectContent{Object: privateObject, ContentRange: "bytes 0-1/4"},
+			wantErr:        nil,
+		},
+		{
+			name:    "success redirect",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, Redirect: true},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: redirectObject,
+			},
+			wantPresign: true,
+			want:        &model.ObjectContent{Object: redirectObject, RedirectURL: "https://s3.bucket/test.jpg"},
+			wantErr:     nil,
+		},
+		{
+			name:    "success redirect of encrypted object is streamed",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID, Redirect: true},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: encryptedObject,
+			},
+			wantGetContent: true,
+			want:           &model.ObjectContent{Object: encryptedObject},
+			wantErr:        nil,
+		},
+		{
+			name:    "error unauthorized access",
+			payload: &model.GetObjectContentPayload{ObjectID: objectID},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: privateObject,
+			},
+			mockHasAccess: wrapperspb.Bool(false),
+			want:          nil,
+			wantErr:       model.ErrUnauthorizeAccess,
+		},
+		{
+			name:               "error object not found",
+			payload:            &model.GetObjectContentPayload{ObjectID: objectID},
+			mockFindObjectByID: &mockFindObjectByID{},
+			want:               nil,
+			wantErr:            model.ErrObjectNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			objectGrantRepo := mock.NewMockObjectGrantRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionDownload, objectID, tt.wantErr).
+				Times(1)
+
+			objectRepo.EXPECT().
+				FindByID(gomock.Any(), objectID).
+				Times(1).
+				Return(tt.mockFindObjectByID.res, tt.mockFindObjectByID.err)
+
+			if tt.mockHasAccess != nil {
+				objectGrantRepo.EXPECT().
+					FindByObjectID(gomock.Any(), objectID).
+					Times(1).
+					Return(nil, nil)
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess, nil)
+			}
+
+			if tt.wantGetContent {
+				objectRepo.EXPECT().
+					GetContent(gomock.Any(), tt.mockFindObjectByID.res, tt.payload).
+					Times(1).
+					Return(tt.want, nil)
+			}
+
+			if tt.wantPresign {
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "image"}, nil)
+				objectRepo.EXPECT().
+					GeneratePresignedURL(gomock.Any(), tt.mockFindObjectByID.res, time.Duration(0)).
+					Times(1).
+					Return(&model.GetPresignedURLResponse{ID: objectID, URL: tt.want.RedirectURL}, nil)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectTypeRepo(objectTypeRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectGrantRepo(objectGrantRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.GetObjectContent(ctx, tt.payload)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.GetObjectContent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectUsecase.GetObjectContent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
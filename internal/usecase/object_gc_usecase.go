@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+)
+
+const pendingDeletionTimeout = 10 * time.Second
+
+type objectGCUsecase struct {
+	objectRepo          model.ObjectRepository
+	pendingDeletionRepo model.PendingDeletionRepository
+	jsClient            nats.JetStreamContext
+}
+
+func NewObjectGCUsecase() model.ObjectGCUsecase {
+	return new(objectGCUsecase)
+}
+
+func (uc *objectGCUsecase) PurgeTemporaryObjects(ctx context.Context, dryRun bool) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	now := time.Now()
+	batchSize := config.GCBatchSize()
+	logger := logrus.WithFields(logrus.Fields{
+		"dryRun":    dryRun,
+		"batchSize": batchSize,
+	})
+
+	purged := make([]*model.Object, 0)
+	// purged rows leave the result set, only skipped ones move the offset
+	offset := 0
+	for {
+		objects, err := uc.objectRepo.FindUnreferencedTemporary(ctx, now, batchSize, offset)
+		if err != nil {
+			logger.Error(err.Error())
+			return purged, err
+		}
+
+		for _, object := range objects {
+			if dryRun {
+				purged = append(purged, object)
+				offset++
+				continue
+			}
+
+			deleted, err := uc.purge(ctx, object, now)
+			if err != nil {
+				logger.WithField("objectID", object.ID).Error(err.Error())
+			}
+			if !deleted {
+				offset++
+				continue
+			}
+			purged = append(purged, object)
+		}
+
+		if len(objects) < batchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	logger.WithField("purged", len(purged)).Info("temporary objects purged")
+	return purged, nil
+}
+
+// purge deletes the row before the content, a content left behind by a failed
+// s3 delete is only an orphan while the reverse would break readers.
+func (uc *objectGCUsecase) purge(ctx context.Context, object *model.Object, before time.Time) (deleted bool, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		if deleted || err != nil {
+			metrics.ObserveGCPurge(err)
+		}
+	}()
+
+	deleted, err = uc.objectRepo.DeleteUnreferencedTemporary(ctx, object.ID, before)
+	if err != nil || !deleted {
+		return false, err
+	}
+	// subscribers learn of a gc purge like of any other delete
+	publishObjectDeleted(ctx, uc.jsClient, object, constant.SystemID)
+
+	err = uc.objectRepo.DeleteContent(ctx, object)
+	if err != nil {
+		uc.deferDeletion(ctx, object, err)
+		return true, err
+	}
+	return true, nil
+}
+
+// deferDeletion hands the content of a purged row to the pending deletion retries,
+// nothing else points at it once the row is gone.
+func (uc *objectGCUsecase) deferDeletion(ctx context.Context, object *model.Object, cause error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	// a stopping gc must still record what it could not delete
+	ctx, cancel := context.WithTimeout(utils.NewDetachedContext(ctx), pendingDeletionTimeout)
+	defer cancel()
+
+	bucket := object.Bucket
+	if bucket == "" {
+		bucket = config.TenantS3BucketName(object.TenantID)
+	}
+
+	err := uc.pendingDeletionRepo.Create(ctx, &model.PendingDeletion{
+		ID:        utils.GenerateUUID(),
+		Bucket:    bucket,
+		Key:       object.Key,
+		Reason:    "temporary object purged",
+		LastError: cause.Error(),
+	})
+	if err != nil {
+		logrus.WithField("objectID", object.ID).Error(err.Error())
+	}
+}
+
+// RetryPendingDeletions makes one pass, failed deletions move to the back of the queue.
+func (uc *objectGCUsecase) RetryPendingDeletions(ctx context.Context) (int, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"batchSize": config.GCBatchSize(),
+	})
+
+	deletions, err := uc.pendingDeletionRepo.FindAll(ctx, config.GCBatchSize())
+	if err != nil {
+		logger.Error(err.Error())
+		return 0, err
+	}
+
+	deleted := 0
+	for _, deletion := range deletions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err := uc.objectRepo.DeleteContent(ctx, &model.Object{Bucket: deletion.Bucket, Key: deletion.Key})
+		if err != nil {
+			logger.WithField("pendingDeletionID", deletion.ID).Error(err.Error())
+			_ = uc.pendingDeletionRepo.MarkFailed(ctx, deletion.ID, err)
+			continue
+		}
+
+		err = uc.pendingDeletionRepo.DeleteByID(ctx, deletion.ID)
+		if err != nil {
+			logger.WithField("pendingDeletionID", deletion.ID).Error(err.Error())
+			continue
+		}
+		deleted++
+	}
+
+	logger.WithField("deleted", deleted).Info("pending deletions retried")
+	return deleted, nil
+}
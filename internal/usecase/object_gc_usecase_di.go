@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/nats-io/nats.go"
+)
+
+func (uc *objectGCUsecase) InjectObjectRepo(repo model.ObjectRepository) error {
+	if repo == nil {
+		return errors.New("invalid object repository")
+	}
+	uc.objectRepo = repo
+	return nil
+}
+
+func (uc *objectGCUsecase) InjectPendingDeletionRepo(repo model.PendingDeletionRepository) error {
+	if repo == nil {
+		return errors.New("invalid pending deletion repository")
+	}
+	uc.pendingDeletionRepo = repo
+	return nil
+}
+
+func (uc *objectGCUsecase) InjectJetstreamClient(client nats.JetStreamContext) error {
+	if client == nil {
+		return errors.New("invalid jetstream client")
+	}
+	uc.jsClient = client
+	return nil
+}
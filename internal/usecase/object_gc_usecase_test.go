@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func Test_objectGCUsecase_PurgeTemporaryObjects(t *testing.T) {
+	var (
+		first  = &model.Object{ID: utils.GenerateUUID(), Key: "first", Bucket: "bucket"}
+		second = &model.Object{ID: utils.GenerateUUID(), Key: "second"}
+		third  = &model.Object{ID: utils.GenerateUUID(), Key: "third"}
+	)
+	type mockFind struct {
+		offset int
+		res    []*model.Object
+		err    error
+	}
+	type mockDelete struct {
+		object  *model.Object
+		deleted bool
+		err     error
+	}
+	tests := []struct {
+		name              string
+		dryRun            bool
+		mockFind          []mockFind
+		mockDelete        []mockDelete
+		mockDeleteContent map[*model.Object]error
+		wantDeferred      []*model.Object
+		want              []*model.Object
+		wantErr           bool
+	}{
+		{
+			name:   "success dry run",
+			dryRun: true,
+			mockFind: []mockFind{
+				{offset: 0, res: []*model.Object{first, second}},
+				{offset: 2, res: []*model.Object{third}},
+			},
+			want:    []*model.Object{first, second, third},
+			wantErr: false,
+		},
+		{
+			name: "success skip object referenced meanwhile",
+			mockFind: []mockFind{
+				{offset: 0, res: []*model.Object{first, second}},
+				{offset: 1, res: []*model.Object{}},
+			},
+			mockDelete: []mockDelete{
+				{object: first, deleted: true},
+				{object: second, deleted: false},
+			},
+			mockDeleteContent: map[*model.Object]error{
+				first: nil,
+			},
+			want:    []*model.Object{first},
+			wantErr: false,
+		},
+		{
+			name: "success content delete failed",
+			mockFind: []mockFind{
+				{offset: 0, res: []*model.Object{first}},
+			},
+			mockDelete: []mockDelete{
+				{object: first, deleted: true},
+			},
+			mockDeleteContent: map[*model.Object]error{
+				first: errors.New("s3 error"),
+			},
+			wantDeferred: []*model.Object{first},
+			want:         []*model.Object{first},
+			wantErr:      false,
+		},
+		{
+			name: "error find objects",
+			mockFind: []mockFind{
+				{offset: 0, err: errors.New("db error")},
+			},
+			want:    []*model.Object{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("gc.batch_size", 2)
+			defer viper.Set("gc.batch_size", nil)
+
+			ctx := context.TODO()
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			pendingDeletionRepo := mock.NewMockPendingDeletionRepository(ctrl)
+
+			for _, find := range tt.mockFind {
+				objectRepo.EXPECT().
+					FindUnreferencedTemporary(gomock.Any(), gomock.Any(), 2, find.offset).
+					Times(1).
+					Return(find.res, find.err)
+			}
+			for _, del := range tt.mockDelete {
+				objectRepo.EXPECT().
+					DeleteUnreferencedTemporary(gomock.Any(), del.object.ID, gomock.Any()).
+					Times(1).
+					Return(del.deleted, del.err)
+			}
+			for object, err := range tt.mockDeleteContent {
+				objectRepo.EXPECT().
+					DeleteContent(gomock.Any(), object).
+					Times(1).
+					Return(err)
+			}
+			for _, object := range tt.wantDeferred {
+				object := object
+				pendingDeletionRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, deletion *model.PendingDeletion) error {
+						if deletion.Bucket != object.Bucket || deletion.Key != object.Key {
+							t.Errorf("pending deletion = %s/%s, want %s/%s", deletion.Bucket, deletion.Key, object.Bucket, object.Key)
+						}
+						return nil
+					})
+			}
+
+			uc := NewObjectGCUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectPendingDeletionRepo(pendingDeletionRepo)
+			utils.ContinueOrFatal(err)
+			jsClient := new(fakeJetStream)
+			err = uc.InjectJetstreamClient(jsClient)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.PurgeTemporaryObjects(ctx, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectGCUsecase.PurgeTemporaryObjects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectGCUsecase.PurgeTemporaryObjects() = %v, want %v", got, tt.want)
+			}
+			// every purged row is published, dry runs purge nothing
+			wantPublished := 0
+			if !tt.dryRun {
+				wantPublished = len(tt.want) * len(model.ObjectDeleteStreamSubjects)
+			}
+			if len(jsClient.subjects) != wantPublished {
+				t.Errorf("objectGCUsecase.PurgeTemporaryObjects() published %v, want %d subjects", jsClient.subjects, wantPublished)
+			}
+		})
+	}
+}
+
+func Test_objectGCUsecase_RetryPendingDeletions(t *testing.T) {
+	var (
+		first  = &model.PendingDeletion{ID: utils.GenerateUUID(), Bucket: "media", Key: "first"}
+		second = &model.PendingDeletion{ID: utils.GenerateUUID(), Bucket: "media", Key: "second"}
+	)
+	tests := []struct {
+		name              string
+		mockFind          []*model.PendingDeletion
+		mockFindErr       error
+		mockDeleteContent map[*model.PendingDeletion]error
+		want              int
+		wantErr           bool
+	}{
+		{
+			name:     "success",
+			mockFind: []*model.PendingDeletion{first, second},
+			mockDeleteContent: map[*model.PendingDeletion]error{
+				first:  nil,
+				second: nil,
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:     "success failed delete is kept",
+			mockFind: []*model.PendingDeletion{first, second},
+			mockDeleteContent: map[*model.PendingDeletion]error{
+				first:  errors.New("s3 error"),
+				second: nil,
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:        "error find pending deletions",
+			mockFindErr: errors.New("db error"),
+			want:        0,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			pendingDeletionRepo := mock.NewMockPendingDeletionRepository(ctrl)
+
+			pendingDeletionRepo.EXPECT().
+				FindAll(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tt.mockFind, tt.mockFindErr)
+			for deletion, err := range tt.mockDeleteContent {
+				objectRepo.EXPECT().
+					DeleteContent(gomock.Any(), &model.Object{Bucket: deletion.Bucket, Key: deletion.Key}).
+					Times(1).
+					Return(err)
+				if err != nil {
+					pendingDeletionRepo.EXPECT().
+						MarkFailed(gomock.Any(), deletion.ID, err).
+						Times(1).
+						Return(nil)
+					continue
+				}
+				pendingDeletionRepo.EXPECT().
+					DeleteByID(gomock.Any(), deletion.ID).
+					Times(1).
+					Return(nil)
+			}
+
+			uc := NewObjectGCUsecase()
+			utils.ContinueOrFatal(uc.InjectObjectRepo(objectRepo))
+			utils.ContinueOrFatal(uc.InjectPendingDeletionRepo(pendingDeletionRepo))
+
+			got, err := uc.RetryPendingDeletions(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectGCUsecase.RetryPendingDeletions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("objectGCUsecase.RetryPendingDeletions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func (uc *objectUsecase) GrantAccess(ctx context.Context, payload *model.GrantObjectAccessPayload) (grant *model.ObjectGrant, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionGrant, payload.ObjectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":    payload.ObjectID,
+		"granteeType": payload.GranteeType,
+		"granteeID":   payload.GranteeID,
+		"permission":  payload.Permission,
+	})
+
+	object, err := uc.findManageableObject(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	grant = &model.ObjectGrant{
+		ID:          utils.GenerateUUID(),
+		ObjectID:    object.ID,
+		GranteeType: payload.GranteeType,
+		GranteeID:   payload.GranteeID,
+		Permission:  payload.Permission,
+		GrantedBy:   getUserIDFromCtx(ctx),
+		ExpiredAt:   payload.ExpiredAt,
+	}
+	err = grant.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.objectGrantRepo.Create(ctx, grant)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+func (uc *objectUsecase) RevokeAccess(ctx context.Context, objectID string, grantID string) (err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionRevoke, objectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+		"grantID":  grantID,
+	})
+
+	object, err := uc.findManageableObject(ctx, objectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = uc.objectGrantRepo.DeleteByID(ctx, object.ID, grantID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (uc *objectUsecase) ListGrants(ctx context.Context, objectID string) ([]*model.ObjectGrant, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+	})
+
+	object, err := uc.findManageableObject(ctx, objectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	grants, err := uc.objectGrantRepo.FindByObjectID(ctx, object.ID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// findManageableObject returns the object when the user may manage its grants,
+// that is its owner or a holder of a full object permission.
+func (uc *objectUsecase) findManageableObject(ctx context.Context, objectID string) (*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	object, err := uc.objectRepo.FindByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, model.ErrObjectNotFound
+	}
+
+	userID := getUserIDFromCtx(ctx)
+	// guest uploads are shared by every guest, guests must not manage them
+	if userID != constant.GuestID && object.UploadedBy == userID {
+		return object, nil
+	}
+
+	err = hasAccess(ctx, uc.authClient, []string{
+		constant.PermissionFullAccess,
+		constant.PermissionObjectAll,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// hasGrant reports whether an active grant gives the user permission on the object,
+// the user groups are only fetched when a group grant could apply.
+func (uc *objectUsecase) hasGrant(ctx context.Context, object *model.Object, permission string) (bool, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+	if userID == constant.GuestID {
+		return false, nil
+	}
+
+	grants, err := uc.objectGrantRepo.FindByObjectID(ctx, object.ID)
+	if err != nil {
+		return false, err
+	}
+
+	hasGroupGrant := false
+	for _, grant := range grants {
+		if grant.Allows(permission, userID, nil) {
+			return true, nil
+		}
+		if grant.GranteeType == model.GranteeTypeGroup && grant.Permission == permission {
+			hasGroupGrant = true
+		}
+	}
+	if !hasGroupGrant {
+		return false, nil
+	}
+
+	groupIDs, err := getUserGroupIDs(ctx, uc.authClient, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		if grant.Allows(permission, userID, groupIDs) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_objectUsecase_ListGrants(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+	)
+	grants := []*model.ObjectGrant{{ID: utils.GenerateUUID(), ObjectID: objectID}}
+	tests := []struct {
+		name          string
+		userID        string
+		object        *model.Object
+		mockHasAccess *wrapperspb.BoolValue
+		want          []*model.ObjectGrant
+		wantErr       error
+	}{
+		{
+			name:    "success owner",
+			userID:  userID,
+			object:  &model.Object{ID: objectID, UploadedBy: userID},
+			want:    grants,
+			wantErr: nil,
+		},
+		{
+			name:          "success full object permission",
+			userID:        userID,
+			object:        &model.Object{ID: objectID, UploadedBy: "other-user"},
+			mockHasAccess: wrapperspb.Bool(true),
+			want:          grants,
+			wantErr:       nil,
+		},
+		{
+			name:          "error guest upload is not owned by the guest",
+			userID:        constant.GuestID,
+			object:        &model.Object{ID: objectID, UploadedBy: constant.GuestID},
+			mockHasAccess: wrapperspb.Bool(false),
+			want:          nil,
+			wantErr:       model.ErrUnauthorizeAccess,
+		},
+		{
+			name:    "error object not found",
+			userID:  userID,
+			object:  nil,
+			want:    nil,
+			wantErr: model.ErrObjectNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectGrantRepo := mock.NewMockObjectGrantRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+
+			objectRepo.EXPECT().
+				FindByID(gomock.Any(), objectID).
+				Times(1).
+				Return(tt.object, nil)
+
+			if tt.mockHasAccess != nil {
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess, nil)
+			}
+
+			if tt.wantErr == nil {
+				objectGrantRepo.EXPECT().
+					FindByObjectID(gomock.Any(), objectID).
+					Times(1).
+					Return(grants, nil)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectGrantRepo(objectGrantRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.ListGrants(ctx, objectID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.ListGrants() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectUsecase.ListGrants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
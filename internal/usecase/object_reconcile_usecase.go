@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type objectReconcileUsecase struct {
+	objectRepo     model.ObjectRepository
+	objectTypeRepo model.ObjectTypeRepository
+}
+
+func NewObjectReconcileUsecase() model.ObjectReconcileUsecase {
+	return new(objectReconcileUsecase)
+}
+
+// Reconcile holds the keys of every row in memory while the buckets are listed.
+// Rows are read before the listing, so a blob uploaded meanwhile shows up as an
+// orphan within the grace period and is left alone.
+func (uc *objectReconcileUsecase) Reconcile(ctx context.Context, dryRun bool) (*model.ReconcileReport, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	gracePeriod := config.ReconcileGracePeriod()
+	logger := logrus.WithFields(logrus.Fields{
+		"dryRun":      dryRun,
+		"gracePeriod": gracePeriod,
+	})
+
+	report := model.NewReconcileReport(dryRun, gracePeriod)
+	graceStart := time.Now().Add(-gracePeriod)
+
+	rows, err := uc.findRowsByBucket(ctx, report)
+	if err != nil {
+		logger.Error(err.Error())
+		return report, err
+	}
+
+	buckets, err := uc.findBuckets(ctx, rows)
+	if err != nil {
+		logger.Error(err.Error())
+		return report, err
+	}
+	report.Buckets = buckets
+
+	for _, bucket := range buckets {
+		seen := make(map[string]bool, len(rows[bucket]))
+		continuationToken := ""
+		for {
+			page, err := uc.objectRepo.ListContent(ctx, bucket, continuationToken)
+			if err != nil {
+				logger.WithField("bucket", bucket).Error(err.Error())
+				return report, err
+			}
+
+			for _, content := range page.Contents {
+				report.BlobsScanned++
+				if _, ok := rows[bucket][content.Key]; ok {
+					seen[content.Key] = true
+					continue
+				}
+				report.Orphans = append(report.Orphans, uc.handleOrphan(ctx, content, graceStart, dryRun))
+			}
+
+			continuationToken = page.ContinuationToken
+			if continuationToken == "" || ctx.Err() != nil {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		for key, object := range rows[bucket] {
+			if !seen[key] {
+				report.MissingContent = append(report.MissingContent, object)
+			}
+		}
+	}
+
+	sort.Slice(report.MissingContent, func(i, j int) bool {
+		return report.MissingContent[i].ID < report.MissingContent[j].ID
+	})
+	for _, orphan := range report.Orphans {
+		if orphan.Deleted {
+			report.OrphansDeleted++
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"rowsScanned":    report.RowsScanned,
+		"blobsScanned":   report.BlobsScanned,
+		"missingContent": len(report.MissingContent),
+		"orphans":        len(report.Orphans),
+		"orphansDeleted": report.OrphansDeleted,
+	}).Info("objects reconciled")
+	return report, nil
+}
+
+// findRowsByBucket indexes the object rows by bucket and key.
+func (uc *objectReconcileUsecase) findRowsByBucket(ctx context.Context, report *model.ReconcileReport) (map[string]map[string]*model.Object, error) {
+	batchSize := config.ReconcileBatchSize()
+	rows := make(map[string]map[string]*model.Object)
+
+	afterID := ""
+	for {
+		objects, err := uc.objectRepo.FindAllAfterID(ctx, afterID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			if rows[object.Bucket] == nil {
+				rows[object.Bucket] = make(map[string]*model.Object)
+			}
+			rows[object.Bucket][object.Key] = object
+			afterID = object.ID
+		}
+		report.RowsScanned += len(objects)
+
+		if len(objects) < batchSize {
+			return rows, nil
+		}
+	}
+}
+
+// findBuckets returns every bucket the service may have written to.
+func (uc *objectReconcileUsecase) findBuckets(ctx context.Context, rows map[string]map[string]*model.Object) ([]string, error) {
+	typeBuckets, err := uc.objectTypeRepo.FindBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make(map[string]bool)
+	for _, bucket := range config.S3BucketNames() {
+		unique[bucket] = true
+	}
+	for _, bucket := range typeBuckets {
+		unique[bucket] = true
+	}
+	for bucket := range rows {
+		unique[bucket] = true
+	}
+
+	buckets := make([]string, 0, len(unique))
+	for bucket := range unique {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	return buckets, nil
+}
+
+func (uc *objectReconcileUsecase) handleOrphan(ctx context.Context, content *model.StoredContent, graceStart time.Time, dryRun bool) *model.ReconcileOrphan {
+	orphan := &model.ReconcileOrphan{
+		StoredContent: content,
+		WithinGrace:   content.LastModified.After(graceStart),
+	}
+	if dryRun || orphan.WithinGrace {
+		return orphan
+	}
+
+	err := uc.objectRepo.DeleteContent(ctx, &model.Object{Bucket: content.Bucket, Key: content.Key})
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"bucket": content.Bucket,
+			"key":    content.Key,
+		}).Error(err.Error())
+		orphan.Error = err.Error()
+		return orphan
+	}
+	orphan.Deleted = true
+	return orphan
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (uc *objectReconcileUsecase) InjectObjectRepo(repo model.ObjectRepository) error {
+	if repo == nil {
+		return errors.New("invalid object repository")
+	}
+	uc.objectRepo = repo
+	return nil
+}
+
+func (uc *objectReconcileUsecase) InjectObjectTypeRepo(repo model.ObjectTypeRepository) error {
+	if repo == nil {
+		return errors.New("invalid object type repository")
+	}
+	uc.objectTypeRepo = repo
+	return nil
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/spf13/viper"
+)
+
+func Test_objectReconcileUsecase_Reconcile(t *testing.T) {
+	var (
+		stale  = time.Now().Add(-48 * time.Hour)
+		fresh  = time.Now()
+		intact = &model.Object{ID: "1", Bucket: "default-bucket", Key: "user/1.png"}
+		lost   = &model.Object{ID: "2", Bucket: "default-bucket", Key: "user/2.png"}
+		media  = &model.Object{ID: "3", Bucket: "media", Key: "image/3.png"}
+	)
+	type mockList struct {
+		bucket string
+		token  string
+		page   *model.StoredContentPage
+		err    error
+	}
+	tests := []struct {
+		name              string
+		dryRun            bool
+		mockFindRows      []*model.Object
+		mockFindRowsErr   error
+		mockTypeBuckets   []string
+		mockList          []mockList
+		mockDeleteContent []error
+		wantMissing       []*model.Object
+		wantOrphans       []*model.ReconcileOrphan
+		wantErr           bool
+	}{
+		{
+			name:            "success delete stale orphan",
+			mockFindRows:    []*model.Object{intact, lost, media},
+			mockTypeBuckets: []string{"media"},
+			mockList: []mockList{
+				{bucket: "default-bucket", page: &model.StoredContentPage{
+					Contents: []*model.StoredContent{
+						{Bucket: "default-bucket", Key: "user/1.png", LastModified: stale},
+						{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale},
+					},
+					ContinuationToken: "next",
+				}},
+				{bucket: "default-bucket", token: "next", page: &model.StoredContentPage{
+					Contents: []*model.StoredContent{
+						{Bucket: "default-bucket", Key: "uploading.png", LastModified: fresh},
+					},
+				}},
+				{bucket: "media", page: &model.StoredContentPage{
+					Contents: []*model.StoredContent{
+						{Bucket: "media", Key: "image/3.png", LastModified: stale},
+					},
+				}},
+			},
+			mockDeleteContent: []error{nil},
+			wantMissing:       []*model.Object{lost},
+			wantOrphans: []*model.ReconcileOrphan{
+				{StoredContent: &model.StoredContent{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale}, Deleted: true},
+				{StoredContent: &model.StoredContent{Bucket: "default-bucket", Key: "uploading.png", LastModified: fresh}, WithinGrace: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "success dry run keeps orphans",
+			dryRun:       true,
+			mockFindRows: []*model.Object{intact},
+			mockList: []mockList{
+				{bucket: "default-bucket", page: &model.StoredContentPage{
+					Contents: []*model.StoredContent{
+						{Bucket: "default-bucket", Key: "user/1.png", LastModified: stale},
+						{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale},
+					},
+				}},
+			},
+			wantMissing: []*model.Object{},
+			wantOrphans: []*model.ReconcileOrphan{
+				{StoredContent: &model.StoredContent{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale}},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "success orphan delete failed",
+			mockFindRows: []*model.Object{},
+			mockList: []mockList{
+				{bucket: "default-bucket", page: &model.StoredContentPage{
+					Contents: []*model.StoredContent{
+						{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale},
+					},
+				}},
+			},
+			mockDeleteContent: []error{errors.New("s3 error")},
+			wantMissing:       []*model.Object{},
+			wantOrphans: []*model.ReconcileOrphan{
+				{StoredContent: &model.StoredContent{Bucket: "default-bucket", Key: "orphan.png", LastModified: stale}, Error: "s3 error"},
+			},
+			wantErr: false,
+		},
+		{
+			name:            "error find rows",
+			mockFindRowsErr: errors.New("db error"),
+			wantErr:         true,
+		},
+		{
+			name:         "error list bucket",
+			mockFindRows: []*model.Object{},
+			mockList: []mockList{
+				{bucket: "default-bucket", err: errors.New("s3 error")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("s3.bucket", "default-bucket")
+			viper.Set("reconcile.grace_period", "24h")
+			defer viper.Set("s3.bucket", nil)
+			defer viper.Set("reconcile.grace_period", nil)
+
+			ctx := context.TODO()
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+
+			objectRepo.EXPECT().
+				FindAllAfterID(gomock.Any(), "", gomock.Any()).
+				Times(1).
+				Return(tt.mockFindRows, tt.mockFindRowsErr)
+			if tt.mockFindRowsErr == nil {
+				objectTypeRepo.EXPECT().
+					FindBuckets(gomock.Any()).
+					Times(1).
+					Return(tt.mockTypeBuckets, nil)
+			}
+			for _, list := range tt.mockList {
+				objectRepo.EXPECT().
+					ListContent(gomock.Any(), list.bucket, list.token).
+					Times(1).
+					Return(list.page, list.err)
+			}
+			for _, err := range tt.mockDeleteContent {
+				objectRepo.EXPECT().
+					DeleteContent(gomock.Any(), &model.Object{Bucket: "default-bucket", Key: "orphan.png"}).
+					Times(1).
+					Return(err)
+			}
+
+			uc := NewObjectReconcileUsecase()
+			utils.ContinueOrFatal(uc.InjectObjectRepo(objectRepo))
+			utils.ContinueOrFatal(uc.InjectObjectTypeRepo(objectTypeRepo))
+
+			got, err := uc.Reconcile(ctx, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectReconcileUsecase.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.MissingContent, tt.wantMissing) {
+				t.Errorf("objectReconcileUsecase.Reconcile() missing = %v, want %v", got.MissingContent, tt.wantMissing)
+			}
+			if !reflect.DeepEqual(got.Orphans, tt.wantOrphans) {
+				t.Errorf("objectReconcileUsecase.Reconcile() orphans = %v, want %v", got.Orphans, tt.wantOrphans)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// AddReference claims the object for an entity, so a temporary object is no longer purged.
+func (uc *objectUsecase) AddReference(ctx context.Context, payload *model.ObjectReferencePayload) (reference *model.ObjectReference, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionReference, payload.ObjectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":     payload.ObjectID,
+		"ownerService": payload.OwnerService,
+		"entityType":   payload.EntityType,
+		"entityID":     payload.EntityID,
+	})
+
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := uc.findManageableObject(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	reference = &model.ObjectReference{
+		ID:           utils.GenerateUUID(),
+		ObjectID:     object.ID,
+		OwnerService: payload.OwnerService,
+		EntityType:   payload.EntityType,
+		EntityID:     payload.EntityID,
+		CreatedBy:    getUserIDFromCtx(ctx),
+	}
+	err = uc.objectReferenceRepo.Create(ctx, reference)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return reference, nil
+}
+
+// RemoveReference releases the claim of an entity, an expired temporary object
+// without references left is purged by the next gc run.
+func (uc *objectUsecase) RemoveReference(ctx context.Context, payload *model.ObjectReferencePayload) (err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionUnreference, payload.ObjectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":     payload.ObjectID,
+		"ownerService": payload.OwnerService,
+		"entityType":   payload.EntityType,
+		"entityID":     payload.EntityID,
+	})
+
+	err = payload.Validate()
+	if err != nil {
+		return err
+	}
+
+	object, err := uc.findManageableObject(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	payload.ObjectID = object.ID
+	err = uc.objectReferenceRepo.Delete(ctx, payload)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_objectUsecase_AddReference(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+	)
+	type mockFindObjectByID struct {
+		res *model.Object
+		err error
+	}
+	tests := []struct {
+		name               string
+		payload            *model.ObjectReferencePayload
+		mockFindObjectByID *mockFindObjectByID
+		mockHasAccess      *wrapperspb.BoolValue
+		wantCreate         bool
+		wantErr            error
+	}{
+		{
+			name: "success reference own object",
+			payload: &model.ObjectReferencePayload{
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+				EntityType:   "product",
+				EntityID:     "1",
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: userID},
+			},
+			wantCreate: true,
+			wantErr:    nil,
+		},
+		{
+			name: "error invalid reference",
+			payload: &model.ObjectReferencePayload{
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+			},
+			wantErr: model.ErrInvalidObjectReference,
+		},
+		{
+			name: "error object not found",
+			payload: &model.ObjectReferencePayload{
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+				EntityType:   "product",
+				EntityID:     "1",
+			},
+			mockFindObjectByID: &mockFindObjectByID{},
+			wantErr:            model.ErrObjectNotFound,
+		},
+		{
+			name: "error unauthorized reference",
+			payload: &model.ObjectReferencePayload{
+				ObjectID:     objectID,
+				OwnerService: "product-service",
+				EntityType:   "product",
+				EntityID:     "1",
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: "other-user"},
+			},
+			mockHasAccess: wrapperspb.Bool(false),
+			wantErr:       model.ErrUnauthorizeAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectReferenceRepo := mock.NewMockObjectReferenceRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionReference, objectID, gomock.Any()).
+				Times(1)
+
+			if tt.mockFindObjectByID != nil {
+				objectRepo.EXPECT().
+					FindByID(gomock.Any(), objectID).
+					Times(1).
+					Return(tt.mockFindObjectByID.res, tt.mockFindObjectByID.err)
+			}
+			if tt.mockHasAccess != nil {
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess, nil)
+			}
+			if tt.wantCreate {
+				objectReferenceRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectReferenceRepo(objectReferenceRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.AddReference(ctx, tt.payload)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.AddReference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.ObjectID != objectID || got.CreatedBy != userID) {
+				t.Errorf("objectUsecase.AddReference() = %v", got)
+			}
+		})
+	}
+}
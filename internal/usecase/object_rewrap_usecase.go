@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const rewrapBatchSize = 500
+
+type objectRewrapUsecase struct {
+	objectRepo model.ObjectRepository
+	keyring    model.Keyring
+}
+
+func NewObjectRewrapUsecase() model.ObjectRewrapUsecase {
+	return new(objectRewrapUsecase)
+}
+
+func (uc *objectRewrapUsecase) Rewrap(ctx context.Context, dryRun bool) (*model.ObjectRewrapReport, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	activeKeyID := uc.keyring.ActiveKeyID()
+	logger := logrus.WithFields(logrus.Fields{
+		"activeKeyID": activeKeyID,
+		"dryRun":      dryRun,
+	})
+
+	report := model.NewObjectRewrapReport(activeKeyID)
+	if activeKeyID == "" {
+		return report, model.ErrEncryptionKeyNotFound
+	}
+
+	afterID := ""
+	for {
+		objects, err := uc.objectRepo.FindAllAfterID(ctx, afterID, rewrapBatchSize)
+		if err != nil {
+			logger.Error(err.Error())
+			return report, err
+		}
+
+		for _, object := range objects {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			afterID = object.ID
+			if !object.IsEncrypted() {
+				continue
+			}
+			report.Checked++
+			if object.EncryptionKeyID == activeKeyID {
+				continue
+			}
+			report.Pending++
+			if dryRun {
+				continue
+			}
+
+			rewrapped, err := uc.rewrap(ctx, object)
+			if err != nil {
+				logger.WithField("objectID", object.ID).Error(err.Error())
+				report.Failures = append(report.Failures, &model.ObjectRewrapFailure{Object: object, Error: err.Error()})
+				continue
+			}
+			if rewrapped {
+				report.Rewrapped++
+			}
+		}
+
+		if len(objects) < rewrapBatchSize {
+			break
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"checked":   report.Checked,
+		"pending":   report.Pending,
+		"rewrapped": report.Rewrapped,
+		"failures":  len(report.Failures),
+	}).Info("data keys rewrapped")
+	return report, nil
+}
+
+// rewrap moves the data key of object to the active master key, false means the
+// object was rewrapped concurrently.
+func (uc *objectRewrapUsecase) rewrap(ctx context.Context, object *model.Object) (bool, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	wrapped, err := base64.StdEncoding.DecodeString(object.WrappedDataKey)
+	if err != nil {
+		return false, err
+	}
+	dataKey, err := uc.keyring.UnwrapKey(object.EncryptionKeyID, wrapped)
+	if err != nil {
+		return false, err
+	}
+	keyID, wrapped, err := uc.keyring.WrapKey(dataKey)
+	if err != nil {
+		return false, err
+	}
+
+	previousKeyID := object.EncryptionKeyID
+	object.EncryptionKeyID = keyID
+	object.WrappedDataKey = base64.StdEncoding.EncodeToString(wrapped)
+	return uc.objectRepo.UpdateWrappedDataKey(ctx, object, previousKeyID)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (uc *objectRewrapUsecase) InjectObjectRepo(repo model.ObjectRepository) error {
+	if repo == nil {
+		return errors.New("invalid object repository")
+	}
+	uc.objectRepo = repo
+	return nil
+}
+
+func (uc *objectRewrapUsecase) InjectKeyring(keyring model.Keyring) error {
+	if keyring == nil {
+		return errors.New("invalid keyring")
+	}
+	uc.keyring = keyring
+	return nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+)
+
+func Test_objectRewrapUsecase_Rewrap(t *testing.T) {
+	var (
+		wrapped = base64.StdEncoding.EncodeToString([]byte("wrapped"))
+		plain   = &model.Object{ID: "1"}
+		current = &model.Object{ID: "2", EncryptionKeyID: "k2", WrappedDataKey: wrapped}
+		retired = &model.Object{ID: "3", EncryptionKeyID: "k1", WrappedDataKey: wrapped}
+		raced   = &model.Object{ID: "4", EncryptionKeyID: "k1", WrappedDataKey: wrapped}
+		lost    = &model.Object{ID: "5", EncryptionKeyID: "k0", WrappedDataKey: wrapped}
+		// rewrapping updates the object in place, the dry run gets its own
+		stale = &model.Object{ID: "6", EncryptionKeyID: "k1", WrappedDataKey: wrapped}
+	)
+	tests := []struct {
+		name          string
+		dryRun        bool
+		activeKeyID   string
+		mockFind      []*model.Object
+		mockFindErr   error
+		mockUnwrap    map[string]error
+		mockUpdate    map[string]bool
+		wantPending   int
+		wantRewrapped int
+		wantFailures  int
+		wantErr       bool
+	}{
+		{
+			name:          "success",
+			activeKeyID:   "k2",
+			mockFind:      []*model.Object{plain, current, retired, raced, lost},
+			mockUnwrap:    map[string]error{retired.ID: nil, raced.ID: nil, lost.ID: model.ErrEncryptionKeyNotFound},
+			mockUpdate:    map[string]bool{retired.ID: true, raced.ID: false},
+			wantPending:   3,
+			wantRewrapped: 1,
+			wantFailures:  1,
+			wantErr:       false,
+		},
+		{
+			name:        "success dry run",
+			dryRun:      true,
+			activeKeyID: "k2",
+			mockFind:    []*model.Object{plain, current, stale},
+			wantPending: 1,
+			wantErr:     false,
+		},
+		{
+			name:        "error no active key",
+			activeKeyID: "",
+			wantErr:     true,
+		},
+		{
+			name:        "error find objects",
+			activeKeyID: "k2",
+			mockFindErr: errors.New("db error"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			keyring := mock.NewMockKeyring(ctrl)
+
+			keyring.EXPECT().ActiveKeyID().Times(1).Return(tt.activeKeyID)
+			if tt.activeKeyID != "" {
+				objectRepo.EXPECT().
+					FindAllAfterID(gomock.Any(), "", gomock.Any()).
+					Times(1).
+					Return(tt.mockFind, tt.mockFindErr)
+			}
+			for _, object := range tt.mockFind {
+				err, ok := tt.mockUnwrap[object.ID]
+				if !ok {
+					continue
+				}
+				keyring.EXPECT().
+					UnwrapKey(object.EncryptionKeyID, []byte("wrapped")).
+					Times(1).
+					Return([]byte("data key"), err)
+				if err != nil {
+					continue
+				}
+				keyring.EXPECT().
+					WrapKey([]byte("data key")).
+					Times(1).
+					Return(tt.activeKeyID, []byte("rewrapped"), nil)
+				objectRepo.EXPECT().
+					UpdateWrappedDataKey(gomock.Any(), object, "k1").
+					Times(1).
+					Return(tt.mockUpdate[object.ID], nil)
+			}
+
+			uc := NewObjectRewrapUsecase()
+			_ = uc.InjectObjectRepo(objectRepo)
+			_ = uc.InjectKeyring(keyring)
+
+			report, err := uc.Rewrap(ctx, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectRewrapUsecase.Rewrap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if report.Pending != tt.wantPending || report.Rewrapped != tt.wantRewrapped || len(report.Failures) != tt.wantFailures {
+				t.Errorf("objectRewrapUsecase.Rewrap() pending = %d, rewrapped = %d, failures = %d", report.Pending, report.Rewrapped, len(report.Failures))
+			}
+		})
+	}
+}
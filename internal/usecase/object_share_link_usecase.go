@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	shareTokenSize = 32
+	// bcrypt ignores everything past 72 bytes.
+	maxShareLinkPasswordLength = 72
+)
+
+func (uc *objectUsecase) CreateShareLink(ctx context.Context, payload *model.CreateShareLinkPayload) (created *model.CreatedShareLink, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionShare, payload.ObjectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":     payload.ObjectID,
+		"maxDownloads": payload.MaxDownloads,
+	})
+
+	object, err := uc.findManageableObject(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	expiredAt := now.Add(config.ShareLinkDefaultTTL())
+	if payload.ExpiredAt != nil {
+		expiredAt = *payload.ExpiredAt
+	}
+	if !expiredAt.After(now) || expiredAt.After(now.Add(config.ShareLinkMaxTTL())) ||
+		payload.MaxDownloads < 0 || len(payload.Password) > maxShareLinkPasswordLength {
+		return nil, model.ErrInvalidShareLink
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	shareLink := &model.ShareLink{
+		ID:           utils.GenerateUUID(),
+		ObjectID:     object.ID,
+		TokenHash:    model.HashShareToken(token),
+		MaxDownloads: payload.MaxDownloads,
+		CreatedBy:    getUserIDFromCtx(ctx),
+		ExpiredAt:    expiredAt.UTC(),
+		CreatedAt:    now.UTC(),
+	}
+	if payload.Password != "" {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		hash := string(passwordHash)
+		shareLink.PasswordHash = &hash
+	}
+
+	err = uc.shareLinkRepo.Create(ctx, shareLink)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &model.CreatedShareLink{
+		ShareLink: shareLink,
+		Token:     token,
+	}, nil
+}
+
+func (uc *objectUsecase) ListShareLinks(ctx context.Context, objectID string) ([]*model.ShareLink, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+	})
+
+	object, err := uc.findManageableObject(ctx, objectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	shareLinks, err := uc.shareLinkRepo.FindByObjectID(ctx, object.ID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return shareLinks, nil
+}
+
+func (uc *objectUsecase) RevokeShareLink(ctx context.Context, objectID string, id string) (err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionShareRevoke, objectID, err)
+	}()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": objectID,
+		"id":       id,
+	})
+
+	object, err := uc.findManageableObject(ctx, objectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	err = uc.shareLinkRepo.Revoke(ctx, object.ID, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ResolveShareLink counts a download of the link and signs a fresh url for it,
+// encrypted objects are returned as a stream instead.
+func (uc *objectUsecase) ResolveShareLink(ctx context.Context, payload *model.ResolveShareLinkPayload) (content *model.ObjectContent, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	objectID := ""
+	defer func() {
+		uc.auditLogUC.Record(ctx, model.AuditActionShareDownload, objectID, err)
+	}()
+
+	shareLink, err := uc.shareLinkRepo.FindByTokenHash(ctx, model.HashShareToken(payload.Token))
+	if err != nil {
+		return nil, err
+	}
+	if shareLink == nil {
+		return nil, model.ErrShareLinkNotFound
+	}
+	objectID = shareLink.ObjectID
+	ctx = utils.NewTenantContext(ctx, shareLink.TenantID)
+
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID":    shareLink.ObjectID,
+		"shareLinkID": shareLink.ID,
+	})
+
+	if !shareLink.IsUsable(time.Now()) {
+		return nil, model.ErrShareLinkUnavailable
+	}
+	if shareLink.HasPassword() {
+		if shareLink.IsLocked(time.Now()) {
+			return nil, model.ErrShareLinkLocked
+		}
+		err = bcrypt.CompareHashAndPassword([]byte(*shareLink.PasswordHash), []byte(payload.Password))
+		if err != nil {
+			countErr := uc.shareLinkRepo.CountFailedAttempt(ctx, shareLink.ID, config.ShareLinkMaxPasswordAttempts(), config.ShareLinkLockoutDuration())
+			if countErr != nil {
+				logger.Error(countErr.Error())
+			}
+			return nil, model.ErrShareLinkWrongPassword
+		}
+	}
+
+	object, err := uc.objectRepo.FindByID(ctx, shareLink.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if object == nil {
+		return nil, model.ErrObjectNotFound
+	}
+
+	objectType, err := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if objectType == nil {
+		return nil, model.ErrObjectNotFound
+	}
+	object.SetType(objectType.Name)
+
+	// encrypted objects can not be presigned, they are streamed like the content proxy does
+	if object.IsEncrypted() {
+		content, err = uc.objectRepo.GetContent(ctx, object, &model.GetObjectContentPayload{ObjectID: object.ID})
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	} else {
+		// never the cached url of the owner, it would outlive the link
+		presignedObject, err := uc.objectRepo.SignPresignedURL(ctx, object, config.ShareLinkPresignTTL())
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		content = &model.ObjectContent{Object: object, RedirectURL: presignedObject.URL}
+	}
+
+	// counted last so a failed read does not use up a download
+	err = uc.shareLinkRepo.CountDownload(ctx, shareLink.ID)
+	if err != nil {
+		logger.Error(err.Error())
+		if content.Body != nil {
+			_ = content.Body.Close()
+		}
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func generateShareToken() (string, error) {
+	b := make([]byte, shareTokenSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_objectUsecase_ResolveShareLink(t *testing.T) {
+	var (
+		objectID    = utils.GenerateUUID()
+		typeID      = utils.GenerateUUID()
+		shareLinkID = utils.GenerateUUID()
+		token       = "token"
+		expiredAt   = time.Now().Add(time.Hour)
+		revokedAt   = time.Now().Add(-time.Minute)
+		lockedUntil = time.Now().Add(time.Minute)
+	)
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	utils.ContinueOrFatal(err)
+	hash := string(passwordHash)
+
+	presigned := &model.GetPresignedURLResponse{
+		ID:  objectID,
+		URL: "https://s3.bucket/test.jpg",
+	}
+	type mockFindShareLink struct {
+		res *model.ShareLink
+		err error
+	}
+	tests := []struct {
+		name              string
+		payload           *model.ResolveShareLinkPayload
+		mockFindShareLink *mockFindShareLink
+		wantPresign       bool
+		wantStream        bool
+		mockCountDownload error
+		wantCountFailure  bool
+		want              *model.ObjectContent
+		wantErr           error
+	}{
+		{
+			name:    "success",
+			payload: &model.ResolveShareLinkPayload{Token: token},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt},
+			},
+			wantPresign: true,
+			want:        &model.ObjectContent{RedirectURL: presigned.URL},
+			wantErr:     nil,
+		},
+		{
+			name:    "success with password",
+			payload: &model.ResolveShareLinkPayload{Token: token, Password: "secret"},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt, PasswordHash: &hash},
+			},
+			wantPresign: true,
+			want:        &model.ObjectContent{RedirectURL: presigned.URL},
+			wantErr:     nil,
+		},
+		{
+			name:    "success encrypted object is streamed",
+			payload: &model.ResolveShareLinkPayload{Token: token},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt},
+			},
+			wantStream: true,
+			want:       &model.ObjectContent{Body: io.NopCloser(strings.NewReader("plain"))},
+			wantErr:    nil,
+		},
+		{
+			name:    "error wrong password",
+			payload: &model.ResolveShareLinkPayload{Token: token, Password: "guess"},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt, PasswordHash: &hash},
+			},
+			wantCountFailure: true,
+			want:             nil,
+			wantErr:          model.ErrShareLinkWrongPassword,
+		},
+		{
+			name:    "error share link locked",
+			payload: &model.ResolveShareLinkPayload{Token: token, Password: "secret"},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt, PasswordHash: &hash, LockedUntil: &lockedUntil},
+			},
+			want:    nil,
+			wantErr: model.ErrShareLinkLocked,
+		},
+		{
+			name:              "error share link not found",
+			payload:           &model.ResolveShareLinkPayload{Token: token},
+			mockFindShareLink: &mockFindShareLink{},
+			want:              nil,
+			wantErr:           model.ErrShareLinkNotFound,
+		},
+		{
+			name:    "error share link revoked",
+			payload: &model.ResolveShareLinkPayload{Token: token},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt, RevokedAt: &revokedAt},
+			},
+			want:    nil,
+			wantErr: model.ErrShareLinkUnavailable,
+		},
+		{
+			name:    "error share link used up",
+			payload: &model.ResolveShareLinkPayload{Token: token},
+			mockFindShareLink: &mockFindShareLink{
+				res: &model.ShareLink{ID: shareLinkID, ObjectID: objectID, ExpiredAt: expiredAt, MaxDownloads: 1},
+			},
+			wantPresign:       true,
+			mockCountDownload: model.ErrShareLinkUnavailable,
+			want:              nil,
+			wantErr:           model.ErrShareLinkUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, constant.GuestID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			shareLinkRepo := mock.NewMockShareLinkRepository(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionShareDownload, gomock.Any(), tt.wantErr).
+				Times(1)
+
+			shareLinkRepo.EXPECT().
+				FindByTokenHash(gomock.Any(), model.HashShareToken(tt.payload.Token)).
+				Times(1).
+				Return(tt.mockFindShareLink.res, tt.mockFindShareLink.err)
+
+			if tt.wantCountFailure {
+				shareLinkRepo.EXPECT().
+					CountFailedAttempt(gomock.Any(), shareLinkID, config.ShareLinkMaxPasswordAttempts(), config.ShareLinkLockoutDuration()).
+					Times(1).
+					Return(nil)
+			}
+
+			if tt.wantPresign || tt.wantStream {
+				object := &model.Object{ID: objectID, TypeID: typeID}
+				if tt.wantStream {
+					object.EncryptionKeyID = "k1"
+				}
+				objectRepo.EXPECT().
+					FindByID(gomock.Any(), objectID).
+					Times(1).
+					Return(object, nil)
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "image"}, nil)
+				if tt.wantPresign {
+					objectRepo.EXPECT().
+						SignPresignedURL(gomock.Any(), object, config.ShareLinkPresignTTL()).
+						Times(1).
+						Return(presigned, nil)
+				} else {
+					objectRepo.EXPECT().
+						GetContent(gomock.Any(), object, &model.GetObjectContentPayload{ObjectID: objectID}).
+						Times(1).
+						Return(&model.ObjectContent{Object: object, Body: tt.want.Body}, nil)
+				}
+				shareLinkRepo.EXPECT().
+					CountDownload(gomock.Any(), shareLinkID).
+					Times(1).
+					Return(tt.mockCountDownload)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectTypeRepo(objectTypeRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectShareLinkRepo(shareLinkRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.ResolveShareLink(ctx, tt.payload)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.ResolveShareLink() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("objectUsecase.ResolveShareLink() = %v, want nil", got)
+				}
+				return
+			}
+			if got.RedirectURL != tt.want.RedirectURL || got.Body != tt.want.Body {
+				t.Errorf("objectUsecase.ResolveShareLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
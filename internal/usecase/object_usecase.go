@@ -3,12 +3,16 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"errors"
 	"mime"
 	"net/http"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/krobus00/storage-service/internal/config"
 	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/metrics"
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/nats-io/nats.go"
 
@@ -21,7 +25,13 @@ type objectUsecase struct {
 	objectRepo              model.ObjectRepository
 	objectTypeRepo          model.ObjectTypeRepository
 	ObjectWhitelistTypeRepo model.ObjectWhitelistTypeRepository
+	objectGrantRepo         model.ObjectGrantRepository
+	shareLinkRepo           model.ShareLinkRepository
+	objectReferenceRepo     model.ObjectReferenceRepository
+	pendingDeletionRepo     model.PendingDeletionRepository
+	storageUsageUC          model.StorageUsageUsecase
 	authClient              authPB.AuthServiceClient
+	auditLogUC              model.AuditLogUsecase
 	jsClient                nats.JetStreamContext
 }
 
@@ -47,11 +57,22 @@ func (uc *objectUsecase) CreateStream() error {
 	return nil
 }
 
-func (uc *objectUsecase) Upload(ctx context.Context, payload *model.ObjectPayload) (*model.Object, error) {
+func (uc *objectUsecase) Upload(ctx context.Context, payload *model.ObjectPayload) (object *model.Object, err error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
+	start := time.Now()
+	typeLabel := metrics.UnknownLabel
+	defer func() {
+		metrics.ObserveUpload(typeLabel, len(payload.Src), start, err)
+		objectID := ""
+		if err == nil {
+			objectID = object.ID
+		}
+		uc.auditLogUC.Record(ctx, model.AuditActionUpload, objectID, err)
+	}()
+
 	logger := logrus.WithFields(logrus.Fields{
 		"objectKey": payload.Object.Key,
 		"fileName":  payload.Object.FileName,
@@ -60,7 +81,7 @@ func (uc *objectUsecase) Upload(ctx context.Context, payload *model.ObjectPayloa
 
 	userID := getUserIDFromCtx(ctx)
 
-	err := hasAccess(ctx, uc.authClient, []string{
+	err = hasAccess(ctx, uc.authClient, []string{
 		constant.PermissionFullAccess,
 		constant.PermissionObjectAll,
 		constant.PermissionObjectCreate,
@@ -76,26 +97,73 @@ func (uc *objectUsecase) Upload(ctx context.Context, payload *model.ObjectPayloa
 		return nil, err
 	}
 	if objectType == nil {
+		metrics.ObserveValidationRejection(metrics.UnknownLabel, metrics.RejectReasonUnknownType)
 		return nil, model.ErrObjectTypeNotFound
 	}
+	typeLabel = objectType.Name
+
+	err = payload.Object.Metadata.Validate()
+	if err != nil {
+		return nil, err
+	}
 
-	err = uc.validationObjectType(ctx, payload.Src, objectType.ID)
+	err = model.VerifyChecksums(payload.Src, payload.ContentMD5, payload.ChecksumSHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	err = model.ValidateIdempotencyKey(payload.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if payload.IdempotencyKey != "" {
+		object, err = uc.findIdempotentUpload(ctx, userID, payload, objectType)
+		if err != nil || object != nil {
+			return object, err
+		}
+	}
+
+	err = uc.validationObjectType(ctx, payload.Src, objectType)
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
 	}
+
+	size := int64(len(payload.Src))
+	// fails early before the content is written, the quota is enforced again when storing the row
+	err = uc.storageUsageUC.CheckQuota(ctx, userID, size)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
 	newObject := model.NewObject().
 		SetID(utils.GenerateUUID()).
 		SetTypeID(objectType.ID).
 		SetType(objectType.Name).
 		SetUploadedBy(userID).
 		SetFileName(payload.Object.FileName).
-		SetKey(model.DefaultPath).
-		SetIsPublic(payload.Object.IsPublic)
+		SetIsPublic(payload.Object.IsPublic).
+		SetSize(size).
+		SetChecksumSHA256(model.SHA256Hex(payload.Src)).
+		SetMetadata(payload.Object.Metadata)
+	if payload.Temporary {
+		temporaryUntil := time.Now().Add(config.TemporaryObjectTTL())
+		newObject.SetTemporaryUntil(&temporaryUntil)
+	}
 
-	payload.SetObject(newObject)
+	payload.SetObject(newObject).SetObjectType(objectType)
+	payload.Quota = uploadQuotaOf(utils.GetTenantIDFromContext(ctx), userID)
 
 	err = uc.objectRepo.Create(ctx, payload)
+	if errors.Is(err, model.ErrIdempotencyKeyTaken) {
+		// a concurrent retry stored the object first
+		object, err = uc.findIdempotentUpload(ctx, userID, payload, objectType)
+		if err == nil && object == nil {
+			err = model.ErrIdempotencyKeyTaken
+		}
+		return object, err
+	}
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
@@ -103,13 +171,37 @@ func (uc *objectUsecase) Upload(ctx context.Context, payload *model.ObjectPayloa
 	return payload.Object, nil
 }
 
-func (uc *objectUsecase) GeneratePresignedURL(ctx context.Context, payload *model.GetPresignedURLPayload) (*model.GetPresignedURLResponse, error) {
+// findIdempotentUpload returns the object an earlier upload with the key created,
+// reusing the key for a different upload is rejected.
+func (uc *objectUsecase) findIdempotentUpload(ctx context.Context, userID string, payload *model.ObjectPayload, objectType *model.ObjectType) (*model.Object, error) {
+	object, err := uc.objectRepo.FindByIdempotencyKey(ctx, userID, payload.IdempotencyKey)
+	if err != nil || object == nil {
+		return nil, err
+	}
+	if object.TypeID != objectType.ID || object.Size != int64(len(payload.Src)) {
+		return nil, model.ErrIdempotencyKeyReused
+	}
+	if object.ChecksumSHA256 != "" && object.ChecksumSHA256 != model.SHA256Hex(payload.Src) {
+		return nil, model.ErrIdempotencyKeyReused
+	}
+	return object.SetType(objectType.Name), nil
+}
+
+func (uc *objectUsecase) GeneratePresignedURL(ctx context.Context, payload *model.GetPresignedURLPayload) (presignedObject *model.GetPresignedURLResponse, err error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
+	start := time.Now()
+	typeLabel := metrics.UnknownLabel
+	defer func() {
+		metrics.ObservePresign(typeLabel, start, err)
+		uc.auditLogUC.Record(ctx, model.AuditActionPresign, payload.ObjectID, err)
+	}()
+
 	logger := logrus.WithFields(logrus.Fields{
-		"objectID": payload.ObjectID,
+		"objectID":    payload.ObjectID,
+		"minValidity": payload.MinValidity,
 	})
 
 	object, err := uc.objectRepo.FindByID(ctx, payload.ObjectID)
@@ -137,8 +229,9 @@ func (uc *objectUsecase) GeneratePresignedURL(ctx context.Context, payload *mode
 		return nil, model.ErrObjectNotFound
 	}
 	object.SetType(objectType.Name)
+	typeLabel = objectType.Name
 
-	presignedObject, err := uc.objectRepo.GeneratePresignedURL(ctx, object)
+	presignedObject, err = uc.objectRepo.GeneratePresignedURL(ctx, object, payload.MinValidity)
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
@@ -147,15 +240,109 @@ func (uc *objectUsecase) GeneratePresignedURL(ctx context.Context, payload *mode
 	return presignedObject, nil
 }
 
-func (uc *objectUsecase) DeleteObject(ctx context.Context, id string) error {
+func (uc *objectUsecase) UpdateObject(ctx context.Context, payload *model.UpdateObjectPayload) (object *model.Object, err error) {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
 
+	userID := getUserIDFromCtx(ctx)
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": payload.ObjectID,
+		"userID":   userID,
+		"version":  payload.Version,
+	})
+
+	action := model.AuditActionUpdate
+	defer func() {
+		uc.auditLogUC.Record(ctx, action, payload.ObjectID, err)
+	}()
+
+	err = payload.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if payload.IsPublic != nil {
+		action = model.AuditActionVisibilityChange
+	}
+
+	object, err = uc.objectRepo.FindByID(ctx, payload.ObjectID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if object == nil {
+		return nil, model.ErrObjectNotFound
+	}
+
+	err = uc.canUpdate(ctx, object)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	// fail early instead of writing over a version the caller never saw
+	if object.Version != payload.Version {
+		return nil, model.ErrObjectVersionConflict
+	}
+
+	changes := payload.Apply(object)
+	if len(changes) == 0 {
+		return object, nil
+	}
+
+	err = uc.objectRepo.Update(ctx, object, changes)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	objectType, err := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if objectType != nil {
+		object.SetType(objectType.Name)
+	}
+
+	jsPayload := model.JSUpdateObjectPayload{
+		TenantID:  object.TenantID,
+		ObjectID:  object.ID,
+		UpdatedBy: userID,
+		Version:   object.Version,
+		Changes:   make([]string, 0, len(changes)),
+	}
+	for column := range changes {
+		jsPayload.Changes = append(jsPayload.Changes, column)
+	}
+	sort.Strings(jsPayload.Changes)
+
+	// the update is committed, a failed event must not fail the request
+	err = publishJS(ctx, uc.jsClient, model.ObjectUpdatedSubject, jsPayload)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return object, nil
+}
+
+func (uc *objectUsecase) DeleteObject(ctx context.Context, id string) (err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
 	logger := logrus.WithFields(logrus.Fields{
 		"objectID": id,
+		"userID":   userID,
 	})
 
+	typeLabel := metrics.UnknownLabel
+	defer func() {
+		metrics.ObserveDelete(typeLabel, err)
+		uc.auditLogUC.Record(ctx, model.AuditActionDelete, id, err)
+	}()
+
 	object, err := uc.objectRepo.FindByID(ctx, id)
 	if err != nil {
 		logger.Error(err.Error())
@@ -166,14 +353,104 @@ func (uc *objectUsecase) DeleteObject(ctx context.Context, id string) error {
 		return model.ErrObjectNotFound
 	}
 
+	err = uc.canDelete(ctx, object)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	// the type only labels metrics, a failed lookup must not block the delete
+	objectType, typeErr := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+	if typeErr == nil && objectType != nil {
+		typeLabel = objectType.Name
+	}
+
 	err = uc.objectRepo.DeleteByID(ctx, id)
 	if err != nil {
 		logger.Error(err.Error())
 		return err
 	}
 
+	publishObjectDeleted(ctx, uc.jsClient, object, userID)
+
+	return nil
+}
+
+// PurgeObject deletes the object for an operator, without access checks,
+// and deletes its content right away instead of leaving it to the reconcile.
+func (uc *objectUsecase) PurgeObject(ctx context.Context, id string) (object *model.Object, err error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+	logger := logrus.WithFields(logrus.Fields{
+		"objectID": id,
+		"userID":   userID,
+	})
+
+	typeLabel := metrics.UnknownLabel
+	defer func() {
+		metrics.ObserveDelete(typeLabel, err)
+		uc.auditLogUC.Record(ctx, model.AuditActionDelete, id, err)
+	}()
+
+	object, err = uc.objectRepo.FindByID(ctx, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+	if object == nil {
+		return nil, model.ErrObjectNotFound
+	}
+
+	objectType, typeErr := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+	if typeErr == nil && objectType != nil {
+		typeLabel = objectType.Name
+		object.SetType(objectType.Name)
+	}
+
+	err = uc.objectRepo.DeleteByID(ctx, id)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	publishObjectDeleted(ctx, uc.jsClient, object, userID)
+
+	// the row is gone, a failed delete is left to the gc retries
+	contentErr := uc.objectRepo.DeleteContent(ctx, object)
+	if contentErr != nil {
+		logger.Error(contentErr.Error())
+		bucket := object.Bucket
+		if bucket == "" {
+			bucket = config.TenantS3BucketName(object.TenantID)
+		}
+		contentErr = uc.pendingDeletionRepo.Create(ctx, &model.PendingDeletion{
+			ID:        utils.GenerateUUID(),
+			Bucket:    bucket,
+			Key:       object.Key,
+			Reason:    "object purged",
+			LastError: contentErr.Error(),
+		})
+		if contentErr != nil {
+			logger.Error(contentErr.Error())
+		}
+	}
+
+	return object, nil
+}
+
+// publishObjectDeleted tells the subscribers the object is gone, a failed publish is only logged.
+func publishObjectDeleted(ctx context.Context, jsClient nats.JetStreamContext, object *model.Object, deletedBy string) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
 	jsPayload := model.JSDeleteObjectPayload{
-		ObjectID: object.ID,
+		TenantID:  object.TenantID,
+		ObjectID:  object.ID,
+		DeletedBy: deletedBy,
 	}
 
 	wg := sync.WaitGroup{}
@@ -181,16 +458,70 @@ func (uc *objectUsecase) DeleteObject(ctx context.Context, id string) error {
 		wg.Add(1)
 		go func(subject string) {
 			defer wg.Done()
-			err = publishJS(ctx, uc.jsClient, subject, jsPayload)
+			err := publishJS(ctx, jsClient, subject, jsPayload)
 			if err != nil {
-				logger.Error(err.Error())
+				logrus.WithField("objectID", object.ID).Error(err.Error())
 			}
 		}(subject)
 	}
 
 	wg.Wait()
+}
 
-	return nil
+func (uc *objectUsecase) ListObjects(ctx context.Context, payload *model.ListObjectsPayload) ([]*model.Object, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	payload.Normalize()
+	userID := getUserIDFromCtx(ctx)
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID": userID,
+		"limit":  payload.Limit,
+		"offset": payload.Offset,
+	})
+
+	err := payload.Metadata.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// guest uploads are shared by every guest, listing them would leak them
+	if userID == constant.GuestID {
+		return nil, model.ErrUnauthorizeAccess
+	}
+
+	groupIDs, err := getUserGroupIDs(ctx, uc.authClient, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	objects, err := uc.objectRepo.FindAll(ctx, &model.ObjectFilter{
+		UserID:   userID,
+		GroupIDs: groupIDs,
+		Metadata: payload.Metadata,
+		Limit:    payload.Limit,
+		Offset:   payload.Offset,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	for _, object := range objects {
+		objectType, err := uc.objectTypeRepo.FindByID(ctx, object.TypeID)
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		if objectType != nil {
+			object.SetType(objectType.Name)
+		}
+	}
+
+	return objects, nil
 }
 
 func (uc *objectUsecase) hasAccess(ctx context.Context, object *model.Object) error {
@@ -206,6 +537,15 @@ func (uc *objectUsecase) hasAccess(ctx context.Context, object *model.Object) er
 	if object.UploadedBy == userID {
 		return nil
 	}
+
+	granted, err := uc.hasGrant(ctx, object, model.GrantPermissionRead)
+	if err != nil {
+		return err
+	}
+	if granted {
+		return nil
+	}
+
 	if !object.IsPublic {
 		err := hasAccess(ctx, uc.authClient, []string{
 			constant.PermissionFullAccess,
@@ -215,11 +555,14 @@ func (uc *objectUsecase) hasAccess(ctx context.Context, object *model.Object) er
 		if err == nil {
 			return nil
 		}
+		if !errors.Is(err, model.ErrUnauthorizeAccess) {
+			return err
+		}
 	}
 	return model.ErrUnauthorizeAccess
 }
 
-func (uc *objectUsecase) validationObjectType(ctx context.Context, data []byte, typeID string) error {
+func (uc *objectUsecase) validationObjectType(ctx context.Context, data []byte, objectType *model.ObjectType) error {
 	_, _, fn := utils.Trace()
 	ctx, span := utils.NewSpan(ctx, fn)
 	defer span.End()
@@ -231,13 +574,64 @@ func (uc *objectUsecase) validationObjectType(ctx context.Context, data []byte,
 	if err != nil {
 		return err
 	}
+	if len(exts) == 0 {
+		metrics.ObserveValidationRejection(objectType.Name, metrics.RejectReasonUnknownContentType)
+		return model.ErrExtensionNotAllowed
+	}
 
-	whiteList, err := uc.ObjectWhitelistTypeRepo.FindByTypeIDAndExt(ctx, typeID, exts[0])
+	whiteList, err := uc.ObjectWhitelistTypeRepo.FindByTypeIDAndExt(ctx, objectType.ID, exts[0])
 	if err != nil {
 		return err
 	}
 	if whiteList == nil {
+		metrics.ObserveValidationRejection(objectType.Name, metrics.RejectReasonExtensionNotAllowed)
 		return model.ErrExtensionNotAllowed
 	}
 	return nil
 }
+
+// canDelete allows the owner, a delete grant or an OBJECT_DELETE permission holder.
+func (uc *objectUsecase) canDelete(ctx context.Context, object *model.Object) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+	// guest uploads are shared by every guest, guests must not delete them
+	if userID != constant.GuestID && object.UploadedBy == userID {
+		return nil
+	}
+
+	granted, err := uc.hasGrant(ctx, object, model.GrantPermissionDelete)
+	if err != nil {
+		return err
+	}
+	if granted {
+		return nil
+	}
+
+	return hasAccess(ctx, uc.authClient, []string{
+		constant.PermissionFullAccess,
+		constant.PermissionObjectAll,
+		constant.PermissionObjectDelete,
+	})
+}
+
+// canUpdate allows the owner or an OBJECT_UPDATE permission holder.
+func (uc *objectUsecase) canUpdate(ctx context.Context, object *model.Object) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	userID := getUserIDFromCtx(ctx)
+	// guest uploads are shared by every guest, guests must not change them
+	if userID != constant.GuestID && object.UploadedBy == userID {
+		return nil
+	}
+
+	return hasAccess(ctx, uc.authClient, []string{
+		constant.PermissionFullAccess,
+		constant.PermissionObjectAll,
+		constant.PermissionObjectUpdate,
+	})
+}
@@ -32,6 +32,14 @@ func (uc *objectUsecase) InjectObjectWhitelistTypeRepo(repo model.ObjectWhitelis
 	return nil
 }
 
+func (uc *objectUsecase) InjectObjectGrantRepo(repo model.ObjectGrantRepository) error {
+	if repo == nil {
+		return errors.New("invalid object grant repository")
+	}
+	uc.objectGrantRepo = repo
+	return nil
+}
+
 func (uc *objectUsecase) InjectAuthClient(client authPB.AuthServiceClient) error {
 	if client == nil {
 		return errors.New("invalid auth client")
@@ -47,3 +55,43 @@ func (uc *objectUsecase) InjectJetstreamClient(client nats.JetStreamContext) err
 	uc.jsClient = client
 	return nil
 }
+
+func (uc *objectUsecase) InjectPendingDeletionRepo(repo model.PendingDeletionRepository) error {
+	if repo == nil {
+		return errors.New("invalid pending deletion repository")
+	}
+	uc.pendingDeletionRepo = repo
+	return nil
+}
+
+func (uc *objectUsecase) InjectAuditLogUsecase(auditLogUC model.AuditLogUsecase) error {
+	if auditLogUC == nil {
+		return errors.New("invalid audit log usecase")
+	}
+	uc.auditLogUC = auditLogUC
+	return nil
+}
+
+func (uc *objectUsecase) InjectShareLinkRepo(repo model.ShareLinkRepository) error {
+	if repo == nil {
+		return errors.New("invalid share link repository")
+	}
+	uc.shareLinkRepo = repo
+	return nil
+}
+
+func (uc *objectUsecase) InjectObjectReferenceRepo(repo model.ObjectReferenceRepository) error {
+	if repo == nil {
+		return errors.New("invalid object reference repository")
+	}
+	uc.objectReferenceRepo = repo
+	return nil
+}
+
+func (uc *objectUsecase) InjectStorageUsageUsecase(storageUsageUC model.StorageUsageUsecase) error {
+	if storageUsageUC == nil {
+		return errors.New("invalid storage usage usecase")
+	}
+	uc.storageUsageUC = storageUsageUC
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -12,6 +14,7 @@ import (
 	"github.com/krobus00/storage-service/internal/model"
 	"github.com/krobus00/storage-service/internal/model/mock"
 	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/nats-io/nats.go"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -37,6 +40,9 @@ func Test_objectUsecase_Upload(t *testing.T) {
 		res *model.ObjectWhitelistType
 		err error
 	}
+	type mockCheckQuota struct {
+		err error
+	}
 	type mockCreate struct {
 		res *model.Object
 		err error
@@ -47,6 +53,7 @@ func Test_objectUsecase_Upload(t *testing.T) {
 		mockHasAccess          *mockHasAccess
 		mockFindObjectType     *mockFindObjectType
 		mockFindByTypeIDAndExt *mockFindByTypeIDAndExt
+		mockCheckQuota         *mockCheckQuota
 		mockCreate             *mockCreate
 		want                   *model.Object
 		wantErr                bool
@@ -81,6 +88,9 @@ func Test_objectUsecase_Upload(t *testing.T) {
 				},
 				err: nil,
 			},
+			mockCheckQuota: &mockCheckQuota{
+				err: nil,
+			},
 			mockCreate: &mockCreate{
 				res: &model.Object{
 					ID:         objectID,
@@ -122,6 +132,32 @@ func Test_objectUsecase_Upload(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error invalid metadata",
+			args: args{
+				userID: userID,
+				payload: &model.ObjectPayload{
+					Object: &model.Object{
+						Type:     "image",
+						FileName: "test",
+						IsPublic: false,
+						Metadata: model.ObjectMetadata{"": "empty key"},
+					},
+				},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+				err:       nil,
+			},
+			mockFindObjectType: &mockFindObjectType{
+				res: &model.ObjectType{
+					ID:   typeID,
+					Name: "image",
+				},
+				err: nil,
+			},
+			wantErr: true,
+		},
 		{
 			name: "error object type not found",
 			args: args{
@@ -224,6 +260,67 @@ func Test_objectUsecase_Upload(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error quota exceeded",
+			args: args{
+				userID: userID,
+				payload: &model.ObjectPayload{
+					Object: &model.Object{
+						Type:     "image",
+						FileName: "test",
+						IsPublic: false,
+					},
+				},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+				err:       nil,
+			},
+			mockFindObjectType: &mockFindObjectType{
+				res: &model.ObjectType{
+					ID:   typeID,
+					Name: "image",
+				},
+				err: nil,
+			},
+			mockFindByTypeIDAndExt: &mockFindByTypeIDAndExt{
+				res: &model.ObjectWhitelistType{
+					TypeID:    typeID,
+					Extension: ".png",
+				},
+				err: nil,
+			},
+			mockCheckQuota: &mockCheckQuota{
+				err: model.ErrQuotaExceeded,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error checksum mismatch",
+			args: args{
+				userID: userID,
+				payload: &model.ObjectPayload{
+					Object: &model.Object{
+						Type:     "image",
+						FileName: "test",
+						IsPublic: false,
+					},
+					ChecksumSHA256: strings.Repeat("0", 64),
+				},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+				err:       nil,
+			},
+			mockFindObjectType: &mockFindObjectType{
+				res: &model.ObjectType{
+					ID:   typeID,
+					Name: "image",
+				},
+				err: nil,
+			},
+			wantErr: true,
+		},
 		{
 			name: "error create object",
 			args: args{
@@ -254,6 +351,9 @@ func Test_objectUsecase_Upload(t *testing.T) {
 				},
 				err: nil,
 			},
+			mockCheckQuota: &mockCheckQuota{
+				err: nil,
+			},
 			mockCreate: &mockCreate{
 				res: nil,
 				err: errors.New("db error"),
@@ -273,31 +373,43 @@ func Test_objectUsecase_Upload(t *testing.T) {
 			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
 			objectWhitelistTypeRepo := mock.NewMockObjectWhitelistTypeRepository(ctrl)
 			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			storageUsageUC := mock.NewMockStorageUsageUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionUpload, gomock.Any(), gomock.Any()).
+				Times(1)
 
 			if tt.mockHasAccess != nil {
 				authClientMock.EXPECT().
-					HasAccess(ctx, gomock.Any()).
+					HasAccess(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(tt.mockHasAccess.hasAccess, tt.mockHasAccess.err)
 			}
 
 			if tt.mockFindObjectType != nil {
 				objectTypeRepo.EXPECT().
-					FindByName(ctx, tt.args.payload.Object.Type).
+					FindByName(gomock.Any(), tt.args.payload.Object.Type).
 					Times(1).
 					Return(tt.mockFindObjectType.res, tt.mockFindObjectType.err)
 			}
 
 			if tt.mockFindByTypeIDAndExt != nil {
 				objectWhitelistTypeRepo.EXPECT().
-					FindByTypeIDAndExt(ctx, tt.mockFindObjectType.res.ID, gomock.Any()).
+					FindByTypeIDAndExt(gomock.Any(), tt.mockFindObjectType.res.ID, gomock.Any()).
 					Times(1).
 					Return(tt.mockFindByTypeIDAndExt.res, tt.mockFindByTypeIDAndExt.err)
 			}
 
+			if tt.mockCheckQuota != nil {
+				storageUsageUC.EXPECT().
+					CheckQuota(gomock.Any(), tt.args.userID, gomock.Any()).
+					Times(1).
+					Return(tt.mockCheckQuota.err)
+			}
+
 			if tt.mockCreate != nil {
 				objectRepo.EXPECT().
-					Create(ctx, gomock.Any()).
+					Create(gomock.Any(), gomock.Any()).
 					Times(1).
 					DoAndReturn(func(ctx context.Context, data *model.ObjectPayload) error {
 						data.Object = tt.mockCreate.res
@@ -314,6 +426,10 @@ func Test_objectUsecase_Upload(t *testing.T) {
 			utils.ContinueOrFatal(err)
 			err = uc.InjectAuthClient(authClientMock)
 			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectStorageUsageUsecase(storageUsageUC)
+			utils.ContinueOrFatal(err)
 
 			got, err := uc.Upload(ctx, tt.args.payload)
 			if (err != nil) != tt.wantErr {
@@ -345,6 +461,10 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 		res *model.ObjectType
 		err error
 	}
+	type mockFindGrants struct {
+		res []*model.ObjectGrant
+		err error
+	}
 	type mockGeneratePresignedURL struct {
 		res *model.GetPresignedURLResponse
 		err error
@@ -358,6 +478,7 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 		args                     args
 		mockHasAccess            *mockHasAccess
 		mockFindObjectByID       *mockFindObjectByID
+		mockFindGrants           *mockFindGrants
 		mockFindObjectType       *mockFindObjectType
 		mockGeneratePresignedURL *mockGeneratePresignedURL
 		want                     *model.GetPresignedURLResponse
@@ -456,6 +577,81 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success get other user private object by grant",
+			args: args{
+				userID: userID,
+				payload: &model.GetPresignedURLPayload{
+					ObjectID: objectID,
+				},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{
+					ID:         objectID,
+					UploadedBy: "other-user",
+					Type:       typeID,
+					IsPublic:   false,
+				},
+				err: nil,
+			},
+			mockFindGrants: &mockFindGrants{
+				res: []*model.ObjectGrant{
+					{
+						ObjectID:    objectID,
+						GranteeType: model.GranteeTypeUser,
+						GranteeID:   userID,
+						Permission:  model.GrantPermissionRead,
+					},
+				},
+			},
+			mockFindObjectType: &mockFindObjectType{
+				res: &model.ObjectType{
+					ID:   typeID,
+					Name: "image",
+				},
+			},
+			mockGeneratePresignedURL: &mockGeneratePresignedURL{
+				res: &model.GetPresignedURLResponse{
+					ID:         objectID,
+					Filename:   "test.png",
+					Type:       "image",
+					URL:        "https://s3.bucket/test.jpg",
+					IsPublic:   false,
+					UploadedBy: "other-user",
+				},
+			},
+			want: &model.GetPresignedURLResponse{
+				ID:         objectID,
+				Filename:   "test.png",
+				Type:       "image",
+				URL:        "https://s3.bucket/test.jpg",
+				IsPublic:   false,
+				UploadedBy: "other-user",
+			},
+			wantErr: false,
+		},
+		{
+			name: "error find object grants",
+			args: args{
+				userID: userID,
+				payload: &model.GetPresignedURLPayload{
+					ObjectID: objectID,
+				},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{
+					ID:         objectID,
+					UploadedBy: "other-user",
+					Type:       typeID,
+					IsPublic:   false,
+				},
+				err: nil,
+			},
+			mockFindGrants: &mockFindGrants{
+				err: errors.New("db error"),
+			},
+			wantErr: true,
+		},
 		{
 			name: "success get other user public object",
 			args: args{
@@ -649,19 +845,33 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 
 			objectRepo := mock.NewMockObjectRepository(ctrl)
 			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			objectGrantRepo := mock.NewMockObjectGrantRepository(ctrl)
 			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionPresign, gomock.Any(), gomock.Any()).
+				Times(1)
 
 			if tt.mockFindObjectByID != nil {
 				objectRepo.EXPECT().
-					FindByID(ctx, tt.args.payload.ObjectID).
+					FindByID(gomock.Any(), tt.args.payload.ObjectID).
 					Times(1).
 					Return(tt.mockFindObjectByID.res, tt.mockFindObjectByID.err)
 
 				object := tt.mockFindObjectByID.res
-				if object != nil {
-					if tt.mockHasAccess != nil && !object.IsPublic && object.UploadedBy != tt.args.userID {
+				if object != nil && !object.IsPublic && object.UploadedBy != tt.args.userID {
+					grants := &mockFindGrants{}
+					if tt.mockFindGrants != nil {
+						grants = tt.mockFindGrants
+					}
+					objectGrantRepo.EXPECT().
+						FindByObjectID(gomock.Any(), object.ID).
+						Times(1).
+						Return(grants.res, grants.err)
+
+					if tt.mockHasAccess != nil {
 						authClientMock.EXPECT().
-							HasAccess(ctx, gomock.Any()).
+							HasAccess(gomock.Any(), gomock.Any()).
 							Times(1).
 							Return(tt.mockHasAccess.hasAccess, tt.mockHasAccess.err)
 					}
@@ -670,14 +880,14 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 
 			if tt.mockFindObjectType != nil {
 				objectTypeRepo.EXPECT().
-					FindByID(ctx, tt.mockFindObjectByID.res.TypeID).
+					FindByID(gomock.Any(), tt.mockFindObjectByID.res.TypeID).
 					Times(1).
 					Return(tt.mockFindObjectType.res, tt.mockFindObjectType.err)
 			}
 
 			if tt.mockGeneratePresignedURL != nil {
 				objectRepo.EXPECT().
-					GeneratePresignedURL(ctx, tt.mockFindObjectByID.res).
+					GeneratePresignedURL(gomock.Any(), tt.mockFindObjectByID.res, tt.args.payload.MinValidity).
 					Times(1).
 					Return(tt.mockGeneratePresignedURL.res, tt.mockGeneratePresignedURL.err)
 			}
@@ -687,8 +897,12 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 			utils.ContinueOrFatal(err)
 			err = uc.InjectObjectTypeRepo(objectTypeRepo)
 			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectGrantRepo(objectGrantRepo)
+			utils.ContinueOrFatal(err)
 			err = uc.InjectAuthClient(authClientMock)
 			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
 
 			got, err := uc.GeneratePresignedURL(ctx, tt.args.payload)
 			if (err != nil) != tt.wantErr {
@@ -701,3 +915,658 @@ func Test_objectUsecase_GeneratePresignedURL(t *testing.T) {
 		})
 	}
 }
+
+// fakeJetStream records published subjects, the other JetStreamContext methods are not used.
+type fakeJetStream struct {
+	nats.JetStreamContext
+	mu       sync.Mutex
+	subjects []string
+}
+
+func (f *fakeJetStream) PublishMsg(msg *nats.Msg, _ ...nats.PubOpt) (*nats.PubAck, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subjects = append(f.subjects, msg.Subject)
+	return &nats.PubAck{}, nil
+}
+
+func Test_objectUsecase_DeleteObject(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	type mockHasAccess struct {
+		err       error
+		hasAccess *wrapperspb.BoolValue
+	}
+	type mockFindObjectByID struct {
+		res *model.Object
+		err error
+	}
+	type mockFindGrants struct {
+		res []*model.ObjectGrant
+		err error
+	}
+	type args struct {
+		userID string
+		id     string
+	}
+	tests := []struct {
+		name               string
+		args               args
+		mockFindObjectByID *mockFindObjectByID
+		mockFindGrants     *mockFindGrants
+		mockHasAccess      *mockHasAccess
+		mockDeleteByID     error
+		wantDelete         bool
+		wantErr            error
+	}{
+		{
+			name: "success delete own object",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: userID, TypeID: typeID},
+			},
+			wantDelete: true,
+			wantErr:    nil,
+		},
+		{
+			name: "success delete other user object by grant",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: "other-user", TypeID: typeID},
+			},
+			mockFindGrants: &mockFindGrants{
+				res: []*model.ObjectGrant{
+					{
+						ObjectID:    objectID,
+						GranteeType: model.GranteeTypeUser,
+						GranteeID:   userID,
+						Permission:  model.GrantPermissionDelete,
+					},
+				},
+			},
+			wantDelete: true,
+			wantErr:    nil,
+		},
+		{
+			name: "success delete other user object by permission",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: "other-user", TypeID: typeID},
+			},
+			mockFindGrants: &mockFindGrants{},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+			},
+			wantDelete: true,
+			wantErr:    nil,
+		},
+		{
+			name: "error unauthorized delete",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: "other-user", TypeID: typeID},
+			},
+			mockFindGrants: &mockFindGrants{
+				res: []*model.ObjectGrant{
+					{
+						ObjectID:    objectID,
+						GranteeType: model.GranteeTypeUser,
+						GranteeID:   userID,
+						Permission:  model.GrantPermissionRead,
+					},
+				},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(false),
+			},
+			wantErr: model.ErrUnauthorizeAccess,
+		},
+		{
+			name: "error guest delete guest object",
+			args: args{
+				userID: constant.GuestID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: constant.GuestID, TypeID: typeID},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(false),
+			},
+			wantErr: model.ErrUnauthorizeAccess,
+		},
+		{
+			name: "error object not found",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{},
+			wantErr:            model.ErrObjectNotFound,
+		},
+		{
+			name: "error delete object",
+			args: args{
+				userID: userID,
+				id:     objectID,
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: userID, TypeID: typeID},
+			},
+			mockDeleteByID: errors.New("db error"),
+			wantDelete:     true,
+			wantErr:        errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.args.userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			objectGrantRepo := mock.NewMockObjectGrantRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionDelete, gomock.Any(), gomock.Any()).
+				Times(1)
+			jsClient := new(fakeJetStream)
+
+			objectRepo.EXPECT().
+				FindByID(gomock.Any(), tt.args.id).
+				Times(1).
+				Return(tt.mockFindObjectByID.res, tt.mockFindObjectByID.err)
+
+			if tt.mockFindGrants != nil {
+				objectGrantRepo.EXPECT().
+					FindByObjectID(gomock.Any(), tt.args.id).
+					Times(1).
+					Return(tt.mockFindGrants.res, tt.mockFindGrants.err)
+			}
+
+			if tt.mockHasAccess != nil {
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess.hasAccess, tt.mockHasAccess.err)
+			}
+
+			if tt.wantDelete {
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "image"}, nil)
+				objectRepo.EXPECT().
+					DeleteByID(gomock.Any(), tt.args.id).
+					Times(1).
+					Return(tt.mockDeleteByID)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectTypeRepo(objectTypeRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectGrantRepo(objectGrantRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectJetstreamClient(jsClient)
+			utils.ContinueOrFatal(err)
+
+			err = uc.DeleteObject(ctx, tt.args.id)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.DeleteObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && len(jsClient.subjects) != len(model.ObjectDeleteStreamSubjects) {
+				t.Errorf("objectUsecase.DeleteObject() published %v, want %v", jsClient.subjects, model.ObjectDeleteStreamSubjects)
+			}
+		})
+	}
+}
+
+func Test_objectUsecase_PurgeObject(t *testing.T) {
+	var (
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+	)
+	tests := []struct {
+		name              string
+		object            *model.Object
+		mockDeleteByID    error
+		mockDeleteContent error
+		wantDeferred      bool
+		wantErr           error
+	}{
+		{
+			name:    "success",
+			object:  &model.Object{ID: objectID, TypeID: typeID, Bucket: "bucket", Key: "key"},
+			wantErr: nil,
+		},
+		{
+			name:              "success content delete deferred",
+			object:            &model.Object{ID: objectID, TypeID: typeID, Bucket: "bucket", Key: "key"},
+			mockDeleteContent: errors.New("s3 error"),
+			wantDeferred:      true,
+			wantErr:           nil,
+		},
+		{
+			name:    "error object not found",
+			object:  nil,
+			wantErr: model.ErrObjectNotFound,
+		},
+		{
+			name:           "error delete object",
+			object:         &model.Object{ID: objectID, TypeID: typeID, Bucket: "bucket", Key: "key"},
+			mockDeleteByID: errors.New("db error"),
+			wantErr:        errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, constant.SystemID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			pendingDeletionRepo := mock.NewMockPendingDeletionRepository(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), model.AuditActionDelete, objectID, tt.wantErr).
+				Times(1)
+			jsClient := new(fakeJetStream)
+
+			objectRepo.EXPECT().
+				FindByID(gomock.Any(), objectID).
+				Times(1).
+				Return(tt.object, nil)
+
+			if tt.object != nil {
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "image"}, nil)
+				objectRepo.EXPECT().
+					DeleteByID(gomock.Any(), objectID).
+					Times(1).
+					Return(tt.mockDeleteByID)
+			}
+			if tt.object != nil && tt.mockDeleteByID == nil {
+				objectRepo.EXPECT().
+					DeleteContent(gomock.Any(), tt.object).
+					Times(1).
+					Return(tt.mockDeleteContent)
+			}
+			if tt.wantDeferred {
+				pendingDeletionRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, deletion *model.PendingDeletion) error {
+						if deletion.Bucket != tt.object.Bucket || deletion.Key != tt.object.Key {
+							t.Errorf("pending deletion = %s/%s, want %s/%s", deletion.Bucket, deletion.Key, tt.object.Bucket, tt.object.Key)
+						}
+						return nil
+					})
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectTypeRepo(objectTypeRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectPendingDeletionRepo(pendingDeletionRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectJetstreamClient(jsClient)
+			utils.ContinueOrFatal(err)
+
+			_, err = uc.PurgeObject(ctx, objectID)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.PurgeObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && len(jsClient.subjects) != len(model.ObjectDeleteStreamSubjects) {
+				t.Errorf("objectUsecase.PurgeObject() published %v, want %v", jsClient.subjects, model.ObjectDeleteStreamSubjects)
+			}
+		})
+	}
+}
+
+func Test_objectUsecase_UpdateObject(t *testing.T) {
+	var (
+		userID   = utils.GenerateUUID()
+		objectID = utils.GenerateUUID()
+		typeID   = utils.GenerateUUID()
+		isPublic = true
+		fileName = "report.v2.pdf"
+	)
+	type mockHasAccess struct {
+		err       error
+		hasAccess *wrapperspb.BoolValue
+	}
+	type mockFindObjectByID struct {
+		res *model.Object
+		err error
+	}
+	type args struct {
+		userID  string
+		payload *model.UpdateObjectPayload
+	}
+	tests := []struct {
+		name               string
+		args               args
+		mockFindObjectByID *mockFindObjectByID
+		mockHasAccess      *mockHasAccess
+		mockUpdate         error
+		wantUpdate         bool
+		wantAction         string
+		want               *model.Object
+		wantErr            error
+	}{
+		{
+			name: "success change own object visibility",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1, IsPublic: &isPublic},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, FileName: "report.pdf", UploadedBy: userID, TypeID: typeID, Version: 1},
+			},
+			wantUpdate: true,
+			wantAction: model.AuditActionVisibilityChange,
+			want: &model.Object{
+				ID:         objectID,
+				FileName:   "report.pdf",
+				UploadedBy: userID,
+				IsPublic:   true,
+				TypeID:     typeID,
+				Type:       "document",
+				Version:    2,
+			},
+		},
+		{
+			name: "success rename other user object by permission",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 3, FileName: &fileName},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, FileName: "report.pdf", UploadedBy: "other-user", TypeID: typeID, Version: 3},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(true),
+			},
+			wantUpdate: true,
+			wantAction: model.AuditActionUpdate,
+			want: &model.Object{
+				ID:               objectID,
+				FileName:         "report.v2.pdf",
+				OriginalFileName: "report.v2.pdf",
+				UploadedBy:       "other-user",
+				TypeID:           typeID,
+				Type:             "document",
+				Version:          4,
+			},
+		},
+		{
+			name: "error invalid payload",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1},
+			},
+			wantAction: model.AuditActionUpdate,
+			wantErr:    model.ErrInvalidObjectUpdate,
+		},
+		{
+			name: "error object not found",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1, IsPublic: &isPublic},
+			},
+			mockFindObjectByID: &mockFindObjectByID{},
+			wantAction:         model.AuditActionVisibilityChange,
+			wantErr:            model.ErrObjectNotFound,
+		},
+		{
+			name: "error unauthorized update",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1, IsPublic: &isPublic},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: "other-user", TypeID: typeID, Version: 1},
+			},
+			mockHasAccess: &mockHasAccess{
+				hasAccess: wrapperspb.Bool(false),
+			},
+			wantAction: model.AuditActionVisibilityChange,
+			wantErr:    model.ErrUnauthorizeAccess,
+		},
+		{
+			name: "error stale version",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1, IsPublic: &isPublic},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: userID, TypeID: typeID, Version: 2},
+			},
+			wantAction: model.AuditActionVisibilityChange,
+			wantErr:    model.ErrObjectVersionConflict,
+		},
+		{
+			name: "error concurrent update",
+			args: args{
+				userID:  userID,
+				payload: &model.UpdateObjectPayload{ObjectID: objectID, Version: 1, IsPublic: &isPublic},
+			},
+			mockFindObjectByID: &mockFindObjectByID{
+				res: &model.Object{ID: objectID, UploadedBy: userID, TypeID: typeID, Version: 1},
+			},
+			mockUpdate: model.ErrObjectVersionConflict,
+			wantUpdate: true,
+			wantAction: model.AuditActionVisibilityChange,
+			wantErr:    model.ErrObjectVersionConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			ctx = context.WithValue(ctx, constant.KeyUserIDCtx, tt.args.userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.EXPECT().
+				Record(gomock.Any(), tt.wantAction, objectID, gomock.Any()).
+				Times(1)
+			jsClient := new(fakeJetStream)
+
+			if tt.mockFindObjectByID != nil {
+				objectRepo.EXPECT().
+					FindByID(gomock.Any(), objectID).
+					Times(1).
+					Return(tt.mockFindObjectByID.res, tt.mockFindObjectByID.err)
+			}
+
+			if tt.mockHasAccess != nil {
+				authClientMock.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess.hasAccess, tt.mockHasAccess.err)
+			}
+
+			if tt.wantUpdate {
+				objectRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, object *model.Object, _ map[string]any) error {
+						if tt.mockUpdate == nil {
+							object.Version++
+						}
+						return tt.mockUpdate
+					})
+			}
+
+			if tt.wantUpdate && tt.mockUpdate == nil {
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "document"}, nil)
+			}
+
+			uc := NewObjectUsecase()
+			err := uc.InjectObjectRepo(objectRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectObjectTypeRepo(objectTypeRepo)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuthClient(authClientMock)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectAuditLogUsecase(auditLogUC)
+			utils.ContinueOrFatal(err)
+			err = uc.InjectJetstreamClient(jsClient)
+			utils.ContinueOrFatal(err)
+
+			got, err := uc.UpdateObject(ctx, tt.args.payload)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("objectUsecase.UpdateObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectUsecase.UpdateObject() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(jsClient.subjects, []string{model.ObjectUpdatedSubject}) {
+				t.Errorf("objectUsecase.UpdateObject() published %v, want %v", jsClient.subjects, []string{model.ObjectUpdatedSubject})
+			}
+		})
+	}
+}
+
+func Test_objectUsecase_Upload_idempotency(t *testing.T) {
+	var (
+		userID     = utils.GenerateUUID()
+		typeID     = utils.GenerateUUID()
+		objectType = &model.ObjectType{ID: typeID, Name: "image"}
+	)
+	stored := func(size int64) *model.Object {
+		return &model.Object{ID: "stored", TypeID: typeID, UploadedBy: userID, Size: size, IdempotencyKey: "retry-1"}
+	}
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		mockFind       []*model.Object
+		mockCreateErr  error
+		wantCreate     bool
+		want           *model.Object
+		wantErr        error
+	}{
+		{
+			name:           "success replay returns the first object",
+			idempotencyKey: "retry-1",
+			mockFind:       []*model.Object{stored(0)},
+			want:           stored(0).SetType("image"),
+		},
+		{
+			name:           "success concurrent retry stored first",
+			idempotencyKey: "retry-1",
+			mockFind:       []*model.Object{nil, stored(0)},
+			mockCreateErr:  model.ErrIdempotencyKeyTaken,
+			wantCreate:     true,
+			want:           stored(0).SetType("image"),
+		},
+		{
+			name:           "error key reused for another upload",
+			idempotencyKey: "retry-1",
+			mockFind:       []*model.Object{stored(10)},
+			wantErr:        model.ErrIdempotencyKeyReused,
+		},
+		{
+			name:           "error invalid key",
+			idempotencyKey: "retry 1",
+			wantErr:        model.ErrInvalidIdempotencyKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, userID)
+
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			objectWhitelistTypeRepo := mock.NewMockObjectWhitelistTypeRepository(ctrl)
+			authClientMock := authMock.NewMockAuthServiceClient(ctrl)
+			auditLogUC := mock.NewMockAuditLogUsecase(ctrl)
+			storageUsageUC := mock.NewMockStorageUsageUsecase(ctrl)
+
+			auditLogUC.EXPECT().Record(gomock.Any(), model.AuditActionUpload, gomock.Any(), gomock.Any()).Times(1)
+			authClientMock.EXPECT().HasAccess(gomock.Any(), gomock.Any()).Times(1).Return(wrapperspb.Bool(true), nil)
+			objectTypeRepo.EXPECT().FindByName(gomock.Any(), "image").Times(1).Return(objectType, nil)
+			for _, object := range tt.mockFind {
+				objectRepo.EXPECT().
+					FindByIdempotencyKey(gomock.Any(), userID, tt.idempotencyKey).
+					Times(1).
+					Return(object, nil)
+			}
+			if tt.wantCreate {
+				objectWhitelistTypeRepo.EXPECT().
+					FindByTypeIDAndExt(gomock.Any(), typeID, gomock.Any()).
+					Times(1).
+					Return(&model.ObjectWhitelistType{TypeID: typeID}, nil)
+				storageUsageUC.EXPECT().CheckQuota(gomock.Any(), userID, gomock.Any()).Times(1).Return(nil)
+				objectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(tt.mockCreateErr)
+			}
+
+			uc := NewObjectUsecase()
+			utils.ContinueOrFatal(uc.InjectObjectRepo(objectRepo))
+			utils.ContinueOrFatal(uc.InjectObjectTypeRepo(objectTypeRepo))
+			utils.ContinueOrFatal(uc.InjectObjectWhitelistTypeRepo(objectWhitelistTypeRepo))
+			utils.ContinueOrFatal(uc.InjectAuthClient(authClientMock))
+			utils.ContinueOrFatal(uc.InjectAuditLogUsecase(auditLogUC))
+			utils.ContinueOrFatal(uc.InjectStorageUsageUsecase(storageUsageUC))
+
+			got, err := uc.Upload(ctx, &model.ObjectPayload{
+				Object:         &model.Object{Type: "image", FileName: "test"},
+				IdempotencyKey: tt.idempotencyKey,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("objectUsecase.Upload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectUsecase.Upload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/metrics"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type objectVerifyUsecase struct {
+	objectRepo model.ObjectRepository
+}
+
+func NewObjectVerifyUsecase() model.ObjectVerifyUsecase {
+	return new(objectVerifyUsecase)
+}
+
+func (uc *objectVerifyUsecase) Verify(ctx context.Context) (*model.ObjectVerifyReport, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	batchSize := config.VerifyBatchSize()
+	logger := logrus.WithFields(logrus.Fields{
+		"batchSize": batchSize,
+	})
+
+	report := model.NewObjectVerifyReport()
+	afterID := ""
+	for {
+		objects, err := uc.objectRepo.FindAllAfterID(ctx, afterID, batchSize)
+		if err != nil {
+			logger.Error(err.Error())
+			return report, err
+		}
+
+		for _, object := range objects {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			afterID = object.ID
+			report.Checked++
+
+			failure := uc.verify(ctx, object)
+			if failure != nil {
+				metrics.ObserveVerifyFailure(failure.Status)
+				logger.WithFields(logrus.Fields{
+					"objectID": object.ID,
+					"status":   failure.Status,
+				}).Warn(failure.Detail)
+				report.Failures = append(report.Failures, failure)
+				continue
+			}
+			report.Passed++
+			if object.ChecksumSHA256 == "" {
+				report.SizeOnly++
+			}
+		}
+
+		if len(objects) < batchSize {
+			break
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"checked":  report.Checked,
+		"passed":   report.Passed,
+		"failures": len(report.Failures),
+	}).Info("objects verified")
+	return report, nil
+}
+
+// verify streams the content through sha256, nil means the content matches the row.
+func (uc *objectVerifyUsecase) verify(ctx context.Context, object *model.Object) *model.ObjectVerifyFailure {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	content, err := uc.objectRepo.GetContent(ctx, object, &model.GetObjectContentPayload{ObjectID: object.ID})
+	switch {
+	case errors.Is(err, model.ErrContentNotFound):
+		return &model.ObjectVerifyFailure{Object: object, Status: model.VerifyStatusMissing, Detail: err.Error()}
+	case errors.Is(err, model.ErrContentTampered):
+		return &model.ObjectVerifyFailure{Object: object, Status: model.VerifyStatusCorrupted, Detail: err.Error()}
+	case err != nil:
+		return &model.ObjectVerifyFailure{Object: object, Status: model.VerifyStatusError, Detail: err.Error()}
+	}
+	defer content.Body.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, content.Body)
+	if err != nil {
+		return &model.ObjectVerifyFailure{Object: object, Status: model.VerifyStatusError, Detail: err.Error()}
+	}
+
+	if size != object.Size {
+		return &model.ObjectVerifyFailure{
+			Object: object,
+			Status: model.VerifyStatusCorrupted,
+			Detail: fmt.Sprintf("size %d, want %d", size, object.Size),
+		}
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); object.ChecksumSHA256 != "" && checksum != object.ChecksumSHA256 {
+		return &model.ObjectVerifyFailure{
+			Object: object,
+			Status: model.VerifyStatusCorrupted,
+			Detail: fmt.Sprintf("sha256 %s, want %s", checksum, object.ChecksumSHA256),
+		}
+	}
+	return nil
+}
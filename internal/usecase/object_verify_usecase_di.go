@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (uc *objectVerifyUsecase) InjectObjectRepo(repo model.ObjectRepository) error {
+	if repo == nil {
+		return errors.New("invalid object repository")
+	}
+	uc.objectRepo = repo
+	return nil
+}
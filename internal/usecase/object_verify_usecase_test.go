@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+)
+
+func Test_objectVerifyUsecase_Verify(t *testing.T) {
+	const content = "hello"
+	var (
+		intact    = &model.Object{ID: "1", Size: 5, ChecksumSHA256: model.SHA256Hex([]byte(content))}
+		legacy    = &model.Object{ID: "2", Size: 5}
+		corrupted = &model.Object{ID: "3", Size: 5, ChecksumSHA256: model.SHA256Hex([]byte("world"))}
+		truncated = &model.Object{ID: "4", Size: 10}
+		missing   = &model.Object{ID: "5", Size: 5}
+		tampered  = &model.Object{ID: "6", Size: 5, EncryptionKeyID: "k1"}
+	)
+	tests := []struct {
+		name         string
+		mockFind     []*model.Object
+		mockFindErr  error
+		mockContent  map[*model.Object]error
+		wantPassed   int
+		wantSizeOnly int
+		wantFailures map[string]string
+		wantErr      bool
+	}{
+		{
+			name:     "success",
+			mockFind: []*model.Object{intact, legacy, corrupted, truncated, missing, tampered},
+			mockContent: map[*model.Object]error{
+				intact:    nil,
+				legacy:    nil,
+				corrupted: nil,
+				truncated: nil,
+				missing:   model.ErrContentNotFound,
+				tampered:  model.ErrContentTampered,
+			},
+			wantPassed:   2,
+			wantSizeOnly: 1,
+			wantFailures: map[string]string{
+				corrupted.ID: model.VerifyStatusCorrupted,
+				truncated.ID: model.VerifyStatusCorrupted,
+				missing.ID:   model.VerifyStatusMissing,
+				tampered.ID:  model.VerifyStatusCorrupted,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "error find objects",
+			mockFindErr: errors.New("db error"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.TODO()
+			objectRepo := mock.NewMockObjectRepository(ctrl)
+
+			objectRepo.EXPECT().
+				FindAllAfterID(gomock.Any(), "", gomock.Any()).
+				Times(1).
+				Return(tt.mockFind, tt.mockFindErr)
+			for object, err := range tt.mockContent {
+				var res *model.ObjectContent
+				if err == nil {
+					res = &model.ObjectContent{Object: object, Body: io.NopCloser(strings.NewReader(content))}
+				}
+				objectRepo.EXPECT().
+					GetContent(gomock.Any(), object, gomock.Any()).
+					Times(1).
+					Return(res, err)
+			}
+
+			uc := NewObjectVerifyUsecase()
+			utils.ContinueOrFatal(uc.InjectObjectRepo(objectRepo))
+
+			got, err := uc.Verify(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("objectVerifyUsecase.Verify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Checked != len(tt.mockFind) || got.Passed != tt.wantPassed || got.SizeOnly != tt.wantSizeOnly {
+				t.Errorf("objectVerifyUsecase.Verify() = %+v", got)
+			}
+			failures := make(map[string]string, len(got.Failures))
+			for _, failure := range got.Failures {
+				failures[failure.Object.ID] = failure.Status
+			}
+			if len(failures) != len(tt.wantFailures) {
+				t.Errorf("objectVerifyUsecase.Verify() failures = %v, want %v", failures, tt.wantFailures)
+			}
+			for id, status := range tt.wantFailures {
+				if failures[id] != status {
+					t.Errorf("objectVerifyUsecase.Verify() failure %s = %v, want %v", id, failures[id], status)
+				}
+			}
+		})
+	}
+}
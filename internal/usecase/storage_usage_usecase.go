@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/storage-service/internal/config"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type storageUsageUsecase struct {
+	storageUsageRepo model.StorageUsageRepository
+	objectTypeRepo   model.ObjectTypeRepository
+	authClient       authPB.AuthServiceClient
+}
+
+func NewStorageUsageUsecase() model.StorageUsageUsecase {
+	return new(storageUsageUsecase)
+}
+
+// GetUsage returns the usage of the user, the caller when userID is empty,
+// reading another user needs USAGE_READ.
+func (uc *storageUsageUsecase) GetUsage(ctx context.Context, userID string) (*model.UserStorageUsage, error) {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	sessionUserID := getUserIDFromCtx(ctx)
+	if userID == "" {
+		userID = sessionUserID
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID":        userID,
+		"sessionUserID": sessionUserID,
+	})
+
+	if userID == constant.GuestID {
+		return nil, model.ErrUnauthorizeAccess
+	}
+	if userID != sessionUserID {
+		err := hasAccess(ctx, uc.authClient, []string{
+			constant.PermissionFullAccess,
+			constant.PermissionUsageRead,
+		})
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	usage, err := uc.findUsage(ctx, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	for _, typeUsage := range usage.Types {
+		objectType, err := uc.objectTypeRepo.FindByID(ctx, typeUsage.TypeID)
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		if objectType != nil {
+			typeUsage.Type = objectType.Name
+		}
+	}
+
+	return usage, nil
+}
+
+func (uc *storageUsageUsecase) CheckQuota(ctx context.Context, userID string, size int64) error {
+	_, _, fn := utils.Trace()
+	ctx, span := utils.NewSpan(ctx, fn)
+	defer span.End()
+
+	logger := logrus.WithFields(logrus.Fields{
+		"userID": userID,
+		"size":   size,
+	})
+
+	quota := quotaOf(userID)
+	if quota.IsUnlimited() {
+		return nil
+	}
+
+	usage, err := uc.findUsage(ctx, userID)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+	if !quota.Allows(usage, size) {
+		return model.ErrQuotaExceeded
+	}
+
+	return nil
+}
+
+func (uc *storageUsageUsecase) findUsage(ctx context.Context, userID string) (*model.UserStorageUsage, error) {
+	usages, err := uc.storageUsageRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewUserStorageUsage(userID, quotaOf(userID), usages), nil
+}
+
+// uploadQuotaOf are the limits the repository enforces when storing an upload of the user.
+func uploadQuotaOf(tenantID string, userID string) model.UploadQuota {
+	return model.UploadQuota{
+		User: quotaOf(userID),
+		Tenant: model.StorageQuota{
+			MaxBytes:   config.TenantQuotaMaxBytes(tenantID),
+			MaxObjects: config.TenantQuotaMaxObjects(tenantID),
+		},
+	}
+}
+
+func quotaOf(userID string) model.StorageQuota {
+	return model.StorageQuota{
+		MaxBytes:   config.QuotaMaxBytes(userID),
+		MaxObjects: config.QuotaMaxObjects(userID),
+	}
+}
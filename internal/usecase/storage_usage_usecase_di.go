@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"errors"
+
+	authPB "github.com/krobus00/auth-service/pb/auth"
+	"github.com/krobus00/storage-service/internal/model"
+)
+
+func (uc *storageUsageUsecase) InjectStorageUsageRepo(repo model.StorageUsageRepository) error {
+	if repo == nil {
+		return errors.New("invalid storage usage repository")
+	}
+	uc.storageUsageRepo = repo
+	return nil
+}
+
+func (uc *storageUsageUsecase) InjectObjectTypeRepo(repo model.ObjectTypeRepository) error {
+	if repo == nil {
+		return errors.New("invalid object type repository")
+	}
+	uc.objectTypeRepo = repo
+	return nil
+}
+
+func (uc *storageUsageUsecase) InjectAuthClient(client authPB.AuthServiceClient) error {
+	if client == nil {
+		return errors.New("invalid auth client")
+	}
+	uc.authClient = client
+	return nil
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	authMock "github.com/krobus00/auth-service/pb/auth/mock"
+	"github.com/krobus00/storage-service/internal/constant"
+	"github.com/krobus00/storage-service/internal/model"
+	"github.com/krobus00/storage-service/internal/model/mock"
+	"github.com/krobus00/storage-service/internal/utils"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_storageUsageUsecase_CheckQuota(t *testing.T) {
+	var (
+		userID  = utils.GenerateUUID()
+		otherID = utils.GenerateUUID()
+		usages  = []*model.StorageUsage{
+			{UserID: userID, TypeID: utils.GenerateUUID(), Bytes: 600, Objects: 2},
+			{UserID: userID, TypeID: utils.GenerateUUID(), Bytes: 300, Objects: 1},
+		}
+	)
+	type mockFindByUserID struct {
+		res []*model.StorageUsage
+		err error
+	}
+	tests := []struct {
+		name             string
+		userID           string
+		size             int64
+		config           map[string]any
+		mockFindByUserID *mockFindByUserID
+		wantErr          error
+	}{
+		{
+			name:    "success unlimited",
+			userID:  userID,
+			size:    1 << 30,
+			config:  map[string]any{},
+			wantErr: nil,
+		},
+		{
+			name:   "success within quota",
+			userID: userID,
+			size:   100,
+			config: map[string]any{
+				"quota.max_bytes":   1000,
+				"quota.max_objects": 4,
+			},
+			mockFindByUserID: &mockFindByUserID{res: usages},
+			wantErr:          nil,
+		},
+		{
+			name:   "error bytes exceeded",
+			userID: userID,
+			size:   101,
+			config: map[string]any{
+				"quota.max_bytes": 1000,
+			},
+			mockFindByUserID: &mockFindByUserID{res: usages},
+			wantErr:          model.ErrQuotaExceeded,
+		},
+		{
+			name:   "error objects exceeded",
+			userID: userID,
+			size:   1,
+			config: map[string]any{
+				"quota.max_objects": 3,
+			},
+			mockFindByUserID: &mockFindByUserID{res: usages},
+			wantErr:          model.ErrQuotaExceeded,
+		},
+		{
+			name:   "success override of another user does not apply",
+			userID: userID,
+			size:   100,
+			config: map[string]any{
+				"quota.max_bytes": 1000,
+				fmt.Sprintf("quota.overrides.%s.max_bytes", otherID): 1,
+			},
+			mockFindByUserID: &mockFindByUserID{res: usages},
+			wantErr:          nil,
+		},
+		{
+			name:   "error user override exceeded",
+			userID: userID,
+			size:   100,
+			config: map[string]any{
+				"quota.max_bytes": 0,
+				fmt.Sprintf("quota.overrides.%s.max_bytes", userID): 950,
+			},
+			mockFindByUserID: &mockFindByUserID{res: usages},
+			wantErr:          model.ErrQuotaExceeded,
+		},
+		{
+			name:   "error find usage",
+			userID: userID,
+			size:   100,
+			config: map[string]any{
+				"quota.max_bytes": 1000,
+			},
+			mockFindByUserID: &mockFindByUserID{err: errors.New("db error")},
+			wantErr:          errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			for key, value := range tt.config {
+				viper.Set(key, value)
+			}
+			defer viper.Set("quota", nil)
+
+			ctx := context.TODO()
+			storageUsageRepo := mock.NewMockStorageUsageRepository(ctrl)
+			if tt.mockFindByUserID != nil {
+				storageUsageRepo.EXPECT().
+					FindByUserID(gomock.Any(), tt.userID).
+					Times(1).
+					Return(tt.mockFindByUserID.res, tt.mockFindByUserID.err)
+			}
+
+			uc := NewStorageUsageUsecase()
+			utils.ContinueOrFatal(uc.InjectStorageUsageRepo(storageUsageRepo))
+
+			err := uc.CheckQuota(ctx, tt.userID, tt.size)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("storageUsageUsecase.CheckQuota() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_storageUsageUsecase_GetUsage(t *testing.T) {
+	var (
+		userID  = utils.GenerateUUID()
+		otherID = utils.GenerateUUID()
+		typeID  = utils.GenerateUUID()
+	)
+	type mockHasAccess struct {
+		res *wrapperspb.BoolValue
+		err error
+	}
+	tests := []struct {
+		name          string
+		sessionUserID string
+		userID        string
+		mockHasAccess *mockHasAccess
+		mockFind      bool
+		want          *model.UserStorageUsage
+		wantErr       error
+	}{
+		{
+			name:          "success own usage",
+			sessionUserID: userID,
+			userID:        "",
+			mockFind:      true,
+			want: &model.UserStorageUsage{
+				UserID:  userID,
+				Bytes:   1024,
+				Objects: 2,
+				Quota:   model.StorageQuota{MaxBytes: 4096},
+				Types: []*model.StorageUsage{
+					{UserID: userID, TypeID: typeID, Type: "image", Bytes: 1024, Objects: 2},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:          "success other user with permission",
+			sessionUserID: otherID,
+			userID:        userID,
+			mockHasAccess: &mockHasAccess{res: wrapperspb.Bool(true)},
+			mockFind:      true,
+			want: &model.UserStorageUsage{
+				UserID:  userID,
+				Bytes:   1024,
+				Objects: 2,
+				Quota:   model.StorageQuota{MaxBytes: 4096},
+				Types: []*model.StorageUsage{
+					{UserID: userID, TypeID: typeID, Type: "image", Bytes: 1024, Objects: 2},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:          "error other user without permission",
+			sessionUserID: otherID,
+			userID:        userID,
+			mockHasAccess: &mockHasAccess{res: wrapperspb.Bool(false)},
+			wantErr:       model.ErrUnauthorizeAccess,
+		},
+		{
+			name:          "error guest",
+			sessionUserID: constant.GuestID,
+			userID:        "",
+			wantErr:       model.ErrUnauthorizeAccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			viper.Set("quota.max_bytes", 4096)
+			defer viper.Set("quota", nil)
+
+			ctx := context.WithValue(context.TODO(), constant.KeyUserIDCtx, tt.sessionUserID)
+
+			storageUsageRepo := mock.NewMockStorageUsageRepository(ctrl)
+			objectTypeRepo := mock.NewMockObjectTypeRepository(ctrl)
+			authClient := authMock.NewMockAuthServiceClient(ctrl)
+
+			if tt.mockHasAccess != nil {
+				authClient.EXPECT().
+					HasAccess(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tt.mockHasAccess.res, tt.mockHasAccess.err)
+			}
+			if tt.mockFind {
+				storageUsageRepo.EXPECT().
+					FindByUserID(gomock.Any(), userID).
+					Times(1).
+					Return([]*model.StorageUsage{
+						{UserID: userID, TypeID: typeID, Bytes: 1024, Objects: 2},
+					}, nil)
+				objectTypeRepo.EXPECT().
+					FindByID(gomock.Any(), typeID).
+					Times(1).
+					Return(&model.ObjectType{ID: typeID, Name: "image"}, nil)
+			}
+
+			uc := NewStorageUsageUsecase()
+			utils.ContinueOrFatal(uc.InjectStorageUsageRepo(storageUsageRepo))
+			utils.ContinueOrFatal(uc.InjectObjectTypeRepo(objectTypeRepo))
+			utils.ContinueOrFatal(uc.InjectAuthClient(authClient))
+
+			got, err := uc.GetUsage(ctx, tt.userID)
+			if err != tt.wantErr {
+				t.Errorf("storageUsageUsecase.GetUsage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storageUsageUsecase.GetUsage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+type detachedContext struct {
+	parent context.Context
+}
+
+// NewDetachedContext keeps the values of ctx but not its deadline or cancellation,
+// for cleanups that have to finish after the request is gone.
+func NewDetachedContext(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+// SleepContext waits for d unless ctx is done first.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
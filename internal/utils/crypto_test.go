@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealOpenAESGCM(t *testing.T) {
+	key, err := NewDataKey()
+	assert.NoError(t, err)
+	otherKey, err := NewDataKey()
+	assert.NoError(t, err)
+	plaintext := []byte("id document")
+
+	sealed, err := SealAESGCM(key, plaintext)
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(sealed, plaintext))
+
+	resealed, err := SealAESGCM(key, plaintext)
+	assert.NoError(t, err)
+	assert.NotEqual(t, sealed, resealed, "every seal uses a fresh nonce")
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		name    string
+		key     []byte
+		sealed  []byte
+		want    []byte
+		wantErr error
+	}{
+		{
+			name:   "success",
+			key:    key,
+			sealed: sealed,
+			want:   plaintext,
+		},
+		{
+			name:    "error wrong key",
+			key:     otherKey,
+			sealed:  sealed,
+			wantErr: ErrInvalidCiphertext,
+		},
+		{
+			name:    "error tampered",
+			key:     key,
+			sealed:  tampered,
+			wantErr: ErrInvalidCiphertext,
+		},
+		{
+			name:    "error shorter than the nonce",
+			key:     key,
+			sealed:  sealed[:4],
+			wantErr: ErrInvalidCiphertext,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenAESGCM(tt.key, tt.sealed)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = SealAESGCM(key[:10], plaintext)
+	assert.Error(t, err, "invalid key size")
+}
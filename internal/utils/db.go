@@ -11,6 +11,12 @@ func NewTxContext(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, constant.KeyDBCtx, tx)
 }
 
+// HasTxInContext reports whether ctx carries a transaction.
+func HasTxInContext(ctx context.Context) bool {
+	_, ok := ctx.Value(constant.KeyDBCtx).(*gorm.DB)
+	return ok
+}
+
 func GetTxFromContext(ctx context.Context, defaultTx *gorm.DB) *gorm.DB {
 	txVal := ctx.Value(constant.KeyDBCtx)
 	tx, ok := txVal.(*gorm.DB)
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts grpc metadata to a propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c MetadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// NATSHeaderCarrier adapts nats message headers to a propagation.TextMapCarrier.
+type NATSHeaderCarrier nats.Header
+
+func (c NATSHeaderCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c NATSHeaderCarrier) Set(key string, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c NATSHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func InjectTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+func ExtractTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/krobus00/storage-service/internal/constant"
+)
+
+func NewTenantContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, constant.KeyTenantIDCtx, tenantID)
+}
+
+// GetTenantIDFromContext returns the tenant of the request, requests without one
+// belong to the default tenant.
+func GetTenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(constant.KeyTenantIDCtx).(string)
+	if tenantID == "" {
+		return constant.DefaultTenantID
+	}
+	return tenantID
+}
@@ -37,6 +37,26 @@ func (m *MockStorageServiceClient) EXPECT() *MockStorageServiceClientMockRecorde
 	return m.recorder
 }
 
+// AddReference mocks base method.
+func (m *MockStorageServiceClient) AddReference(arg0 context.Context, arg1 *storage.ObjectReferenceRequest, arg2 ...grpc.CallOption) (*storage.ObjectReference, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddReference", varargs...)
+	ret0, _ := ret[0].(*storage.ObjectReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReference indicates an expected call of AddReference.
+func (mr *MockStorageServiceClientMockRecorder) AddReference(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReference", reflect.TypeOf((*MockStorageServiceClient)(nil).AddReference), varargs...)
+}
+
 // DeleteObjectByID mocks base method.
 func (m *MockStorageServiceClient) DeleteObjectByID(arg0 context.Context, arg1 *storage.DeleteObjectByIDRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -76,3 +96,183 @@ func (mr *MockStorageServiceClientMockRecorder) GetObjectByID(arg0, arg1 interfa
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectByID", reflect.TypeOf((*MockStorageServiceClient)(nil).GetObjectByID), varargs...)
 }
+
+// GetUsage mocks base method.
+func (m *MockStorageServiceClient) GetUsage(arg0 context.Context, arg1 *storage.GetUsageRequest, arg2 ...grpc.CallOption) (*storage.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUsage", varargs...)
+	ret0, _ := ret[0].(*storage.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockStorageServiceClientMockRecorder) GetUsage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockStorageServiceClient)(nil).GetUsage), varargs...)
+}
+
+// GrantObjectAccess mocks base method.
+func (m *MockStorageServiceClient) GrantObjectAccess(arg0 context.Context, arg1 *storage.GrantObjectAccessRequest, arg2 ...grpc.CallOption) (*storage.ObjectGrant, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GrantObjectAccess", varargs...)
+	ret0, _ := ret[0].(*storage.ObjectGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantObjectAccess indicates an expected call of GrantObjectAccess.
+func (mr *MockStorageServiceClientMockRecorder) GrantObjectAccess(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantObjectAccess", reflect.TypeOf((*MockStorageServiceClient)(nil).GrantObjectAccess), varargs...)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockStorageServiceClient) ListAuditLogs(arg0 context.Context, arg1 *storage.ListAuditLogsRequest, arg2 ...grpc.CallOption) (*storage.ListAuditLogsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAuditLogs", varargs...)
+	ret0, _ := ret[0].(*storage.ListAuditLogsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockStorageServiceClientMockRecorder) ListAuditLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockStorageServiceClient)(nil).ListAuditLogs), varargs...)
+}
+
+// ListObjectGrants mocks base method.
+func (m *MockStorageServiceClient) ListObjectGrants(arg0 context.Context, arg1 *storage.ListObjectGrantsRequest, arg2 ...grpc.CallOption) (*storage.ListObjectGrantsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListObjectGrants", varargs...)
+	ret0, _ := ret[0].(*storage.ListObjectGrantsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectGrants indicates an expected call of ListObjectGrants.
+func (mr *MockStorageServiceClientMockRecorder) ListObjectGrants(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectGrants", reflect.TypeOf((*MockStorageServiceClient)(nil).ListObjectGrants), varargs...)
+}
+
+// ListObjects mocks base method.
+func (m *MockStorageServiceClient) ListObjects(arg0 context.Context, arg1 *storage.ListObjectsRequest, arg2 ...grpc.CallOption) (*storage.ListObjectsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListObjects", varargs...)
+	ret0, _ := ret[0].(*storage.ListObjectsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockStorageServiceClientMockRecorder) ListObjects(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockStorageServiceClient)(nil).ListObjects), varargs...)
+}
+
+// RemoveReference mocks base method.
+func (m *MockStorageServiceClient) RemoveReference(arg0 context.Context, arg1 *storage.ObjectReferenceRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveReference", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReference indicates an expected call of RemoveReference.
+func (mr *MockStorageServiceClientMockRecorder) RemoveReference(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReference", reflect.TypeOf((*MockStorageServiceClient)(nil).RemoveReference), varargs...)
+}
+
+// RevokeObjectAccess mocks base method.
+func (m *MockStorageServiceClient) RevokeObjectAccess(arg0 context.Context, arg1 *storage.RevokeObjectAccessRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeObjectAccess", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeObjectAccess indicates an expected call of RevokeObjectAccess.
+func (mr *MockStorageServiceClientMockRecorder) RevokeObjectAccess(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeObjectAccess", reflect.TypeOf((*MockStorageServiceClient)(nil).RevokeObjectAccess), varargs...)
+}
+
+// UpdateObject mocks base method.
+func (m *MockStorageServiceClient) UpdateObject(arg0 context.Context, arg1 *storage.UpdateObjectRequest, arg2 ...grpc.CallOption) (*storage.Object, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateObject", varargs...)
+	ret0, _ := ret[0].(*storage.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateObject indicates an expected call of UpdateObject.
+func (mr *MockStorageServiceClientMockRecorder) UpdateObject(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateObject", reflect.TypeOf((*MockStorageServiceClient)(nil).UpdateObject), varargs...)
+}
+
+// UploadObject mocks base method.
+func (m *MockStorageServiceClient) UploadObject(arg0 context.Context, arg1 *storage.UploadObjectRequest, arg2 ...grpc.CallOption) (*storage.Object, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadObject", varargs...)
+	ret0, _ := ret[0].(*storage.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadObject indicates an expected call of UploadObject.
+func (mr *MockStorageServiceClientMockRecorder) UploadObject(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadObject", reflect.TypeOf((*MockStorageServiceClient)(nil).UploadObject), varargs...)
+}
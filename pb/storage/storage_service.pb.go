@@ -29,7 +29,7 @@ var file_pb_storage_storage_service_proto_rawDesc = []byte{
 	0x70, 0x62, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61,
 	0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xac, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xdb, 0x07, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
 	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4f,
 	0x62, 0x6a, 0x65, 0x63, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x73,
 	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
@@ -40,26 +40,112 @@ var file_pb_storage_storage_service_proto_rawDesc = []byte{
 	0x67, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x42,
 	0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x70, 0x62, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1f, 0x2e, 0x70, 0x62,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70,
+	0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x22, 0x00, 0x12, 0x50, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x12, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x11, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x12, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x12, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x5f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x47,
+	0x72, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x47, 0x72, 0x61,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x62, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x51, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x20, 0x2e, 0x70,
+	0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x70, 0x62, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x70, 0x62, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_pb_storage_storage_service_proto_goTypes = []interface{}{
-	(*GetObjectByIDRequest)(nil),    // 0: pb.storage.GetObjectByIDRequest
-	(*DeleteObjectByIDRequest)(nil), // 1: pb.storage.DeleteObjectByIDRequest
-	(*Object)(nil),                  // 2: pb.storage.Object
-	(*emptypb.Empty)(nil),           // 3: google.protobuf.Empty
+	(*GetObjectByIDRequest)(nil),      // 0: pb.storage.GetObjectByIDRequest
+	(*DeleteObjectByIDRequest)(nil),   // 1: pb.storage.DeleteObjectByIDRequest
+	(*UploadObjectRequest)(nil),       // 2: pb.storage.UploadObjectRequest
+	(*UpdateObjectRequest)(nil),       // 3: pb.storage.UpdateObjectRequest
+	(*ListObjectsRequest)(nil),        // 4: pb.storage.ListObjectsRequest
+	(*GrantObjectAccessRequest)(nil),  // 5: pb.storage.GrantObjectAccessRequest
+	(*RevokeObjectAccessRequest)(nil), // 6: pb.storage.RevokeObjectAccessRequest
+	(*ListObjectGrantsRequest)(nil),   // 7: pb.storage.ListObjectGrantsRequest
+	(*ObjectReferenceRequest)(nil),    // 8: pb.storage.ObjectReferenceRequest
+	(*GetUsageRequest)(nil),           // 9: pb.storage.GetUsageRequest
+	(*ListAuditLogsRequest)(nil),      // 10: pb.storage.ListAuditLogsRequest
+	(*Object)(nil),                    // 11: pb.storage.Object
+	(*emptypb.Empty)(nil),             // 12: google.protobuf.Empty
+	(*ListObjectsResponse)(nil),       // 13: pb.storage.ListObjectsResponse
+	(*ObjectGrant)(nil),               // 14: pb.storage.ObjectGrant
+	(*ListObjectGrantsResponse)(nil),  // 15: pb.storage.ListObjectGrantsResponse
+	(*ObjectReference)(nil),           // 16: pb.storage.ObjectReference
+	(*StorageUsage)(nil),              // 17: pb.storage.StorageUsage
+	(*ListAuditLogsResponse)(nil),     // 18: pb.storage.ListAuditLogsResponse
 }
 var file_pb_storage_storage_service_proto_depIdxs = []int32{
-	0, // 0: pb.storage.StorageService.GetObjectByID:input_type -> pb.storage.GetObjectByIDRequest
-	1, // 1: pb.storage.StorageService.DeleteObjectByID:input_type -> pb.storage.DeleteObjectByIDRequest
-	2, // 2: pb.storage.StorageService.GetObjectByID:output_type -> pb.storage.Object
-	3, // 3: pb.storage.StorageService.DeleteObjectByID:output_type -> google.protobuf.Empty
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.storage.StorageService.GetObjectByID:input_type -> pb.storage.GetObjectByIDRequest
+	1,  // 1: pb.storage.StorageService.DeleteObjectByID:input_type -> pb.storage.DeleteObjectByIDRequest
+	2,  // 2: pb.storage.StorageService.UploadObject:input_type -> pb.storage.UploadObjectRequest
+	3,  // 3: pb.storage.StorageService.UpdateObject:input_type -> pb.storage.UpdateObjectRequest
+	4,  // 4: pb.storage.StorageService.ListObjects:input_type -> pb.storage.ListObjectsRequest
+	5,  // 5: pb.storage.StorageService.GrantObjectAccess:input_type -> pb.storage.GrantObjectAccessRequest
+	6,  // 6: pb.storage.StorageService.RevokeObjectAccess:input_type -> pb.storage.RevokeObjectAccessRequest
+	7,  // 7: pb.storage.StorageService.ListObjectGrants:input_type -> pb.storage.ListObjectGrantsRequest
+	8,  // 8: pb.storage.StorageService.AddReference:input_type -> pb.storage.ObjectReferenceRequest
+	8,  // 9: pb.storage.StorageService.RemoveReference:input_type -> pb.storage.ObjectReferenceRequest
+	9,  // 10: pb.storage.StorageService.GetUsage:input_type -> pb.storage.GetUsageRequest
+	10, // 11: pb.storage.StorageService.ListAuditLogs:input_type -> pb.storage.ListAuditLogsRequest
+	11, // 12: pb.storage.StorageService.GetObjectByID:output_type -> pb.storage.Object
+	12, // 13: pb.storage.StorageService.DeleteObjectByID:output_type -> google.protobuf.Empty
+	11, // 14: pb.storage.StorageService.UploadObject:output_type -> pb.storage.Object
+	11, // 15: pb.storage.StorageService.UpdateObject:output_type -> pb.storage.Object
+	13, // 16: pb.storage.StorageService.ListObjects:output_type -> pb.storage.ListObjectsResponse
+	14, // 17: pb.storage.StorageService.GrantObjectAccess:output_type -> pb.storage.ObjectGrant
+	12, // 18: pb.storage.StorageService.RevokeObjectAccess:output_type -> google.protobuf.Empty
+	15, // 19: pb.storage.StorageService.ListObjectGrants:output_type -> pb.storage.ListObjectGrantsResponse
+	16, // 20: pb.storage.StorageService.AddReference:output_type -> pb.storage.ObjectReference
+	12, // 21: pb.storage.StorageService.RemoveReference:output_type -> google.protobuf.Empty
+	17, // 22: pb.storage.StorageService.GetUsage:output_type -> pb.storage.StorageUsage
+	18, // 23: pb.storage.StorageService.ListAuditLogs:output_type -> pb.storage.ListAuditLogsResponse
+	12, // [12:24] is the sub-list for method output_type
+	0,  // [0:12] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_pb_storage_storage_service_proto_init() }
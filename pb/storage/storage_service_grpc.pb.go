@@ -20,8 +20,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	StorageService_GetObjectByID_FullMethodName    = "/pb.storage.StorageService/GetObjectByID"
-	StorageService_DeleteObjectByID_FullMethodName = "/pb.storage.StorageService/DeleteObjectByID"
+	StorageService_GetObjectByID_FullMethodName      = "/pb.storage.StorageService/GetObjectByID"
+	StorageService_DeleteObjectByID_FullMethodName   = "/pb.storage.StorageService/DeleteObjectByID"
+	StorageService_UploadObject_FullMethodName       = "/pb.storage.StorageService/UploadObject"
+	StorageService_UpdateObject_FullMethodName       = "/pb.storage.StorageService/UpdateObject"
+	StorageService_ListObjects_FullMethodName        = "/pb.storage.StorageService/ListObjects"
+	StorageService_GrantObjectAccess_FullMethodName  = "/pb.storage.StorageService/GrantObjectAccess"
+	StorageService_RevokeObjectAccess_FullMethodName = "/pb.storage.StorageService/RevokeObjectAccess"
+	StorageService_ListObjectGrants_FullMethodName   = "/pb.storage.StorageService/ListObjectGrants"
+	StorageService_AddReference_FullMethodName       = "/pb.storage.StorageService/AddReference"
+	StorageService_RemoveReference_FullMethodName    = "/pb.storage.StorageService/RemoveReference"
+	StorageService_GetUsage_FullMethodName           = "/pb.storage.StorageService/GetUsage"
+	StorageService_ListAuditLogs_FullMethodName      = "/pb.storage.StorageService/ListAuditLogs"
 )
 
 // StorageServiceClient is the client API for StorageService service.
@@ -30,6 +40,16 @@ const (
 type StorageServiceClient interface {
 	GetObjectByID(ctx context.Context, in *GetObjectByIDRequest, opts ...grpc.CallOption) (*Object, error)
 	DeleteObjectByID(ctx context.Context, in *DeleteObjectByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UploadObject(ctx context.Context, in *UploadObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	UpdateObject(ctx context.Context, in *UpdateObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+	GrantObjectAccess(ctx context.Context, in *GrantObjectAccessRequest, opts ...grpc.CallOption) (*ObjectGrant, error)
+	RevokeObjectAccess(ctx context.Context, in *RevokeObjectAccessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListObjectGrants(ctx context.Context, in *ListObjectGrantsRequest, opts ...grpc.CallOption) (*ListObjectGrantsResponse, error)
+	AddReference(ctx context.Context, in *ObjectReferenceRequest, opts ...grpc.CallOption) (*ObjectReference, error)
+	RemoveReference(ctx context.Context, in *ObjectReferenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*StorageUsage, error)
+	ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error)
 }
 
 type storageServiceClient struct {
@@ -58,12 +78,112 @@ func (c *storageServiceClient) DeleteObjectByID(ctx context.Context, in *DeleteO
 	return out, nil
 }
 
+func (c *storageServiceClient) UploadObject(ctx context.Context, in *UploadObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, StorageService_UploadObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) UpdateObject(ctx context.Context, in *UpdateObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, StorageService_UpdateObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, StorageService_ListObjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) GrantObjectAccess(ctx context.Context, in *GrantObjectAccessRequest, opts ...grpc.CallOption) (*ObjectGrant, error) {
+	out := new(ObjectGrant)
+	err := c.cc.Invoke(ctx, StorageService_GrantObjectAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RevokeObjectAccess(ctx context.Context, in *RevokeObjectAccessRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StorageService_RevokeObjectAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ListObjectGrants(ctx context.Context, in *ListObjectGrantsRequest, opts ...grpc.CallOption) (*ListObjectGrantsResponse, error) {
+	out := new(ListObjectGrantsResponse)
+	err := c.cc.Invoke(ctx, StorageService_ListObjectGrants_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) AddReference(ctx context.Context, in *ObjectReferenceRequest, opts ...grpc.CallOption) (*ObjectReference, error) {
+	out := new(ObjectReference)
+	err := c.cc.Invoke(ctx, StorageService_AddReference_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RemoveReference(ctx context.Context, in *ObjectReferenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StorageService_RemoveReference_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*StorageUsage, error) {
+	out := new(StorageUsage)
+	err := c.cc.Invoke(ctx, StorageService_GetUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ListAuditLogs(ctx context.Context, in *ListAuditLogsRequest, opts ...grpc.CallOption) (*ListAuditLogsResponse, error) {
+	out := new(ListAuditLogsResponse)
+	err := c.cc.Invoke(ctx, StorageService_ListAuditLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 // All implementations must embed UnimplementedStorageServiceServer
 // for forward compatibility
 type StorageServiceServer interface {
 	GetObjectByID(context.Context, *GetObjectByIDRequest) (*Object, error)
 	DeleteObjectByID(context.Context, *DeleteObjectByIDRequest) (*emptypb.Empty, error)
+	UploadObject(context.Context, *UploadObjectRequest) (*Object, error)
+	UpdateObject(context.Context, *UpdateObjectRequest) (*Object, error)
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	GrantObjectAccess(context.Context, *GrantObjectAccessRequest) (*ObjectGrant, error)
+	RevokeObjectAccess(context.Context, *RevokeObjectAccessRequest) (*emptypb.Empty, error)
+	ListObjectGrants(context.Context, *ListObjectGrantsRequest) (*ListObjectGrantsResponse, error)
+	AddReference(context.Context, *ObjectReferenceRequest) (*ObjectReference, error)
+	RemoveReference(context.Context, *ObjectReferenceRequest) (*emptypb.Empty, error)
+	GetUsage(context.Context, *GetUsageRequest) (*StorageUsage, error)
+	ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error)
 	mustEmbedUnimplementedStorageServiceServer()
 }
 
@@ -77,6 +197,36 @@ func (UnimplementedStorageServiceServer) GetObjectByID(context.Context, *GetObje
 func (UnimplementedStorageServiceServer) DeleteObjectByID(context.Context, *DeleteObjectByIDRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteObjectByID not implemented")
 }
+func (UnimplementedStorageServiceServer) UploadObject(context.Context, *UploadObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadObject not implemented")
+}
+func (UnimplementedStorageServiceServer) UpdateObject(context.Context, *UpdateObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateObject not implemented")
+}
+func (UnimplementedStorageServiceServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (UnimplementedStorageServiceServer) GrantObjectAccess(context.Context, *GrantObjectAccessRequest) (*ObjectGrant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantObjectAccess not implemented")
+}
+func (UnimplementedStorageServiceServer) RevokeObjectAccess(context.Context, *RevokeObjectAccessRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeObjectAccess not implemented")
+}
+func (UnimplementedStorageServiceServer) ListObjectGrants(context.Context, *ListObjectGrantsRequest) (*ListObjectGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjectGrants not implemented")
+}
+func (UnimplementedStorageServiceServer) AddReference(context.Context, *ObjectReferenceRequest) (*ObjectReference, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReference not implemented")
+}
+func (UnimplementedStorageServiceServer) RemoveReference(context.Context, *ObjectReferenceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReference not implemented")
+}
+func (UnimplementedStorageServiceServer) GetUsage(context.Context, *GetUsageRequest) (*StorageUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedStorageServiceServer) ListAuditLogs(context.Context, *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLogs not implemented")
+}
 func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
 
 // UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -126,6 +276,186 @@ func _StorageService_DeleteObjectByID_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_UploadObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).UploadObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_UploadObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).UploadObject(ctx, req.(*UploadObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_UpdateObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).UpdateObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_UpdateObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).UpdateObject(ctx, req.(*UpdateObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_ListObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ListObjects(ctx, req.(*ListObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_GrantObjectAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantObjectAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).GrantObjectAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_GrantObjectAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).GrantObjectAccess(ctx, req.(*GrantObjectAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_RevokeObjectAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeObjectAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).RevokeObjectAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_RevokeObjectAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).RevokeObjectAccess(ctx, req.(*RevokeObjectAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ListObjectGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ListObjectGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_ListObjectGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ListObjectGrants(ctx, req.(*ListObjectGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_AddReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).AddReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_AddReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).AddReference(ctx, req.(*ObjectReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_RemoveReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).RemoveReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_RemoveReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).RemoveReference(ctx, req.(*ObjectReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ListAuditLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ListAuditLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_ListAuditLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ListAuditLogs(ctx, req.(*ListAuditLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -141,6 +471,46 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteObjectByID",
 			Handler:    _StorageService_DeleteObjectByID_Handler,
 		},
+		{
+			MethodName: "UploadObject",
+			Handler:    _StorageService_UploadObject_Handler,
+		},
+		{
+			MethodName: "UpdateObject",
+			Handler:    _StorageService_UpdateObject_Handler,
+		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _StorageService_ListObjects_Handler,
+		},
+		{
+			MethodName: "GrantObjectAccess",
+			Handler:    _StorageService_GrantObjectAccess_Handler,
+		},
+		{
+			MethodName: "RevokeObjectAccess",
+			Handler:    _StorageService_RevokeObjectAccess_Handler,
+		},
+		{
+			MethodName: "ListObjectGrants",
+			Handler:    _StorageService_ListObjectGrants_Handler,
+		},
+		{
+			MethodName: "AddReference",
+			Handler:    _StorageService_AddReference_Handler,
+		},
+		{
+			MethodName: "RemoveReference",
+			Handler:    _StorageService_RemoveReference_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _StorageService_GetUsage_Handler,
+		},
+		{
+			MethodName: "ListAuditLogs",
+			Handler:    _StorageService_ListAuditLogs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/storage/storage_service.proto",